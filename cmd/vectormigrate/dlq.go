@@ -0,0 +1,293 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"github.com/AlphaTechini/vector-db-migration/internal/adapters"
+	"github.com/AlphaTechini/vector-db-migration/internal/orchestrator"
+	"github.com/AlphaTechini/vector-db-migration/internal/state"
+	"github.com/spf13/cobra"
+)
+
+var (
+	dlqLimit  int
+	dlqOffset int
+	dlqOutput string
+
+	dlqCmd = &cobra.Command{
+		Use:   "dlq",
+		Short: "Work through dead-lettered records",
+		Long:  "List, export and retry records a migration set aside because they could not be mapped or written.",
+	}
+
+	dlqListCmd = &cobra.Command{
+		Use:   "list [migration-id]",
+		Short: "List dead-lettered records",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runDLQList,
+	}
+
+	dlqExportCmd = &cobra.Command{
+		Use:   "export [migration-id]",
+		Short: "Export dead-lettered records as JSON Lines",
+		Long:  "Write dead-lettered records as JSON Lines, one record per line. The file can be fixed up and migrated with --source-type jsonl.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runDLQExport,
+	}
+
+	dlqRetryCmd = &cobra.Command{
+		Use:   "retry [migration-id]",
+		Short: "Write records that failed to upsert to the target again",
+		Long:  "Write records that failed to upsert to the target again, removing the ones that succeed. Pass the migration's --namespaces and --namespace-route flags to write namespaced records where the migration routed them. Records that failed to map must be exported and fixed.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runDLQRetry,
+	}
+)
+
+func init() {
+	dlqListCmd.Flags().IntVar(&dlqLimit, "limit", 50, "Maximum records to list (0 lists all)")
+	dlqListCmd.Flags().IntVar(&dlqOffset, "offset", 0, "Records to skip")
+
+	dlqExportCmd.Flags().StringVar(&dlqOutput, "output", "-", "File to write (- for stdout)")
+
+	dlqRetryCmd.Flags().StringVar(&targetType, "target-type", "", "Target database type")
+	dlqRetryCmd.Flags().StringVar(&targetURL, "target-url", "", "Target database URL")
+	dlqRetryCmd.Flags().StringVar(&targetAPIKey, "target-api-key", "", "Target database API key")
+	dlqRetryCmd.Flags().StringVar(&targetIndex, "target-index", "", "Target index/collection name")
+	dlqRetryCmd.Flags().StringToStringVar(&targetExtra, "target-extra", nil, "Provider-specific target settings (key=value)")
+	dlqRetryCmd.Flags().IntVar(&batchSize, "batch-size", 100, "Number of records per batch")
+	dlqRetryCmd.Flags().IntVar(&maxRetries, "max-retries", 3, "Maximum retries of a failed target call (timeouts, 429 and 5xx responses)")
+	dlqRetryCmd.Flags().DurationVar(&retryBackoff, "retry-backoff", 500*time.Millisecond, "Wait before the first retry; doubles per retry up to 30s")
+	dlqRetryCmd.Flags().StringSliceVar(&namespaces, "namespaces", nil, "Namespaces the migration was run with")
+	dlqRetryCmd.Flags().StringToStringVar(&namespaceRoutes, "namespace-route", nil, "Per-namespace routing the migration was run with")
+	dlqRetryCmd.Flags().StringVar(&defaultRoute, "namespace-default-route", "field:namespace", "Routing for namespaces without --namespace-route")
+	dlqRetryCmd.MarkFlagRequired("target-type")
+	dlqRetryCmd.MarkFlagRequired("target-url")
+	dlqRetryCmd.MarkFlagRequired("target-index")
+
+	dlqCmd.AddCommand(dlqListCmd)
+	dlqCmd.AddCommand(dlqExportCmd)
+	dlqCmd.AddCommand(dlqRetryCmd)
+}
+
+// openDeadLetters opens the state tracker's dead-letter store
+func openDeadLetters() (state.StateTracker, state.DeadLetterStore, error) {
+	tracker, err := createStateTracker("")
+	if err != nil {
+		return nil, nil, err
+	}
+	store, ok := tracker.(state.DeadLetterStore)
+	if !ok {
+		tracker.Close()
+		return nil, nil, fmt.Errorf("state tracker does not keep dead letters")
+	}
+	return tracker, store, nil
+}
+
+func runDLQList(cmd *cobra.Command, args []string) error {
+	migrationID := args[0]
+
+	tracker, store, err := openDeadLetters()
+	if err != nil {
+		return err
+	}
+	defer tracker.Close()
+
+	letters, err := store.ListDeadLetters(migrationID, dlqLimit, dlqOffset)
+	if err != nil {
+		return err
+	}
+	if len(letters) == 0 {
+		fmt.Printf("No dead-lettered records for migration %s\n", migrationID)
+		return nil
+	}
+
+	fmt.Printf("Dead-lettered records for migration %s:\n", migrationID)
+	for _, letter := range letters {
+		id := letter.RecordID
+		if letter.Namespace != "" {
+			id = letter.Namespace + "/" + id
+		}
+		fmt.Printf("  %s  %-6s  %s  %s\n", letter.CreatedAt.Format("2006-01-02 15:04:05"), letter.Stage, id, letter.Error)
+	}
+	return nil
+}
+
+func runDLQExport(cmd *cobra.Command, args []string) error {
+	migrationID := args[0]
+
+	tracker, store, err := openDeadLetters()
+	if err != nil {
+		return err
+	}
+	defer tracker.Close()
+
+	letters, err := store.ListDeadLetters(migrationID, 0, 0)
+	if err != nil {
+		return err
+	}
+
+	var out io.Writer = os.Stdout
+	if dlqOutput != "-" {
+		file, err := os.Create(dlqOutput)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", dlqOutput, err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	w := bufio.NewWriter(out)
+	for _, letter := range letters {
+		if _, err := fmt.Fprintf(w, "%s\n", letter.Record); err != nil {
+			return fmt.Errorf("failed to write record %s: %w", letter.RecordID, err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to write records: %w", err)
+	}
+
+	if dlqOutput != "-" {
+		log.Printf("✅ Exported %d records to %s", len(letters), dlqOutput)
+	}
+	return nil
+}
+
+func runDLQRetry(cmd *cobra.Command, args []string) error {
+	migrationID := args[0]
+	ctx := cmd.Context()
+
+	if err := validateDatabaseType(targetType); err != nil {
+		return fmt.Errorf("invalid target type: %w", err)
+	}
+
+	tracker, store, err := openDeadLetters()
+	if err != nil {
+		return err
+	}
+	defer tracker.Close()
+
+	letters, err := store.ListDeadLetters(migrationID, 0, 0)
+	if err != nil {
+		return err
+	}
+
+	var pending []state.DeadLetter
+	var records []adapters.Record
+	skipped := 0
+	for _, letter := range letters {
+		if letter.Stage != state.DeadLetterUpsert {
+			skipped++
+			continue
+		}
+		var record adapters.Record
+		if err := json.Unmarshal(letter.Record, &record); err != nil {
+			return fmt.Errorf("failed to decode record %s: %w", letter.RecordID, err)
+		}
+		pending = append(pending, letter)
+		records = append(records, record)
+	}
+	if len(pending) == 0 {
+		log.Printf("No records to retry (%d failed to map; export and fix them)", skipped)
+		return nil
+	}
+
+	routing, err := namespaceRouting()
+	if err != nil {
+		return err
+	}
+
+	// Group the records by namespace, keeping the order they failed in
+	var order []string
+	groups := make(map[string][]int)
+	for i, letter := range pending {
+		if letter.Namespace != "" && routing == nil {
+			return fmt.Errorf("record %s is from namespace %s; pass the migration's --namespaces and --namespace-route flags", letter.RecordID, letter.Namespace)
+		}
+		if _, ok := groups[letter.Namespace]; !ok {
+			order = append(order, letter.Namespace)
+		}
+		groups[letter.Namespace] = append(groups[letter.Namespace], i)
+	}
+
+	targetDB, err := createDatabase(targetType, targetURL, targetAPIKey, targetIndex, 30, targetExtra)
+	if err != nil {
+		return err
+	}
+	defer targetDB.Close()
+
+	log.Printf("🔁 Retrying %d records for migration %s", len(pending), migrationID)
+
+	if batchSize < 1 {
+		batchSize = 1
+	}
+	policy := orchestrator.RetryPolicy{MaxRetries: maxRetries, Backoff: retryBackoff}
+	upsert := func(target adapters.Database, records []adapters.Record) error {
+		return orchestrator.Retry(ctx, policy, func() error {
+			return target.UpsertBatch(ctx, records)
+		})
+	}
+
+	var written, failed []state.DeadLetter
+	for _, namespace := range order {
+		target := targetDB
+		if namespace != "" {
+			route := routing.RouteFor(namespace)
+			if target, err = routing.Target(route, targetDB); err != nil {
+				return fmt.Errorf("failed to open target for namespace %s: %w", namespace, err)
+			}
+		}
+
+		indexes := groups[namespace]
+		for start := 0; start < len(indexes); start += batchSize {
+			end := start + batchSize
+			if end > len(indexes) {
+				end = len(indexes)
+			}
+			batch := make([]adapters.Record, 0, end-start)
+			for _, i := range indexes[start:end] {
+				batch = append(batch, records[i])
+			}
+
+			// Write the batch, then record by record if the batch fails
+			if err := upsert(target, batch); err == nil {
+				for _, i := range indexes[start:end] {
+					written = append(written, pending[i])
+				}
+				continue
+			}
+			for _, i := range indexes[start:end] {
+				if err := upsert(target, records[i:i+1]); err != nil {
+					letter := pending[i]
+					letter.Error = err.Error()
+					failed = append(failed, letter)
+					continue
+				}
+				written = append(written, pending[i])
+			}
+		}
+
+		if target != targetDB {
+			target.Close()
+		}
+	}
+
+	if err := store.DeleteDeadLetters(written); err != nil {
+		return err
+	}
+	if err := store.AddDeadLetters(failed); err != nil {
+		return err
+	}
+
+	log.Printf("✅ Wrote %d records; %d still failing", len(written), len(failed))
+	if skipped > 0 {
+		log.Printf("   %d records failed to map; export and fix them", skipped)
+	}
+	return nil
+}
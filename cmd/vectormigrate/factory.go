@@ -11,13 +11,14 @@ import (
 )
 
 // createDatabase creates a database adapter based on type
-func createDatabase(dbType, url, apiKey, index string, timeout int) (adapters.Database, error) {
+func createDatabase(dbType, url, apiKey, index string, timeout int, extra map[string]string) (adapters.Database, error) {
 	config := adapters.DBConfig{
 		Type:    dbType,
 		URL:     url,
 		APIKey:  apiKey,
 		Index:   index,
 		Timeout: timeout,
+		Extra:   extra,
 	}
 
 	ctx := context.Background()
@@ -44,44 +45,67 @@ func createDatabase(dbType, url, apiKey, index string, timeout int) (adapters.Da
 		}
 		return adapter, nil
 
-	default:
-		return nil, fmt.Errorf("unsupported database type: %s", dbType)
-	}
-}
-
-// createMapper creates a schema mapper based on source/target types
-func createMapper(sourceType, targetType string) (mapper.SchemaMapper, error) {
-	key := sourceType + "_to_" + targetType
+	case "milvus":
+		adapter := &adapters.MilvusAdapter{}
+		if err := adapter.Connect(ctx, config); err != nil {
+			return nil, fmt.Errorf("failed to connect to Milvus: %w", err)
+		}
+		return adapter, nil
 
-	switch key {
-	case "pinecone_to_qdrant":
-		return mapper.NewPineconeQdrantMapper(), nil
+	case "pgvector":
+		adapter := &adapters.PgvectorAdapter{}
+		if err := adapter.Connect(ctx, config); err != nil {
+			return nil, fmt.Errorf("failed to connect to pgvector: %w", err)
+		}
+		return adapter, nil
 
-	case "qdrant_to_pinecone":
-		// TODO: Implement QdrantToPineconeMapper
-		return nil, fmt.Errorf("mapper not implemented: %s", key)
+	case "redis":
+		adapter := &adapters.RedisAdapter{}
+		if err := adapter.Connect(ctx, config); err != nil {
+			return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+		}
+		return adapter, nil
 
-	case "pinecone_to_weaviate":
-		// TODO: Implement PineconeToWeaviateMapper
-		return nil, fmt.Errorf("mapper not implemented: %s", key)
+	case "chroma":
+		adapter := &adapters.ChromaAdapter{}
+		if err := adapter.Connect(ctx, config); err != nil {
+			return nil, fmt.Errorf("failed to connect to Chroma: %w", err)
+		}
+		return adapter, nil
 
-	case "weaviate_to_pinecone":
-		// TODO: Implement WeaviateToPineconeMapper
-		return nil, fmt.Errorf("mapper not implemented: %s", key)
+	case "elasticsearch", "opensearch":
+		adapter := &adapters.ElasticsearchAdapter{}
+		if err := adapter.Connect(ctx, config); err != nil {
+			return nil, fmt.Errorf("failed to connect to %s: %w", dbType, err)
+		}
+		return adapter, nil
 
-	case "qdrant_to_weaviate":
-		// TODO: Implement QdrantToWeaviateMapper
-		return nil, fmt.Errorf("mapper not implemented: %s", key)
+	case "jsonl":
+		adapter := &adapters.JSONLAdapter{}
+		if err := adapter.Connect(ctx, config); err != nil {
+			return nil, fmt.Errorf("failed to open JSONL file: %w", err)
+		}
+		return adapter, nil
 
-	case "weaviate_to_qdrant":
-		// TODO: Implement WeaviateToQdrantMapper
-		return nil, fmt.Errorf("mapper not implemented: %s", key)
+	case "npy":
+		adapter := &adapters.NumpyAdapter{}
+		if err := adapter.Connect(ctx, config); err != nil {
+			return nil, fmt.Errorf("failed to open NumPy/FAISS file: %w", err)
+		}
+		return adapter, nil
 
 	default:
-		return nil, fmt.Errorf("unsupported migration path: %s → %s", sourceType, targetType)
+		return nil, fmt.Errorf("unsupported database type: %s", dbType)
 	}
 }
 
+// createMapper creates a schema mapper for the source/target types that
+// maps for what the connected target can store
+func createMapper(sourceType, targetType string, target adapters.Database) (mapper.SchemaMapper, error) {
+	caps, _ := adapters.CapabilitiesOf(target, targetType)
+	return mapper.NewMapper(sourceType, targetType, caps)
+}
+
 // createStateTracker creates a state tracker
 func createStateTracker(dbPath string) (state.StateTracker, error) {
 	if dbPath == "" {
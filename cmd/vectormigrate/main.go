@@ -41,6 +41,7 @@ func main() {
 	rootCmd.AddCommand(validateCmd)
 	rootCmd.AddCommand(rollbackCmd)
 	rootCmd.AddCommand(serveCmd)
+	rootCmd.AddCommand(dlqCmd)
 
 	// Execute
 	if err := rootCmd.ExecuteContext(ctx); err != nil {
@@ -4,8 +4,12 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/AlphaTechini/vector-db-migration/internal/adapters"
+	"github.com/AlphaTechini/vector-db-migration/internal/mapper"
 	"github.com/AlphaTechini/vector-db-migration/internal/orchestrator"
 	"github.com/spf13/cobra"
 )
@@ -15,52 +19,95 @@ var (
 	sourceURL      string
 	sourceAPIKey   string
 	sourceIndex    string
+	sourceExtra    map[string]string
 	targetType     string
 	targetURL      string
 	targetAPIKey   string
 	targetIndex    string
+	targetExtra    map[string]string
 	batchSize      int
 	maxRetries     int
+	retryBackoff   time.Duration
 	validateEvery  int
+	partitions     int
+	readers        int
+	mappers        int
+	writers        int
 	dryRun         bool
+	resumeID       string
+	namespaces     []string
+	namespaceRoutes map[string]string
+	defaultRoute   string
+	primaryVector  string
+	sparseVector   string
+	sparsePolicy   string
+	sparseField    string
 
 	migrateCmd = &cobra.Command{
 		Use:   "migrate [migration-id]",
 		Short: "Start a migration",
-		Long:  "Migrate data from source vector database to target with zero downtime.",
-		Args:  cobra.ExactArgs(1),
+		Long:  "Migrate data from source vector database to target with zero downtime. With --resume, continue an interrupted migration from its last checkpoint.",
+		Args:  cobra.MaximumNArgs(1),
 		RunE:  runMigrate,
 	}
 )
 
 func init() {
 	// Source flags
-	migrateCmd.Flags().StringVar(&sourceType, "source-type", "", "Source database type (pinecone, qdrant, weaviate)")
+	migrateCmd.Flags().StringVar(&sourceType, "source-type", "", "Source database type (pinecone, qdrant, weaviate, milvus, pgvector, redis, chroma, elasticsearch, opensearch, jsonl, npy)")
 	migrateCmd.Flags().StringVar(&sourceURL, "source-url", "", "Source database URL")
 	migrateCmd.Flags().StringVar(&sourceAPIKey, "source-api-key", "", "Source database API key")
 	migrateCmd.Flags().StringVar(&sourceIndex, "source-index", "", "Source index/collection name")
+	migrateCmd.Flags().StringToStringVar(&sourceExtra, "source-extra", nil, "Provider-specific source settings (key=value)")
 	migrateCmd.MarkFlagRequired("source-type")
 	migrateCmd.MarkFlagRequired("source-url")
 	migrateCmd.MarkFlagRequired("source-index")
 
 	// Target flags
-	migrateCmd.Flags().StringVar(&targetType, "target-type", "", "Target database type (pinecone, qdrant, weaviate)")
+	migrateCmd.Flags().StringVar(&targetType, "target-type", "", "Target database type (pinecone, qdrant, weaviate, milvus, pgvector, redis, chroma, elasticsearch, opensearch, jsonl)")
 	migrateCmd.Flags().StringVar(&targetURL, "target-url", "", "Target database URL")
 	migrateCmd.Flags().StringVar(&targetAPIKey, "target-api-key", "", "Target database API key")
 	migrateCmd.Flags().StringVar(&targetIndex, "target-index", "", "Target index/collection name")
+	migrateCmd.Flags().StringToStringVar(&targetExtra, "target-extra", nil, "Provider-specific target settings (key=value)")
 	migrateCmd.MarkFlagRequired("target-type")
 	migrateCmd.MarkFlagRequired("target-url")
 	migrateCmd.MarkFlagRequired("target-index")
 
 	// Migration options
 	migrateCmd.Flags().IntVar(&batchSize, "batch-size", 100, "Number of records per batch")
-	migrateCmd.Flags().IntVar(&maxRetries, "max-retries", 3, "Maximum retry attempts per batch")
+	migrateCmd.Flags().IntVar(&maxRetries, "max-retries", 3, "Maximum retries of a failed source or target call (timeouts, 429 and 5xx responses)")
+	migrateCmd.Flags().DurationVar(&retryBackoff, "retry-backoff", 500*time.Millisecond, "Wait before the first retry; doubles per retry up to 30s")
 	migrateCmd.Flags().IntVar(&validateEvery, "validate-every", 10, "Validate every N batches")
+	migrateCmd.Flags().IntVar(&readers, "readers", 0, "Concurrent source scans (default: --partitions)")
+	migrateCmd.Flags().IntVar(&mappers, "mappers", 1, "Concurrent batch mappers")
+	migrateCmd.Flags().IntVar(&writers, "writers", 1, "Concurrent target writers")
+	migrateCmd.Flags().IntVar(&partitions, "partitions", 1, "Split the source into partitions scanned concurrently (pgvector id ranges, Qdrant shard keys, Pinecone partition_prefixes)")
 	migrateCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Simulate migration without writing")
+	migrateCmd.Flags().StringVar(&resumeID, "resume", "", "Continue the migration with this ID from its last checkpoint, with the same source, target and partition flags")
+
+	// Namespace options
+	migrateCmd.Flags().StringSliceVar(&namespaces, "namespaces", nil, "Migrate source namespaces (Pinecone) or tenants (Weaviate) separately: 'all' or a list (__default__ is Pinecone's default namespace)")
+	migrateCmd.Flags().StringToStringVar(&namespaceRoutes, "namespace-route", nil, "Per-namespace routing as namespace=mode[:target], mode field, collection or tenant; target may contain {namespace}")
+	migrateCmd.Flags().StringVar(&defaultRoute, "namespace-default-route", "field:namespace", "Routing for namespaces without --namespace-route")
+
+	// Vector mapping options
+	migrateCmd.Flags().StringVar(&primaryVector, "primary-vector", "", "Named vector written as the dense vector on targets without named vectors")
+	migrateCmd.Flags().StringVar(&sparseVector, "sparse-vector", "", "Named sparse vector exchanged with targets that hold one unnamed sparse vector")
+	migrateCmd.Flags().StringVar(&sparsePolicy, "sparse-policy", "error", "Sparse vectors the target cannot store: error, drop or metadata")
+	migrateCmd.Flags().StringVar(&sparseField, "sparse-field", "", "Metadata field the metadata sparse policy writes to (default sparse_vectors)")
 }
 
 func runMigrate(cmd *cobra.Command, args []string) error {
-	migrationID := args[0]
+	migrationID := resumeID
+	if len(args) == 1 {
+		if resumeID != "" && args[0] != resumeID {
+			return fmt.Errorf("migration ID %s does not match --resume %s", args[0], resumeID)
+		}
+		migrationID = args[0]
+	}
+	if migrationID == "" {
+		return fmt.Errorf("a migration ID or --resume is required")
+	}
 
 	// Validate database types
 	if err := validateDatabaseType(sourceType); err != nil {
@@ -69,12 +116,45 @@ func runMigrate(cmd *cobra.Command, args []string) error {
 	if err := validateDatabaseType(targetType); err != nil {
 		return fmt.Errorf("invalid target type: %w", err)
 	}
+	if targetType == "npy" {
+		return fmt.Errorf("invalid target type: npy is a read-only source")
+	}
+	if reason, ok := unresumableSources[sourceType]; ok && resumeID != "" {
+		return fmt.Errorf("cannot resume a migration from %s: %s; run it again without --resume", sourceType, reason)
+	}
 
-	log.Printf("🚀 Starting migration: %s", migrationID)
+	routing, err := namespaceRouting()
+	if err != nil {
+		return err
+	}
+
+	policy, err := mapper.ParseSparsePolicy(sparsePolicy)
+	if err != nil {
+		return err
+	}
+
+	if resumeID != "" {
+		log.Printf("🚀 Resuming migration: %s", migrationID)
+	} else {
+		log.Printf("🚀 Starting migration: %s", migrationID)
+	}
 	log.Printf("   Source: %s (%s)", sourceType, sourceIndex)
 	log.Printf("   Target: %s (%s)", targetType, targetIndex)
 	log.Printf("   Batch size: %d", batchSize)
 	log.Printf("   Validate every: %d batches", validateEvery)
+	if partitions > 1 {
+		log.Printf("   Partitions: %d", partitions)
+	}
+	if mappers > 1 || writers > 1 {
+		log.Printf("   Pipeline: %d mappers, %d writers", mappers, writers)
+	}
+	if routing != nil {
+		if len(routing.Namespaces) == 0 {
+			log.Printf("   Namespaces: all (default route %s)", routing.Default)
+		} else {
+			log.Printf("   Namespaces: %s (default route %s)", strings.Join(routing.Namespaces, ", "), routing.Default)
+		}
+	}
 
 	if dryRun {
 		log.Println("   📝 DRY RUN - no data will be written")
@@ -88,19 +168,19 @@ func runMigrate(cmd *cobra.Command, args []string) error {
 	// Initialize components
 	log.Println("   🔧 Initializing components...")
 
-	sourceDB, err := createDatabase(sourceType, sourceURL, sourceAPIKey, sourceIndex, 30)
+	sourceDB, err := createDatabase(sourceType, sourceURL, sourceAPIKey, sourceIndex, 30, sourceExtra)
 	if err != nil {
 		return err
 	}
 	defer sourceDB.Close()
 
-	targetDB, err := createDatabase(targetType, targetURL, targetAPIKey, targetIndex, 30)
+	targetDB, err := createDatabase(targetType, targetURL, targetAPIKey, targetIndex, 30, targetExtra)
 	if err != nil {
 		return err
 	}
 	defer targetDB.Close()
 
-	schemaMapper, err := createMapper(sourceType, targetType)
+	schemaMapper, err := createMapper(sourceType, targetType, targetDB)
 	if err != nil {
 		return err
 	}
@@ -120,15 +200,34 @@ func runMigrate(cmd *cobra.Command, args []string) error {
 		TargetDB:      targetDB,
 		SchemaMapper:  schemaMapper,
 		StateTracker:  stateTracker,
+		SourceConfig:  adapters.DBConfig{Type: sourceType, URL: sourceURL, Index: sourceIndex, Extra: sourceExtra},
+		TargetConfig:  adapters.DBConfig{Type: targetType, URL: targetURL, Index: targetIndex, Extra: targetExtra},
 		BatchSize:     batchSize,
 		MaxRetries:    maxRetries,
+		RetryBackoff:  retryBackoff,
 		ValidateEvery: validateEvery,
+		NamespaceRouting: routing,
+		Partitions:    partitions,
+		Readers:       readers,
+		Mappers:       mappers,
+		Writers:       writers,
+		PrimaryVector: primaryVector,
+		SparseVector:  sparseVector,
+		SparsePolicy:  policy,
+		SparseField:   sparseField,
 	}
 
-	// Start migration
-	log.Println("   ▶️  Starting migration...")
-	if err := migrator.Start(ctx, orchConfig); err != nil {
-		return fmt.Errorf("failed to start migration: %w", err)
+	// Start migration, or continue it from its checkpoint
+	if resumeID != "" {
+		log.Println("   ▶️  Resuming from checkpoint...")
+		if err := migrator.ResumeFromCheckpoint(ctx, orchConfig); err != nil {
+			return fmt.Errorf("failed to resume migration: %w", err)
+		}
+	} else {
+		log.Println("   ▶️  Starting migration...")
+		if err := migrator.Start(ctx, orchConfig); err != nil {
+			return fmt.Errorf("failed to start migration: %w", err)
+		}
 	}
 
 	// Monitor progress
@@ -159,6 +258,19 @@ func runMigrate(cmd *cobra.Command, args []string) error {
 			if status.Status == "completed" {
 				log.Printf("✅ Migration completed successfully!")
 				log.Printf("   Total: %d records, %d batches", status.MigratedRecords, status.BatchesProcessed)
+				for _, name := range sortedNamespaces(status.Namespaces) {
+					ns := status.Namespaces[name]
+					log.Printf("   %s → %s: %d records", name, ns.Route, ns.MigratedRecords)
+				}
+				if status.FailedRecords > 0 {
+					log.Printf("   ⚠️  %d records failed; see vectormigrate dlq list %s", status.FailedRecords, migrationID)
+				}
+				if status.Retries > 0 {
+					log.Printf("   Retried %d calls (%d rate limited)", status.Retries, status.RateLimited)
+				}
+				if sparse := status.Sparse; sparse.Dropped > 0 || sparse.Stashed > 0 {
+					log.Printf("   Sparse vectors: dropped from %d records, stashed in metadata for %d", sparse.Dropped, sparse.Stashed)
+				}
 				return nil
 			}
 
@@ -169,17 +281,85 @@ func runMigrate(cmd *cobra.Command, args []string) error {
 	}
 }
 
-// validateDatabaseType checks if the database type is supported
-func validateDatabaseType(dbType string) error {
-	supportedTypes := map[string]bool{
-		"pinecone": true,
-		"qdrant":   true,
-		"weaviate": true,
-		"milvus":   true,
+// namespaceRouting builds the multi-namespace routing from the namespace
+// flags, or returns nil when --namespaces is not set
+func namespaceRouting() (*orchestrator.NamespaceRouting, error) {
+	if len(namespaces) == 0 {
+		if len(namespaceRoutes) > 0 {
+			return nil, fmt.Errorf("--namespace-route requires --namespaces")
+		}
+		return nil, nil
 	}
 
-	if !supportedTypes[dbType] {
-		return fmt.Errorf("unsupported database type: %s (supported: pinecone, qdrant, weaviate, milvus)", dbType)
+	routing := &orchestrator.NamespaceRouting{
+		Routes: make(map[string]orchestrator.NamespaceRoute, len(namespaceRoutes)),
+	}
+	if len(namespaces) != 1 || namespaces[0] != "all" {
+		routing.Namespaces = namespaces
+	}
+
+	var err error
+	if routing.Default, err = orchestrator.ParseNamespaceRoute(defaultRoute); err != nil {
+		return nil, fmt.Errorf("invalid --namespace-default-route: %w", err)
+	}
+	rules := []orchestrator.NamespaceRoute{routing.Default}
+	for namespace, rule := range namespaceRoutes {
+		route, err := orchestrator.ParseNamespaceRoute(rule)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --namespace-route for %s: %w", namespace, err)
+		}
+		routing.Routes[namespace] = route
+		rules = append(rules, route)
+	}
+
+	for _, route := range rules {
+		if route.Mode == orchestrator.RouteTenant && targetType != "weaviate" {
+			return nil, fmt.Errorf("tenant routes need a weaviate target, got %s", targetType)
+		}
+	}
+
+	// Collection and tenant routes open their own target connection
+	routing.TargetFor = func(route orchestrator.NamespaceRoute) (adapters.Database, error) {
+		index := targetIndex
+		extra := make(map[string]string, len(targetExtra)+1)
+		for k, v := range targetExtra {
+			extra[k] = v
+		}
+
+		switch route.Mode {
+		case orchestrator.RouteCollection:
+			index = route.Target
+		case orchestrator.RouteTenant:
+			extra["tenant"] = route.Target
+		}
+
+		return createDatabase(targetType, targetURL, targetAPIKey, index, 30, extra)
+	}
+
+	return routing, nil
+}
+
+// sortedNamespaces returns the namespace names of a status in order
+func sortedNamespaces(stats map[string]*orchestrator.NamespaceStats) []string {
+	names := make([]string, 0, len(stats))
+	for name := range stats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// unresumableSources lists the source types a new process cannot continue
+// reading from a checkpointed record ID, with the reason
+var unresumableSources = map[string]string{
+	"redis":  "SCAN cursors cannot be restarted from a key",
+	"chroma": "offset pagination cannot be restarted from a record ID",
+}
+
+// validateDatabaseType checks if the database type is supported
+func validateDatabaseType(dbType string) error {
+	if _, ok := adapters.CapabilitiesFor(dbType); !ok {
+		return fmt.Errorf("unsupported database type: %s (supported: %s)", dbType, strings.Join(adapters.DatabaseTypes(), ", "))
 	}
 	return nil
 }
@@ -0,0 +1,121 @@
+package adapters
+
+import (
+	"sort"
+	"strconv"
+)
+
+// IDFormat is a form of record ID a database accepts
+type IDFormat string
+
+const (
+	IDString IDFormat = "string" // any non-empty string
+	IDInt    IDFormat = "int"    // decimal signed 64-bit integer
+	IDUint   IDFormat = "uint"   // decimal unsigned 64-bit integer
+	IDUUID   IDFormat = "uuid"
+)
+
+// Capabilities describes what a database can store. Limits of 0 mean the
+// database has no fixed limit.
+type Capabilities struct {
+	// NestedMetadata is true if metadata values may be objects
+	NestedMetadata bool `json:"nested_metadata"`
+
+	// MaxMetadataBytes limits the JSON-encoded metadata of one record
+	MaxMetadataBytes int `json:"max_metadata_bytes,omitempty"`
+
+	// MaxBatchSize limits the records read or written per request
+	MaxBatchSize int `json:"max_batch_size,omitempty"`
+
+	// IDFormats lists the accepted ID forms
+	IDFormats []IDFormat `json:"id_formats"`
+
+	// NamedVectors is true if records may carry several named dense vectors
+	NamedVectors bool `json:"named_vectors"`
+
+	// SparseVector is true if records may carry one unnamed sparse vector
+	SparseVector bool `json:"sparse_vector"`
+
+	// NamedSparseVectors is true if records may carry named sparse vectors
+	NamedSparseVectors bool `json:"named_sparse_vectors"`
+
+	// TypedProperties is true if metadata is stored in typed schema fields
+	TypedProperties bool `json:"typed_properties"`
+}
+
+// CapabilityReporter is implemented by databases that describe their
+// capabilities. Connected adapters may refine the defaults of their type,
+// e.g. by the ID type of the configured collection.
+type CapabilityReporter interface {
+	Capabilities() Capabilities
+}
+
+// AcceptsID reports whether id has one of the accepted formats
+func (c Capabilities) AcceptsID(id string) bool {
+	if len(c.IDFormats) == 0 {
+		return id != ""
+	}
+	for _, format := range c.IDFormats {
+		switch format {
+		case IDString:
+			if id != "" {
+				return true
+			}
+		case IDInt:
+			if _, err := strconv.ParseInt(id, 10, 64); err == nil {
+				return true
+			}
+		case IDUint:
+			if _, err := strconv.ParseUint(id, 10, 64); err == nil {
+				return true
+			}
+		case IDUUID:
+			if qdrantUUIDPattern.MatchString(id) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// defaultCapabilities holds the capabilities of each database type as
+// configured by default
+var defaultCapabilities = map[string]Capabilities{
+	"pinecone":      pineconeCapabilities,
+	"qdrant":        qdrantCapabilities,
+	"weaviate":      weaviateCapabilities,
+	"milvus":        milvusCapabilities,
+	"pgvector":      pgvectorCapabilities,
+	"redis":         redisCapabilities,
+	"chroma":        chromaCapabilities,
+	"elasticsearch": elasticsearchCapabilities,
+	"opensearch":    elasticsearchCapabilities,
+	"jsonl":         jsonlCapabilities,
+	"npy":           numpyCapabilities,
+}
+
+// CapabilitiesFor returns the default capabilities of a database type,
+// and false if the type is unknown
+func CapabilitiesFor(dbType string) (Capabilities, bool) {
+	caps, ok := defaultCapabilities[dbType]
+	return caps, ok
+}
+
+// CapabilitiesOf returns db's capabilities if it reports them, else the
+// defaults of dbType
+func CapabilitiesOf(db Database, dbType string) (Capabilities, bool) {
+	if reporter, ok := db.(CapabilityReporter); ok {
+		return reporter.Capabilities(), true
+	}
+	return CapabilitiesFor(dbType)
+}
+
+// DatabaseTypes returns the supported database types in name order
+func DatabaseTypes() []string {
+	types := make([]string, 0, len(defaultCapabilities))
+	for dbType := range defaultCapabilities {
+		types = append(types, dbType)
+	}
+	sort.Strings(types)
+	return types
+}
@@ -0,0 +1,59 @@
+package adapters
+
+import (
+	"testing"
+)
+
+// TestCapabilitiesAcceptsID tests ID format checks
+func TestCapabilitiesAcceptsID(t *testing.T) {
+	cases := []struct {
+		caps Capabilities
+		id   string
+		want bool
+	}{
+		{qdrantCapabilities, "42", true},
+		{qdrantCapabilities, "550e8400-e29b-41d4-a716-446655440000", true},
+		{qdrantCapabilities, "-1", false},
+		{qdrantCapabilities, "doc-1", false},
+		{weaviateCapabilities, "42", false},
+		{pineconeCapabilities, "doc-1", true},
+		{Capabilities{IDFormats: []IDFormat{IDInt}}, "-7", true},
+		{Capabilities{}, "anything", true},
+		{Capabilities{}, "", false},
+	}
+	for _, tc := range cases {
+		if got := tc.caps.AcceptsID(tc.id); got != tc.want {
+			t.Errorf("AcceptsID(%q) with %v = %v, want %v", tc.id, tc.caps.IDFormats, got, tc.want)
+		}
+	}
+
+	t.Log("✓ Capabilities check ID formats")
+}
+
+// TestCapabilitiesOf tests that every type has defaults and connected
+// adapters refine them
+func TestCapabilitiesOf(t *testing.T) {
+	for _, dbType := range DatabaseTypes() {
+		caps, ok := CapabilitiesFor(dbType)
+		if !ok || len(caps.IDFormats) == 0 {
+			t.Errorf("Expected default capabilities with ID formats for %s, got %+v", dbType, caps)
+		}
+	}
+	if _, ok := CapabilitiesFor("mongodb"); ok {
+		t.Error("Expected no capabilities for an unknown type")
+	}
+
+	milvus := &MilvusAdapter{idIsInt: true}
+	if caps, _ := CapabilitiesOf(milvus, "milvus"); len(caps.IDFormats) != 1 || caps.IDFormats[0] != IDInt {
+		t.Errorf("Expected Int64 primary keys to take integer IDs, got %v", caps.IDFormats)
+	}
+	redis := &RedisAdapter{storage: "json"}
+	if caps, _ := CapabilitiesOf(redis, "redis"); !caps.NestedMetadata {
+		t.Error("Expected Redis JSON documents to allow nested metadata")
+	}
+	if caps, ok := CapabilitiesOf(NewMemoryAdapter(), "qdrant"); !ok || !caps.NamedVectors {
+		t.Errorf("Expected the type defaults for an adapter without capabilities, got %+v", caps)
+	}
+
+	t.Log("✓ CapabilitiesOf prefers connected adapters over type defaults")
+}
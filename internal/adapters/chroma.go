@@ -0,0 +1,351 @@
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ChromaDocumentKey is the reserved metadata key carrying a Chroma document.
+// Documents are read into Record.Metadata under this key and written back
+// from it, so they survive migrations through other databases.
+const ChromaDocumentKey = "_document"
+
+// ChromaAdapter implements Database interface for Chroma (HTTP API v1)
+type ChromaAdapter struct {
+	config       DBConfig
+	httpClient   *http.Client
+	baseURL      string
+	sourceURL    string
+	collectionID string
+
+	// Chroma pages by offset, so GetBatch tracks the offset reached by the
+	// last ID it returned
+	lastID     string
+	nextOffset int
+}
+
+// chromaGetResponse represents Chroma get response
+type chromaGetResponse struct {
+	IDs        []string                 `json:"ids"`
+	Embeddings [][]float32              `json:"embeddings"`
+	Metadatas  []map[string]interface{} `json:"metadatas"`
+	Documents  []*string                `json:"documents"`
+}
+
+// chromaUpsertRequest represents Chroma upsert request
+type chromaUpsertRequest struct {
+	IDs        []string                 `json:"ids"`
+	Embeddings [][]float32              `json:"embeddings"`
+	Metadatas  []map[string]interface{} `json:"metadatas"`
+	Documents  []*string                `json:"documents"`
+}
+
+// Connect establishes connection to Chroma and resolves the collection ID
+func (a *ChromaAdapter) Connect(ctx context.Context, config DBConfig) error {
+	if config.Type != "chroma" {
+		return fmt.Errorf("expected type 'chroma', got '%s'", config.Type)
+	}
+
+	a.config = config
+	a.sourceURL = config.URL
+	a.baseURL = strings.TrimRight(config.URL, "/")
+
+	// Create HTTP client with timeout
+	timeout := time.Duration(config.Timeout) * time.Second
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+
+	a.httpClient = &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			MaxIdleConns:        10,
+			MaxIdleConnsPerHost: 5,
+			IdleConnTimeout:     90 * time.Second,
+		},
+	}
+
+	// Validate connection
+	if err := a.ValidateConnection(ctx); err != nil {
+		return err
+	}
+
+	return a.resolveCollection(ctx)
+}
+
+// resolveCollection looks up the collection ID for the configured name
+func (a *ChromaAdapter) resolveCollection(ctx context.Context) error {
+	query := url.Values{}
+	if tenant := a.config.Extra["tenant"]; tenant != "" {
+		query.Set("tenant", tenant)
+	}
+	if database := a.config.Extra["database"]; database != "" {
+		query.Set("database", database)
+	}
+
+	path := "/api/v1/collections/" + url.PathEscape(a.config.Index)
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	var collection struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+	if err := a.do(ctx, "GET", path, nil, &collection); err != nil {
+		return fmt.Errorf("failed to resolve Chroma collection %s: %w", a.config.Index, err)
+	}
+
+	if collection.ID == "" {
+		return fmt.Errorf("Chroma collection %s has no ID", a.config.Index)
+	}
+
+	a.collectionID = collection.ID
+	return nil
+}
+
+// Close closes the HTTP client
+func (a *ChromaAdapter) Close() error {
+	if a.httpClient != nil {
+		a.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+// GetBatch retrieves a batch of records using offset pagination
+func (a *ChromaAdapter) GetBatch(ctx context.Context, afterID string, limit int) ([]Record, error) {
+	offset := 0
+	if afterID != "" {
+		if afterID != a.lastID {
+			return nil, fmt.Errorf("Chroma offset pagination cannot resume after %q; restart from the beginning", afterID)
+		}
+		offset = a.nextOffset
+	}
+
+	request := map[string]interface{}{
+		"limit":   limit,
+		"offset":  offset,
+		"include": []string{"embeddings", "metadatas", "documents"},
+	}
+
+	var getResp chromaGetResponse
+	if err := a.do(ctx, "POST", a.collectionPath("get"), request, &getResp); err != nil {
+		return nil, fmt.Errorf("failed to get from Chroma: %w", err)
+	}
+
+	records := a.toRecords(getResp)
+
+	if len(records) > 0 {
+		a.lastID = records[len(records)-1].ID
+		a.nextOffset = offset + len(records)
+	}
+
+	return records, nil
+}
+
+// FetchByIDs reads records with the get endpoint's ID filter
+func (a *ChromaAdapter) FetchByIDs(ctx context.Context, ids []string) ([]Record, []string, error) {
+	return fetchByIDs(ids, 0, func(chunk []string) ([]Record, error) {
+		request := map[string]interface{}{
+			"ids":     chunk,
+			"include": []string{"embeddings", "metadatas", "documents"},
+		}
+
+		var getResp chromaGetResponse
+		if err := a.do(ctx, "POST", a.collectionPath("get"), request, &getResp); err != nil {
+			return nil, fmt.Errorf("failed to get from Chroma: %w", err)
+		}
+		return a.toRecords(getResp), nil
+	})
+}
+
+// UpsertBatch inserts or updates records in Chroma
+func (a *ChromaAdapter) UpsertBatch(ctx context.Context, records []Record) error {
+	request := chromaUpsertRequest{
+		IDs:        make([]string, len(records)),
+		Embeddings: make([][]float32, len(records)),
+		Metadatas:  make([]map[string]interface{}, len(records)),
+		Documents:  make([]*string, len(records)),
+	}
+
+	for i, r := range records {
+		request.IDs[i] = r.ID
+		request.Embeddings[i] = r.Vector
+
+		var metadata map[string]interface{}
+		for key, value := range r.Metadata {
+			if key == ChromaDocumentKey {
+				if doc, ok := value.(string); ok {
+					request.Documents[i] = &doc
+					continue
+				}
+			}
+			if metadata == nil {
+				metadata = make(map[string]interface{}, len(r.Metadata))
+			}
+			metadata[key] = value
+		}
+		request.Metadatas[i] = metadata
+	}
+
+	if err := a.do(ctx, "POST", a.collectionPath("upsert"), request, nil); err != nil {
+		return fmt.Errorf("failed to upsert to Chroma: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteBatch deletes records from Chroma by IDs
+func (a *ChromaAdapter) DeleteBatch(ctx context.Context, ids []string) error {
+	request := map[string]interface{}{
+		"ids": ids,
+	}
+
+	if err := a.do(ctx, "POST", a.collectionPath("delete"), request, nil); err != nil {
+		return fmt.Errorf("failed to delete from Chroma: %w", err)
+	}
+
+	return nil
+}
+
+// ValidateConnection checks if Chroma is accessible
+func (a *ChromaAdapter) ValidateConnection(ctx context.Context) error {
+	if err := a.do(ctx, "GET", "/api/v1/heartbeat", nil, nil); err != nil {
+		return fmt.Errorf("failed to connect to Chroma: %w", err)
+	}
+	return nil
+}
+
+// GetStats returns Chroma collection statistics
+func (a *ChromaAdapter) GetStats(ctx context.Context) (*DBStats, error) {
+	var count int64
+	if err := a.do(ctx, "GET", a.collectionPath("count"), nil, &count); err != nil {
+		return nil, fmt.Errorf("failed to get stats from Chroma: %w", err)
+	}
+
+	// Chroma does not report dimension; sample one embedding instead
+	var sample chromaGetResponse
+	request := map[string]interface{}{
+		"limit":   1,
+		"include": []string{"embeddings"},
+	}
+	if err := a.do(ctx, "POST", a.collectionPath("get"), request, &sample); err != nil {
+		return nil, fmt.Errorf("failed to sample Chroma embedding: %w", err)
+	}
+
+	dimensions := 0
+	if len(sample.Embeddings) > 0 {
+		dimensions = len(sample.Embeddings[0])
+	}
+
+	return &DBStats{
+		TotalRecords: count,
+		Dimensions:   dimensions,
+		IndexType:    "chroma-hnsw",
+		MemoryUsage:  0, // Not available via API
+	}, nil
+}
+
+// GetSourceURL returns the Chroma source URL
+func (a *ChromaAdapter) GetSourceURL() string {
+	return a.sourceURL
+}
+
+// chromaCapabilities: metadata values are scalars
+var chromaCapabilities = Capabilities{
+	IDFormats: []IDFormat{IDString},
+}
+
+// Capabilities returns what Chroma can store
+func (a *ChromaAdapter) Capabilities() Capabilities {
+	return chromaCapabilities
+}
+
+// collectionPath builds a collection-scoped API path
+func (a *ChromaAdapter) collectionPath(action string) string {
+	return fmt.Sprintf("/api/v1/collections/%s/%s", url.PathEscape(a.collectionID), action)
+}
+
+// toRecords converts a Chroma get response into records
+func (a *ChromaAdapter) toRecords(resp chromaGetResponse) []Record {
+	records := make([]Record, len(resp.IDs))
+	for i, id := range resp.IDs {
+		record := Record{
+			ID:       id,
+			Metadata: make(map[string]interface{}),
+		}
+
+		if i < len(resp.Embeddings) {
+			record.Vector = resp.Embeddings[i]
+		}
+
+		if i < len(resp.Metadatas) {
+			for key, value := range resp.Metadatas[i] {
+				record.Metadata[key] = value
+			}
+		}
+
+		if i < len(resp.Documents) && resp.Documents[i] != nil {
+			record.Metadata[ChromaDocumentKey] = *resp.Documents[i]
+		}
+
+		records[i] = record
+	}
+
+	return records
+}
+
+// do sends a request to the Chroma API and decodes the response into out
+func (a *ChromaAdapter) do(ctx context.Context, method, path string, body interface{}, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		jsonData, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reader = bytes.NewBuffer(jsonData)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, a.baseURL+path, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if a.config.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+a.config.APIKey)
+	}
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return apiError("Chroma", resp)
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
+
+// Ensure ChromaAdapter implements Database interface
+var _ Database = (*ChromaAdapter)(nil)
+
+// Ensure ChromaAdapter implements CapabilityReporter interface
+var _ CapabilityReporter = (*ChromaAdapter)(nil)
@@ -0,0 +1,220 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeChroma is a minimal in-process stand-in for the Chroma HTTP API v1
+type fakeChroma struct {
+	mu        sync.Mutex
+	ids       []string
+	rows      map[string]chromaRow
+	lastQuery string
+}
+
+type chromaRow struct {
+	embedding []float32
+	metadata  map[string]interface{}
+	document  *string
+}
+
+func newFakeChroma(t *testing.T) (*fakeChroma, *httptest.Server) {
+	fake := &fakeChroma{rows: make(map[string]chromaRow)}
+	server := httptest.NewServer(http.HandlerFunc(fake.handle))
+	t.Cleanup(server.Close)
+	return fake, server
+}
+
+func (f *fakeChroma) handle(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	path := r.URL.Path
+
+	switch {
+	case path == "/api/v1/heartbeat":
+		json.NewEncoder(w).Encode(map[string]int64{"nanosecond heartbeat": 1})
+
+	case path == "/api/v1/collections/notes":
+		f.lastQuery = r.URL.RawQuery
+		json.NewEncoder(w).Encode(map[string]string{"id": "c0ffee", "name": "notes"})
+
+	case path == "/api/v1/collections/c0ffee/count":
+		json.NewEncoder(w).Encode(len(f.ids))
+
+	case path == "/api/v1/collections/c0ffee/get":
+		var req struct {
+			IDs    []string `json:"ids"`
+			Limit  int      `json:"limit"`
+			Offset int      `json:"offset"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		ids := f.ids
+		if req.IDs != nil {
+			ids = nil
+			for _, id := range req.IDs {
+				if _, exists := f.rows[id]; exists {
+					ids = append(ids, id)
+				}
+			}
+			req.Offset, req.Limit = 0, len(ids)
+		}
+
+		resp := chromaGetResponse{IDs: []string{}}
+		for i := req.Offset; i < len(ids) && i < req.Offset+req.Limit; i++ {
+			row := f.rows[ids[i]]
+			resp.IDs = append(resp.IDs, ids[i])
+			resp.Embeddings = append(resp.Embeddings, row.embedding)
+			resp.Metadatas = append(resp.Metadatas, row.metadata)
+			resp.Documents = append(resp.Documents, row.document)
+		}
+		json.NewEncoder(w).Encode(resp)
+
+	case path == "/api/v1/collections/c0ffee/upsert":
+		var req chromaUpsertRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		for i, id := range req.IDs {
+			if _, exists := f.rows[id]; !exists {
+				f.ids = append(f.ids, id)
+			}
+			f.rows[id] = chromaRow{req.Embeddings[i], req.Metadatas[i], req.Documents[i]}
+		}
+		json.NewEncoder(w).Encode(true)
+
+	case path == "/api/v1/collections/c0ffee/delete":
+		var req struct {
+			IDs []string `json:"ids"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		for _, id := range req.IDs {
+			delete(f.rows, id)
+			for i, existing := range f.ids {
+				if existing == id {
+					f.ids = append(f.ids[:i], f.ids[i+1:]...)
+					break
+				}
+			}
+		}
+		json.NewEncoder(w).Encode(req.IDs)
+
+	case strings.HasPrefix(path, "/api/v1/collections/"):
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"error": "collection not found"})
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func connectChroma(t *testing.T, url string, extra map[string]string) *ChromaAdapter {
+	adapter := &ChromaAdapter{}
+	err := adapter.Connect(context.Background(), DBConfig{
+		Type:  "chroma",
+		URL:   url,
+		Index: "notes",
+		Extra: extra,
+	})
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	t.Cleanup(func() { adapter.Close() })
+	return adapter
+}
+
+// TestChromaAdapterConnect tests collection name resolution
+func TestChromaAdapterConnect(t *testing.T) {
+	fake, server := newFakeChroma(t)
+	adapter := connectChroma(t, server.URL, map[string]string{"tenant": "acme", "database": "prod"})
+
+	if adapter.collectionID != "c0ffee" {
+		t.Errorf("Expected collection ID 'c0ffee', got '%s'", adapter.collectionID)
+	}
+
+	if fake.lastQuery != "database=prod&tenant=acme" {
+		t.Errorf("Expected tenant and database query, got '%s'", fake.lastQuery)
+	}
+
+	missing := &ChromaAdapter{}
+	if err := missing.Connect(context.Background(), DBConfig{Type: "chroma", URL: server.URL, Index: "other"}); err == nil {
+		t.Error("Expected error for unknown collection, got nil")
+	}
+
+	t.Log("✓ ChromaAdapter resolves collection names to IDs")
+}
+
+// TestChromaAdapterRoundTrip tests offset pagination, documents and stats
+func TestChromaAdapterRoundTrip(t *testing.T) {
+	_, server := newFakeChroma(t)
+	adapter := connectChroma(t, server.URL, nil)
+	ctx := context.Background()
+
+	var records []Record
+	for i := 0; i < 12; i++ {
+		records = append(records, Record{
+			ID:     fmt.Sprintf("note-%d", i),
+			Vector: []float32{float32(i), 1, 2, 3},
+			Metadata: map[string]interface{}{
+				"author":          "ada",
+				ChromaDocumentKey: fmt.Sprintf("body of note %d", i),
+			},
+		})
+	}
+
+	if err := adapter.UpsertBatch(ctx, records); err != nil {
+		t.Fatalf("Failed to upsert: %v", err)
+	}
+
+	var seen []Record
+	afterID := ""
+	for {
+		batch, err := adapter.GetBatch(ctx, afterID, 5)
+		if err != nil {
+			t.Fatalf("Failed to get batch: %v", err)
+		}
+		if len(batch) == 0 {
+			break
+		}
+		seen = append(seen, batch...)
+		afterID = batch[len(batch)-1].ID
+	}
+
+	if len(seen) != 12 {
+		t.Fatalf("Expected 12 records, got %d", len(seen))
+	}
+
+	if seen[7].Metadata[ChromaDocumentKey] != "body of note 7" {
+		t.Errorf("Expected document in reserved metadata key, got %v", seen[7].Metadata)
+	}
+
+	if seen[7].Metadata["author"] != "ada" {
+		t.Errorf("Expected author 'ada', got %v", seen[7].Metadata["author"])
+	}
+
+	if _, err := adapter.GetBatch(ctx, "note-3", 5); err == nil {
+		t.Error("Expected error when resuming from an arbitrary ID")
+	}
+
+	if err := adapter.DeleteBatch(ctx, []string{"note-0", "note-1"}); err != nil {
+		t.Fatalf("Failed to delete: %v", err)
+	}
+
+	stats, err := adapter.GetStats(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get stats: %v", err)
+	}
+
+	if stats.TotalRecords != 10 || stats.Dimensions != 4 {
+		t.Errorf("Expected 10 records with 4 dimensions, got %+v", stats)
+	}
+
+	t.Log("✓ ChromaAdapter round trips records with documents")
+}
@@ -0,0 +1,347 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/AlphaTechini/vector-db-migration/internal/adapters/fakes"
+)
+
+// conformanceOptions describes documented deviations from the Database
+// contract that runConformance should not hold an adapter to
+type conformanceOptions struct {
+	numericIDs bool // backend only accepts integer primary keys
+	uuidIDs    bool // backend only accepts UUIDs
+}
+
+// runConformance checks an adapter against the Database contract that
+// MemoryAdapter defines. newDB must return a connected, empty database
+// holding 3-dimensional vectors; each subtest gets its own.
+func runConformance(t *testing.T, newDB func(t *testing.T) Database, opts conformanceOptions) {
+	ctx := context.Background()
+
+	id := func(i int) string {
+		if opts.numericIDs {
+			return fmt.Sprint(1000 + i)
+		}
+		if opts.uuidIDs {
+			return fmt.Sprintf("00000000-0000-4000-8000-%012d", 1000+i)
+		}
+		return fmt.Sprintf("rec-%03d", i)
+	}
+
+	makeRecords := func(n int) []Record {
+		records := make([]Record, n)
+		for i := range records {
+			records[i] = Record{
+				ID:     id(i),
+				Vector: []float32{float32(i), 0.25, -1},
+				Metadata: map[string]interface{}{
+					"title": fmt.Sprintf("record %d", i),
+					"score": float64(i) / 2,
+				},
+			}
+		}
+		return records
+	}
+
+	t.Run("EmptyDatabase", func(t *testing.T) {
+		db := newDB(t)
+		batch, err := db.GetBatch(ctx, "", 10)
+		if err != nil {
+			t.Fatalf("GetBatch on empty database failed: %v", err)
+		}
+		if len(batch) != 0 {
+			t.Errorf("Expected no records, got %d", len(batch))
+		}
+	})
+
+	t.Run("PaginationCompleteness", func(t *testing.T) {
+		db := newDB(t)
+		records := makeRecords(23)
+		for start := 0; start < len(records); start += 10 {
+			end := start + 10
+			if end > len(records) {
+				end = len(records)
+			}
+			conformanceUpsert(t, db, records[start:end])
+		}
+
+		// Every record exactly once, then an empty slice at the end
+		got := conformanceReadAll(t, db, 5)
+		assertSameRecords(t, got, records)
+	})
+
+	t.Run("UpsertIdempotency", func(t *testing.T) {
+		db := newDB(t)
+		records := makeRecords(6)
+		conformanceUpsert(t, db, records)
+		conformanceUpsert(t, db, records)
+
+		updated := copyRecord(records[2])
+		updated.Vector = []float32{9, 9, 9}
+		updated.Metadata = map[string]interface{}{"title": "updated"}
+		conformanceUpsert(t, db, []Record{updated})
+		records[2] = updated
+
+		assertSameRecords(t, conformanceReadAll(t, db, 4), records)
+	})
+
+	t.Run("DeleteSemantics", func(t *testing.T) {
+		db := newDB(t)
+		records := makeRecords(8)
+		conformanceUpsert(t, db, records)
+
+		if err := db.DeleteBatch(ctx, []string{id(1), id(5), id(99)}); err != nil {
+			t.Fatalf("DeleteBatch with a missing ID failed: %v", err)
+		}
+		if err := db.DeleteBatch(ctx, []string{id(1)}); err != nil {
+			t.Fatalf("Deleting an already deleted ID failed: %v", err)
+		}
+
+		want := append(append([]Record{}, records[0]), records[2:5]...)
+		want = append(want, records[6:]...)
+		assertSameRecords(t, conformanceReadAll(t, db, 3), want)
+	})
+
+	t.Run("UnicodeIDs", func(t *testing.T) {
+		if opts.numericIDs || opts.uuidIDs {
+			t.Skip("adapter only accepts integer or UUID IDs")
+		}
+
+		db := newDB(t)
+		ids := []string{"café", "日本語-キー", "emoji-🚀", "Ωmega", "with space", "a/b:c"}
+		records := make([]Record, len(ids))
+		for i, unicodeID := range ids {
+			records[i] = Record{
+				ID:       unicodeID,
+				Vector:   []float32{1, float32(i), 0},
+				Metadata: map[string]interface{}{"title": "ünïcödé " + unicodeID},
+			}
+		}
+		conformanceUpsert(t, db, records)
+
+		assertSameRecords(t, conformanceReadAll(t, db, 4), records)
+	})
+
+	t.Run("FetchByIDs", func(t *testing.T) {
+		db := newDB(t)
+		records := makeRecords(5)
+		conformanceUpsert(t, db, records)
+
+		// Missing IDs are reported, not errors, and duplicates come back once
+		ids := []string{id(3), id(99), id(0), id(3)}
+		got, missing, err := db.FetchByIDs(ctx, ids)
+		if err != nil {
+			t.Fatalf("FetchByIDs failed: %v", err)
+		}
+		if len(got) != 2 || got[0].ID != id(3) || got[1].ID != id(0) {
+			t.Fatalf("Expected records %s and %s in request order, got %v", id(3), id(0), got)
+		}
+		assertSameRecords(t, got, []Record{records[3], records[0]})
+		if !reflect.DeepEqual(missing, []string{id(99)}) {
+			t.Errorf("Expected missing [%s], got %v", id(99), missing)
+		}
+
+		if got, missing, err := db.FetchByIDs(ctx, nil); err != nil || len(got) != 0 || len(missing) != 0 {
+			t.Errorf("Expected nothing for no IDs, got %v, %v (%v)", got, missing, err)
+		}
+	})
+
+	t.Run("EmptyMetadata", func(t *testing.T) {
+		db := newDB(t)
+		records := []Record{
+			{ID: id(0), Vector: []float32{1, 2, 3}},
+			{ID: id(1), Vector: []float32{4, 5, 6}, Metadata: map[string]interface{}{}},
+		}
+		conformanceUpsert(t, db, records)
+
+		got := conformanceReadAll(t, db, 10)
+		assertSameRecords(t, got, records)
+		for _, r := range got {
+			if len(r.Metadata) != 0 {
+				t.Errorf("Expected empty metadata for %s, got %v", r.ID, r.Metadata)
+			}
+		}
+	})
+}
+
+func conformanceUpsert(t *testing.T, db Database, records []Record) {
+	t.Helper()
+	if err := db.UpsertBatch(context.Background(), records); err != nil {
+		t.Fatalf("UpsertBatch failed: %v", err)
+	}
+}
+
+// conformanceReadAll pages through db, failing on duplicate IDs or a
+// non-empty batch after the end has been reached
+func conformanceReadAll(t *testing.T, db Database, limit int) []Record {
+	t.Helper()
+	ctx := context.Background()
+
+	var all []Record
+	seen := make(map[string]bool)
+	afterID := ""
+	for pages := 0; ; pages++ {
+		if pages > 1000 {
+			t.Fatalf("GetBatch did not terminate after %d pages", pages)
+		}
+
+		batch, err := db.GetBatch(ctx, afterID, limit)
+		if err != nil {
+			t.Fatalf("GetBatch(%q) failed: %v", afterID, err)
+		}
+		if len(batch) > limit {
+			t.Fatalf("GetBatch returned %d records for limit %d", len(batch), limit)
+		}
+		if len(batch) == 0 {
+			break
+		}
+
+		for _, r := range batch {
+			if seen[r.ID] {
+				t.Fatalf("GetBatch returned %s twice", r.ID)
+			}
+			seen[r.ID] = true
+		}
+		all = append(all, batch...)
+		afterID = batch[len(batch)-1].ID
+	}
+
+	return all
+}
+
+// assertSameRecords compares records by ID, ignoring order and treating
+// nil and empty metadata alike
+func assertSameRecords(t *testing.T, got, want []Record) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d records, got %d", len(want), len(got))
+	}
+
+	byID := make(map[string]Record, len(got))
+	for _, r := range got {
+		byID[r.ID] = r
+	}
+
+	for _, r := range want {
+		actual, ok := byID[r.ID]
+		if !ok {
+			t.Errorf("Record %q missing", r.ID)
+			continue
+		}
+		if !reflect.DeepEqual(actual.Vector, r.Vector) {
+			t.Errorf("Record %q vector = %v, want %v", r.ID, actual.Vector, r.Vector)
+		}
+		if normalizeMetadata(actual.Metadata) != normalizeMetadata(r.Metadata) {
+			t.Errorf("Record %q metadata = %v, want %v", r.ID, actual.Metadata, r.Metadata)
+		}
+	}
+}
+
+// normalizeMetadata renders metadata as JSON so numeric types compare equal
+func normalizeMetadata(metadata map[string]interface{}) string {
+	if len(metadata) == 0 {
+		return "{}"
+	}
+	encoded, _ := json.Marshal(metadata)
+	var decoded interface{}
+	json.Unmarshal(encoded, &decoded)
+	encoded, _ = json.Marshal(decoded)
+	return string(encoded)
+}
+
+// TestMemoryAdapterConformance runs the suite against the reference adapter
+func TestMemoryAdapterConformance(t *testing.T) {
+	runConformance(t, func(t *testing.T) Database {
+		return NewMemoryAdapter()
+	}, conformanceOptions{})
+
+	t.Log("✓ MemoryAdapter satisfies the Database contract")
+}
+
+// TestFakeBackedConformance runs the suite against adapters with local fakes
+func TestFakeBackedConformance(t *testing.T) {
+	t.Run("pinecone", func(t *testing.T) {
+		runConformance(t, func(t *testing.T) Database {
+			fake := fakes.NewPinecone(t, "docs", 3)
+			return connectPinecone(t, fake.URL, "tenant-a")
+		}, conformanceOptions{})
+	})
+
+	t.Run("qdrant", func(t *testing.T) {
+		runConformance(t, func(t *testing.T) Database {
+			fake := fakes.NewQdrant(t)
+			fake.CreateCollection("docs", map[string]interface{}{"size": 3, "distance": "Cosine"})
+			return connectQdrant(t, fake.URL, "docs", "")
+		}, conformanceOptions{numericIDs: true})
+	})
+
+	t.Run("weaviate", func(t *testing.T) {
+		runConformance(t, func(t *testing.T) Database {
+			fake := fakes.NewWeaviate(t)
+			if err := fake.CreateClass(map[string]interface{}{"class": "Docs"}); err != nil {
+				t.Fatalf("CreateClass failed: %v", err)
+			}
+			return connectWeaviate(t, fake.URL, "Docs", "")
+		}, conformanceOptions{uuidIDs: true})
+	})
+
+	t.Run("milvus", func(t *testing.T) {
+		runConformance(t, func(t *testing.T) Database {
+			_, server := newFakeMilvus(t, true)
+			adapter := connectMilvus(t, server.URL)
+			t.Cleanup(func() { adapter.Close() })
+			return adapter
+		}, conformanceOptions{numericIDs: true})
+	})
+
+	for _, idType := range []string{"text", "bigint"} {
+		t.Run("pgvector-"+idType, func(t *testing.T) {
+			runConformance(t, func(t *testing.T) Database {
+				_, dsn := newFakePgvector(t, idType)
+				return connectPgvector(t, dsn)
+			}, conformanceOptions{numericIDs: idType == "bigint"})
+		})
+	}
+
+	for _, keyType := range []string{"HASH", "JSON"} {
+		t.Run("redis-"+keyType, func(t *testing.T) {
+			runConformance(t, func(t *testing.T) Database {
+				_, url := newFakeRedis(t, keyType, "")
+				return connectRedis(t, url, "")
+			}, conformanceOptions{})
+		})
+	}
+
+	t.Run("chroma", func(t *testing.T) {
+		runConformance(t, func(t *testing.T) Database {
+			_, server := newFakeChroma(t)
+			return connectChroma(t, server.URL, nil)
+		}, conformanceOptions{})
+	})
+
+	for _, dbType := range []string{"elasticsearch", "opensearch"} {
+		t.Run(dbType, func(t *testing.T) {
+			runConformance(t, func(t *testing.T) Database {
+				_, server := newFakeSearch(t, dbType == "opensearch")
+				adapter := connectSearch(t, dbType, server.URL)
+				t.Cleanup(func() { adapter.Close() })
+				return adapter
+			}, conformanceOptions{})
+		})
+	}
+
+	t.Run("jsonl", func(t *testing.T) {
+		runConformance(t, func(t *testing.T) Database {
+			return connectFile(t, "jsonl", filepath.Join(t.TempDir(), "export.jsonl"))
+		}, conformanceOptions{})
+	})
+
+	t.Log("✓ Fake-backed adapters satisfy the Database contract")
+}
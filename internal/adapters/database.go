@@ -9,14 +9,34 @@ type Record struct {
 	ID       string                 `json:"id"`
 	Vector   []float32              `json:"vector"`
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
+
+	// NamedVectors holds dense vectors stored under a name alongside (or
+	// instead of) the unnamed Vector, e.g. Qdrant's "title" and "body"
+	NamedVectors map[string][]float32 `json:"named_vectors,omitempty"`
+
+	// Sparse is the unnamed sparse vector of a sparse-dense hybrid record
+	// (Pinecone's sparseValues)
+	Sparse *SparseVector `json:"sparse,omitempty"`
+
+	// SparseVectors holds named sparse vectors (Qdrant) used for hybrid search
+	SparseVectors map[string]SparseVector `json:"sparse_vectors,omitempty"`
+}
+
+// SparseVector is a sparse vector as parallel index and value lists
+type SparseVector struct {
+	Indices []uint32  `json:"indices"`
+	Values  []float32 `json:"values"`
 }
 
 // DBStats holds database statistics
 type DBStats struct {
-	TotalRecords int64   `json:"total_records"`
-	Dimensions   int     `json:"dimensions"`
-	IndexType    string  `json:"index_type"`
-	MemoryUsage  float64 `json:"memory_usage_mb"`
+	TotalRecords int64                  `json:"total_records"`
+	Dimensions   int                    `json:"dimensions"`
+	IndexType    string                 `json:"index_type"`
+	MemoryUsage  float64                `json:"memory_usage_mb"`
+	Distance     string                 `json:"distance,omitempty"` // One of the Distance constants, if known
+	HNSW         *HNSWParams            `json:"hnsw,omitempty"`     // HNSW build parameters, if known
+	Details      map[string]interface{} `json:"details,omitempty"` // Provider-specific index details
 }
 
 // Database interface for vector database operations
@@ -30,6 +50,10 @@ type Database interface {
 	// GetBatch retrieves a batch of records after the given ID
 	GetBatch(ctx context.Context, afterID string, limit int) ([]Record, error)
 	
+	// FetchByIDs retrieves records by ID in the order requested. IDs that
+	// do not exist are returned in missing, not as an error.
+	FetchByIDs(ctx context.Context, ids []string) (records []Record, missing []string, err error)
+	
 	// UpsertBatch inserts or updates a batch of records
 	UpsertBatch(ctx context.Context, records []Record) error
 	
@@ -46,12 +70,61 @@ type Database interface {
 	GetSourceURL() string
 }
 
+// CursorReader is implemented by databases whose native pagination uses an
+// opaque continuation token rather than the last record ID. Callers should
+// prefer it over GetBatch and checkpoint the returned cursor.
+type CursorReader interface {
+	// GetBatchCursor retrieves a batch starting at cursor ("" for the first
+	// batch) and returns the cursor of the next batch, "" when exhausted
+	GetBatchCursor(ctx context.Context, cursor string, limit int) ([]Record, string, error)
+}
+
+// Namespaced is implemented by databases partitioned into namespaces that
+// are read and written independently. Extra["namespace"] selects one at
+// connect time; WithNamespace derives handles for the others.
+type Namespaced interface {
+	// ListNamespaces returns the names of the non-empty namespaces
+	ListNamespaces(ctx context.Context) ([]string, error)
+	
+	// WithNamespace returns a handle on the same connection scoped to namespace
+	WithNamespace(namespace string) Database
+}
+
+// Partitioned is implemented by databases whose keyspace can be split into
+// disjoint partitions that are scanned concurrently. Partition keys are
+// stable strings, so each partition can be checkpointed and resumed on its
+// own.
+type Partitioned interface {
+	// Partitions splits the keyspace into about n partitions and returns
+	// their keys. Databases with natural partitions, such as shard keys,
+	// return those whatever n is; none means the keyspace cannot be split.
+	Partitions(ctx context.Context, n int) ([]string, error)
+	
+	// WithPartition returns a handle on the same connection whose GetBatch
+	// and GetBatchCursor read only the partition. Writes are not scoped.
+	WithPartition(key string) (Database, error)
+}
+
+// SchemaProvisioner is implemented by databases that need a collection,
+// class or index created before records can be written. EnsureCollection
+// creates it from spec if it does not exist and checks an existing one is
+// compatible.
+type SchemaProvisioner interface {
+	EnsureCollection(ctx context.Context, spec CollectionSpec) error
+}
+
+// SchemaReader is implemented by databases that describe their metadata
+// fields, from a native schema API or by sampling records
+type SchemaReader interface {
+	GetSchema(ctx context.Context) (*Schema, error)
+}
+
 // DBConfig holds database connection configuration
 type DBConfig struct {
-	Type     string            `json:"type"` // pinecone, qdrant, weaviate
+	Type     string            `json:"type"` // pinecone, qdrant, weaviate, milvus, pgvector, redis, chroma, elasticsearch, opensearch, jsonl, npy
 	URL      string            `json:"url"`
 	APIKey   string            `json:"api_key"`
-	Index    string            `json:"index"` // Pinecone index name / Qdrant collection
+	Index    string            `json:"index"` // Pinecone index name / Qdrant or Milvus collection
 	Timeout  int               `json:"timeout_seconds"`
 	Extra    map[string]string `json:"extra,omitempty"` // Provider-specific settings
 }
@@ -2,7 +2,14 @@ package adapters
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
 	"testing"
+	"time"
+
+	"github.com/AlphaTechini/vector-db-migration/internal/adapters/fakes"
 )
 
 // TestDatabaseInterface ensures all adapters implement the interface
@@ -11,8 +18,16 @@ func TestDatabaseInterface(t *testing.T) {
 	var _ Database = (*PineconeAdapter)(nil)
 	var _ Database = (*QdrantAdapter)(nil)
 	var _ Database = (*WeaviateAdapter)(nil)
+	var _ Database = (*MilvusAdapter)(nil)
+	var _ Database = (*PgvectorAdapter)(nil)
+	var _ Database = (*RedisAdapter)(nil)
+	var _ Database = (*ChromaAdapter)(nil)
+	var _ Database = (*ElasticsearchAdapter)(nil)
+	var _ Database = (*JSONLAdapter)(nil)
+	var _ Database = (*NumpyAdapter)(nil)
+	var _ Database = (*MemoryAdapter)(nil)
 	
-	t.Log("✓ All adapters (Pinecone, Qdrant, Weaviate) implement Database interface")
+	t.Log("✓ All adapters (Pinecone, Qdrant, Weaviate, Milvus, pgvector, Redis, Chroma, Elasticsearch, JSONL, NumPy, Memory) implement Database interface")
 }
 
 // TestPineconeAdapterConnect tests connection validation
@@ -86,3 +101,56 @@ func TestDBConfig(t *testing.T) {
 	
 	t.Log("✓ DBConfig structure works correctly")
 }
+
+// TestClassifyError tests retry classification of adapter errors
+func TestClassifyError(t *testing.T) {
+	fake := fakes.NewPinecone(t, "docs", 2)
+	adapter := connectPinecone(t, fake.URL, "")
+	ctx := context.Background()
+	records := []Record{{ID: "a", Vector: []float32{1, 2}}}
+
+	cases := []struct {
+		fault      fakes.Fault
+		kind       ErrorKind
+		retryAfter time.Duration
+	}{
+		{fakes.Fault{Status: http.StatusTooManyRequests, RetryAfter: "3"}, ErrorRateLimited, 3 * time.Second},
+		{fakes.Fault{Status: http.StatusServiceUnavailable}, ErrorRetryable, 0},
+		{fakes.Fault{Status: http.StatusBadRequest}, ErrorPermanent, 0},
+	}
+	for _, tc := range cases {
+		tc.fault.Path = "/vectors/upsert"
+		tc.fault.Times = 1
+		fake.Inject(tc.fault)
+
+		err := adapter.UpsertBatch(ctx, records)
+		if err == nil {
+			t.Fatalf("Expected status %d to fail", tc.fault.Status)
+		}
+		kind, wait := ClassifyError(err)
+		if kind != tc.kind || wait != tc.retryAfter {
+			t.Errorf("Status %d: expected %s after %v, got %s after %v", tc.fault.Status, tc.kind, tc.retryAfter, kind, wait)
+		}
+		var adapterErr *Error
+		if !errors.As(err, &adapterErr) || adapterErr.StatusCode != tc.fault.Status {
+			t.Errorf("Expected an *Error with status %d, got %v", tc.fault.Status, err)
+		}
+	}
+
+	if kind, _ := ClassifyError(context.Canceled); kind != ErrorPermanent {
+		t.Errorf("Expected cancellation to be permanent, got %s", kind)
+	}
+	if kind, _ := ClassifyError(fmt.Errorf("read: %w", io.ErrUnexpectedEOF)); kind != ErrorRetryable {
+		t.Errorf("Expected a dropped connection to be retryable, got %s", kind)
+	}
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	if wait := retryAfter(now.Add(90*time.Second).Format(http.TimeFormat), now); wait != 90*time.Second {
+		t.Errorf("Expected an HTTP-date Retry-After of 90s, got %v", wait)
+	}
+	if wait := retryAfter("soon", now); wait != 0 {
+		t.Errorf("Expected an invalid Retry-After to be ignored, got %v", wait)
+	}
+
+	t.Log("✓ Adapter errors are classified for retrying")
+}
@@ -0,0 +1,535 @@
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ElasticsearchAdapter implements Database interface for Elasticsearch
+// dense_vector and OpenSearch knn_vector indices.
+//
+// Reads page through a point-in-time with search_after on _id, writes use
+// the _bulk API. The flavour is detected from the cluster root endpoint.
+type ElasticsearchAdapter struct {
+	config      DBConfig
+	httpClient  *http.Client
+	baseURL     string
+	sourceURL   string
+	index       string
+	openSearch  bool
+	vectorField string
+	dimension   int
+	indexType   string
+	mapping     map[string]interface{}
+	pitID       string
+}
+
+// esSearchResponse represents an Elasticsearch search response
+type esSearchResponse struct {
+	PitID string `json:"pit_id"`
+	Hits  struct {
+		Hits []struct {
+			ID     string                 `json:"_id"`
+			Source map[string]interface{} `json:"_source"`
+			Sort   []interface{}          `json:"sort"`
+		} `json:"hits"`
+	} `json:"hits"`
+}
+
+// esBulkResponse represents an Elasticsearch _bulk response
+type esBulkResponse struct {
+	Errors bool                                `json:"errors"`
+	Items  []map[string]esBulkItemResponseBody `json:"items"`
+}
+
+// esBulkItemResponseBody is the per-action result in a _bulk response
+type esBulkItemResponseBody struct {
+	ID     string `json:"_id"`
+	Status int    `json:"status"`
+	Error  *struct {
+		Type   string `json:"type"`
+		Reason string `json:"reason"`
+	} `json:"error,omitempty"`
+}
+
+// Connect establishes connection and reads the vector field from the index mapping
+func (a *ElasticsearchAdapter) Connect(ctx context.Context, config DBConfig) error {
+	if config.Type != "elasticsearch" && config.Type != "opensearch" {
+		return fmt.Errorf("expected type 'elasticsearch' or 'opensearch', got '%s'", config.Type)
+	}
+
+	a.config = config
+	a.sourceURL = config.URL
+	a.baseURL = strings.TrimRight(config.URL, "/")
+	a.index = config.Index
+
+	// Create HTTP client with timeout
+	timeout := time.Duration(config.Timeout) * time.Second
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+
+	a.httpClient = &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			MaxIdleConns:        10,
+			MaxIdleConnsPerHost: 5,
+			IdleConnTimeout:     90 * time.Second,
+		},
+	}
+
+	// Validate connection
+	if err := a.ValidateConnection(ctx); err != nil {
+		return err
+	}
+
+	return a.loadMapping(ctx)
+}
+
+// loadMapping reads the index mapping and locates the vector field
+func (a *ElasticsearchAdapter) loadMapping(ctx context.Context) error {
+	var mappings map[string]struct {
+		Mappings map[string]interface{} `json:"mappings"`
+	}
+	if err := a.do(ctx, "GET", "/"+url.PathEscape(a.index)+"/_mapping", nil, &mappings); err != nil {
+		return fmt.Errorf("failed to get index mapping: %w", err)
+	}
+
+	// The response is keyed by concrete index name, which differs for aliases
+	for _, m := range mappings {
+		a.mapping = m.Mappings
+		break
+	}
+	if a.mapping == nil {
+		return fmt.Errorf("index %s has no mapping", a.index)
+	}
+
+	properties, _ := a.mapping["properties"].(map[string]interface{})
+	a.vectorField = a.config.Extra["vector_field"]
+
+	for name, raw := range properties {
+		field, _ := raw.(map[string]interface{})
+		fieldType, _ := field["type"].(string)
+		if fieldType != "dense_vector" && fieldType != "knn_vector" {
+			continue
+		}
+		if a.vectorField != "" && a.vectorField != name {
+			continue
+		}
+		if a.vectorField == "" {
+			a.vectorField = name
+		}
+
+		switch fieldType {
+		case "dense_vector":
+			a.dimension = jsonInt(field["dims"])
+			a.indexType = "elasticsearch-dense_vector"
+			if options, ok := field["index_options"].(map[string]interface{}); ok {
+				if t, ok := options["type"].(string); ok {
+					a.indexType = "elasticsearch-" + t
+				}
+			}
+		case "knn_vector":
+			a.dimension = jsonInt(field["dimension"])
+			a.indexType = "opensearch-knn"
+			if method, ok := field["method"].(map[string]interface{}); ok {
+				if name, ok := method["name"].(string); ok {
+					a.indexType = "opensearch-" + name
+				}
+			}
+		}
+	}
+
+	if a.indexType == "" {
+		return fmt.Errorf("index %s has no dense_vector or knn_vector field %s", a.index, a.vectorField)
+	}
+
+	return nil
+}
+
+// Close releases the point-in-time and closes the HTTP client
+func (a *ElasticsearchAdapter) Close() error {
+	if a.pitID != "" && a.httpClient != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = a.closePIT(ctx)
+	}
+	if a.httpClient != nil {
+		a.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+// GetBatch retrieves a batch of documents with search_after on _id.
+// A point-in-time is opened on the first page so the scan sees a
+// consistent snapshot.
+func (a *ElasticsearchAdapter) GetBatch(ctx context.Context, afterID string, limit int) ([]Record, error) {
+	if afterID == "" && a.pitID != "" {
+		_ = a.closePIT(ctx)
+	}
+	if a.pitID == "" {
+		if err := a.openPIT(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	request := map[string]interface{}{
+		"size": limit,
+		"sort": []interface{}{map[string]string{"_id": "asc"}},
+		"pit": map[string]interface{}{
+			"id":         a.pitID,
+			"keep_alive": a.keepAlive(),
+		},
+		"track_total_hits": false,
+	}
+	if afterID != "" {
+		request["search_after"] = []string{afterID}
+	}
+
+	var searchResp esSearchResponse
+	if err := a.do(ctx, "POST", "/_search", request, &searchResp); err != nil {
+		return nil, fmt.Errorf("failed to search %s: %w", a.flavour(), err)
+	}
+
+	// The PIT id may change between requests
+	if searchResp.PitID != "" {
+		a.pitID = searchResp.PitID
+	}
+
+	records := make([]Record, 0, len(searchResp.Hits.Hits))
+	for _, hit := range searchResp.Hits.Hits {
+		records = append(records, a.toRecord(hit.ID, hit.Source))
+	}
+
+	return records, nil
+}
+
+// esFetchLimit is the number of documents read per _mget request
+const esFetchLimit = 1000
+
+// FetchByIDs reads documents with the _mget API
+func (a *ElasticsearchAdapter) FetchByIDs(ctx context.Context, ids []string) ([]Record, []string, error) {
+	return fetchByIDs(ids, esFetchLimit, func(chunk []string) ([]Record, error) {
+		var mgetResp struct {
+			Docs []struct {
+				ID     string                 `json:"_id"`
+				Found  bool                   `json:"found"`
+				Source map[string]interface{} `json:"_source"`
+			} `json:"docs"`
+		}
+		path := "/" + url.PathEscape(a.index) + "/_mget"
+		if err := a.do(ctx, "POST", path, map[string]interface{}{"ids": chunk}, &mgetResp); err != nil {
+			return nil, fmt.Errorf("failed to get documents from %s: %w", a.flavour(), err)
+		}
+
+		records := make([]Record, 0, len(mgetResp.Docs))
+		for _, doc := range mgetResp.Docs {
+			if doc.Found {
+				records = append(records, a.toRecord(doc.ID, doc.Source))
+			}
+		}
+		return records, nil
+	})
+}
+
+// toRecord converts a document's source into a record
+func (a *ElasticsearchAdapter) toRecord(id string, source map[string]interface{}) Record {
+	record := Record{
+		ID:       id,
+		Metadata: make(map[string]interface{}),
+	}
+
+	for key, value := range source {
+		if key != a.vectorField {
+			record.Metadata[key] = value
+			continue
+		}
+		values, _ := value.([]interface{})
+		record.Vector = make([]float32, len(values))
+		for i, v := range values {
+			if f, ok := v.(float64); ok {
+				record.Vector[i] = float32(f)
+			}
+		}
+	}
+
+	return record
+}
+
+// UpsertBatch indexes documents with the _bulk API
+func (a *ElasticsearchAdapter) UpsertBatch(ctx context.Context, records []Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, r := range records {
+		action := map[string]interface{}{
+			"index": map[string]string{"_index": a.index, "_id": r.ID},
+		}
+
+		doc := make(map[string]interface{}, len(r.Metadata)+1)
+		for key, value := range r.Metadata {
+			doc[key] = value
+		}
+		doc[a.vectorField] = r.Vector
+
+		if err := encoder.Encode(action); err != nil {
+			return fmt.Errorf("failed to marshal bulk action: %w", err)
+		}
+		if err := encoder.Encode(doc); err != nil {
+			return fmt.Errorf("failed to marshal document %s: %w", r.ID, err)
+		}
+	}
+
+	if err := a.bulk(ctx, &body); err != nil {
+		return fmt.Errorf("failed to upsert to %s: %w", a.flavour(), err)
+	}
+
+	return nil
+}
+
+// DeleteBatch deletes documents by IDs with the _bulk API
+func (a *ElasticsearchAdapter) DeleteBatch(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, id := range ids {
+		action := map[string]interface{}{
+			"delete": map[string]string{"_index": a.index, "_id": id},
+		}
+		if err := encoder.Encode(action); err != nil {
+			return fmt.Errorf("failed to marshal bulk action: %w", err)
+		}
+	}
+
+	if err := a.bulk(ctx, &body); err != nil {
+		return fmt.Errorf("failed to delete from %s: %w", a.flavour(), err)
+	}
+
+	return nil
+}
+
+// ValidateConnection checks the cluster root endpoint and detects OpenSearch
+func (a *ElasticsearchAdapter) ValidateConnection(ctx context.Context) error {
+	var info struct {
+		Version struct {
+			Distribution string `json:"distribution"`
+			Number       string `json:"number"`
+		} `json:"version"`
+	}
+	if err := a.do(ctx, "GET", "/", nil, &info); err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", a.config.Type, err)
+	}
+
+	a.openSearch = info.Version.Distribution == "opensearch"
+	return nil
+}
+
+// GetStats returns document count and the index mapping
+func (a *ElasticsearchAdapter) GetStats(ctx context.Context) (*DBStats, error) {
+	var countResp struct {
+		Count int64 `json:"count"`
+	}
+	if err := a.do(ctx, "GET", "/"+url.PathEscape(a.index)+"/_count", nil, &countResp); err != nil {
+		return nil, fmt.Errorf("failed to get stats from %s: %w", a.flavour(), err)
+	}
+
+	return &DBStats{
+		TotalRecords: countResp.Count,
+		Dimensions:   a.dimension,
+		IndexType:    a.indexType,
+		MemoryUsage:  0, // Not tracked per index
+		Details: map[string]interface{}{
+			"vector_field": a.vectorField,
+			"mapping":      a.mapping,
+		},
+	}, nil
+}
+
+// GetSourceURL returns the cluster URL
+func (a *ElasticsearchAdapter) GetSourceURL() string {
+	return a.sourceURL
+}
+
+// elasticsearchCapabilities, shared with OpenSearch: documents nest and
+// fields are mapped dynamically
+var elasticsearchCapabilities = Capabilities{
+	NestedMetadata: true,
+	IDFormats:      []IDFormat{IDString},
+}
+
+// Capabilities returns what the index can store
+func (a *ElasticsearchAdapter) Capabilities() Capabilities {
+	return elasticsearchCapabilities
+}
+
+// openPIT opens a point-in-time over the index
+func (a *ElasticsearchAdapter) openPIT(ctx context.Context) error {
+	path := "/" + url.PathEscape(a.index) + "/_pit?keep_alive=" + a.keepAlive()
+	if a.openSearch {
+		path = "/" + url.PathEscape(a.index) + "/_search/point_in_time?keep_alive=" + a.keepAlive()
+	}
+
+	var pit struct {
+		ID    string `json:"id"`
+		PitID string `json:"pit_id"`
+	}
+	if err := a.do(ctx, "POST", path, nil, &pit); err != nil {
+		return fmt.Errorf("failed to open point-in-time: %w", err)
+	}
+
+	a.pitID = pit.ID
+	if a.openSearch {
+		a.pitID = pit.PitID
+	}
+	if a.pitID == "" {
+		return fmt.Errorf("failed to open point-in-time: empty id")
+	}
+
+	return nil
+}
+
+// closePIT releases the current point-in-time
+func (a *ElasticsearchAdapter) closePIT(ctx context.Context) error {
+	var err error
+	if a.openSearch {
+		err = a.do(ctx, "DELETE", "/_search/point_in_time", map[string][]string{"pit_id": {a.pitID}}, nil)
+	} else {
+		err = a.do(ctx, "DELETE", "/_pit", map[string]string{"id": a.pitID}, nil)
+	}
+	a.pitID = ""
+	return err
+}
+
+// keepAlive returns the point-in-time keep-alive (Extra["keep_alive"], default 5m)
+func (a *ElasticsearchAdapter) keepAlive() string {
+	if keepAlive := a.config.Extra["keep_alive"]; keepAlive != "" {
+		return keepAlive
+	}
+	return "5m"
+}
+
+// flavour names the backend in error messages
+func (a *ElasticsearchAdapter) flavour() string {
+	if a.openSearch {
+		return "OpenSearch"
+	}
+	return "Elasticsearch"
+}
+
+// bulk sends an NDJSON body to _bulk and reports the first item failure
+func (a *ElasticsearchAdapter) bulk(ctx context.Context, body *bytes.Buffer) error {
+	req, err := http.NewRequestWithContext(ctx, "POST", a.baseURL+"/_bulk", body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	a.authorize(req)
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return apiError(a.flavour(), resp)
+	}
+
+	var bulkResp esBulkResponse
+	if err := json.NewDecoder(resp.Body).Decode(&bulkResp); err != nil {
+		return fmt.Errorf("failed to decode bulk response: %w", err)
+	}
+
+	if !bulkResp.Errors {
+		return nil
+	}
+
+	for _, item := range bulkResp.Items {
+		for action, result := range item {
+			// Deleting a missing document is not a failure
+			if action == "delete" && result.Status == http.StatusNotFound {
+				continue
+			}
+			if result.Error != nil {
+				return fmt.Errorf("bulk %s of %s failed: %s: %s", action, result.ID, result.Error.Type, result.Error.Reason)
+			}
+		}
+	}
+
+	return nil
+}
+
+// do sends a JSON request and decodes the response into out
+func (a *ElasticsearchAdapter) do(ctx context.Context, method, path string, body interface{}, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		jsonData, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reader = bytes.NewBuffer(jsonData)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, a.baseURL+path, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	a.authorize(req)
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return apiError(a.flavour(), resp)
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
+
+// authorize sets the API key header; basic auth comes from the URL
+func (a *ElasticsearchAdapter) authorize(req *http.Request) {
+	if a.config.APIKey != "" {
+		req.Header.Set("Authorization", "ApiKey "+a.config.APIKey)
+	}
+}
+
+// jsonInt converts a decoded JSON number into an int
+func jsonInt(value interface{}) int {
+	if f, ok := value.(float64); ok {
+		return int(f)
+	}
+	return 0
+}
+
+// Ensure ElasticsearchAdapter implements Database interface
+var _ Database = (*ElasticsearchAdapter)(nil)
+
+// Ensure ElasticsearchAdapter implements CapabilityReporter interface
+var _ CapabilityReporter = (*ElasticsearchAdapter)(nil)
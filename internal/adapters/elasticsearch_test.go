@@ -0,0 +1,317 @@
+package adapters
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+)
+
+// fakeSearch is a minimal in-process stand-in for Elasticsearch and OpenSearch
+type fakeSearch struct {
+	mu         sync.Mutex
+	openSearch bool
+	docs       map[string]map[string]interface{}
+	pits       map[string]bool
+	pitCount   int
+}
+
+func newFakeSearch(t *testing.T, openSearch bool) (*fakeSearch, *httptest.Server) {
+	fake := &fakeSearch{
+		openSearch: openSearch,
+		docs:       make(map[string]map[string]interface{}),
+		pits:       make(map[string]bool),
+	}
+	server := httptest.NewServer(http.HandlerFunc(fake.handle))
+	t.Cleanup(server.Close)
+	return fake, server
+}
+
+func (f *fakeSearch) handle(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	route := r.Method + " " + r.URL.Path
+
+	switch route {
+	case "GET /":
+		version := map[string]string{"number": "8.13.0"}
+		if f.openSearch {
+			version = map[string]string{"number": "2.13.0", "distribution": "opensearch"}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"version": version})
+
+	case "GET /products/_mapping":
+		vector := map[string]interface{}{"type": "dense_vector", "dims": 3, "index_options": map[string]string{"type": "hnsw"}}
+		if f.openSearch {
+			vector = map[string]interface{}{"type": "knn_vector", "dimension": 3, "method": map[string]string{"name": "hnsw"}}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"products-v2": map[string]interface{}{
+				"mappings": map[string]interface{}{
+					"properties": map[string]interface{}{
+						"name":      map[string]string{"type": "keyword"},
+						"embedding": vector,
+					},
+				},
+			},
+		})
+
+	case "POST /products/_pit", "POST /products/_search/point_in_time":
+		f.pitCount++
+		id := fmt.Sprintf("pit-%d", f.pitCount)
+		f.pits[id] = true
+		if f.openSearch {
+			json.NewEncoder(w).Encode(map[string]string{"pit_id": id})
+		} else {
+			json.NewEncoder(w).Encode(map[string]string{"id": id})
+		}
+
+	case "DELETE /_pit", "DELETE /_search/point_in_time":
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if id, ok := body["id"].(string); ok {
+			delete(f.pits, id)
+		}
+		if ids, ok := body["pit_id"].([]interface{}); ok {
+			for _, id := range ids {
+				delete(f.pits, id.(string))
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]bool{"succeeded": true})
+
+	case "POST /_search":
+		var body struct {
+			Size        int      `json:"size"`
+			SearchAfter []string `json:"search_after"`
+			Pit         struct {
+				ID string `json:"id"`
+			} `json:"pit"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if !f.pits[body.Pit.ID] {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]string{"error": "search_context_missing_exception"})
+			return
+		}
+
+		ids := make([]string, 0, len(f.docs))
+		for id := range f.docs {
+			if len(body.SearchAfter) == 0 || id > body.SearchAfter[0] {
+				ids = append(ids, id)
+			}
+		}
+		sort.Strings(ids)
+		if len(ids) > body.Size {
+			ids = ids[:body.Size]
+		}
+
+		hits := make([]map[string]interface{}, len(ids))
+		for i, id := range ids {
+			hits[i] = map[string]interface{}{"_id": id, "_source": f.docs[id], "sort": []string{id}}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"pit_id": body.Pit.ID,
+			"hits":   map[string]interface{}{"hits": hits},
+		})
+
+	case "POST /_bulk":
+		scanner := bufio.NewScanner(r.Body)
+		scanner.Buffer(make([]byte, 1<<20), 1<<20)
+		items := []map[string]interface{}{}
+		errors := false
+		for scanner.Scan() {
+			var action map[string]map[string]string
+			json.Unmarshal(scanner.Bytes(), &action)
+			if meta, ok := action["index"]; ok {
+				scanner.Scan()
+				var doc map[string]interface{}
+				json.Unmarshal(scanner.Bytes(), &doc)
+				if vector, _ := doc["embedding"].([]interface{}); len(vector) != 3 {
+					errors = true
+					items = append(items, map[string]interface{}{"index": map[string]interface{}{
+						"_id": meta["_id"], "status": 400,
+						"error": map[string]string{"type": "mapper_parsing_exception", "reason": "wrong dims"},
+					}})
+					continue
+				}
+				f.docs[meta["_id"]] = doc
+				items = append(items, map[string]interface{}{"index": map[string]interface{}{"_id": meta["_id"], "status": 201}})
+			}
+			if meta, ok := action["delete"]; ok {
+				status := 200
+				if _, exists := f.docs[meta["_id"]]; !exists {
+					status = 404
+					errors = true
+				}
+				delete(f.docs, meta["_id"])
+				items = append(items, map[string]interface{}{"delete": map[string]interface{}{"_id": meta["_id"], "status": status}})
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"errors": errors, "items": items})
+
+	case "POST /products/_mget":
+		var body struct {
+			IDs []string `json:"ids"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+
+		docs := make([]map[string]interface{}, len(body.IDs))
+		for i, id := range body.IDs {
+			docs[i] = map[string]interface{}{"_id": id, "found": false}
+			if source, exists := f.docs[id]; exists {
+				docs[i]["found"] = true
+				docs[i]["_source"] = source
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"docs": docs})
+
+	case "GET /products/_count":
+		json.NewEncoder(w).Encode(map[string]int{"count": len(f.docs)})
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"error": "no handler for " + route})
+	}
+}
+
+func connectSearch(t *testing.T, dbType, url string) *ElasticsearchAdapter {
+	adapter := &ElasticsearchAdapter{}
+	err := adapter.Connect(context.Background(), DBConfig{
+		Type:  dbType,
+		URL:   url,
+		Index: "products",
+	})
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	return adapter
+}
+
+// TestElasticsearchAdapterConnect tests mapping discovery for both flavours
+func TestElasticsearchAdapterConnect(t *testing.T) {
+	_, esServer := newFakeSearch(t, false)
+	es := connectSearch(t, "elasticsearch", esServer.URL)
+	defer es.Close()
+
+	if es.openSearch || es.vectorField != "embedding" || es.dimension != 3 || es.indexType != "elasticsearch-hnsw" {
+		t.Errorf("Unexpected Elasticsearch settings: opensearch=%v field=%s dims=%d type=%s",
+			es.openSearch, es.vectorField, es.dimension, es.indexType)
+	}
+
+	_, osServer := newFakeSearch(t, true)
+	os := connectSearch(t, "opensearch", osServer.URL)
+	defer os.Close()
+
+	if !os.openSearch || os.dimension != 3 || os.indexType != "opensearch-hnsw" {
+		t.Errorf("Unexpected OpenSearch settings: opensearch=%v dims=%d type=%s", os.openSearch, os.dimension, os.indexType)
+	}
+
+	wrongType := &ElasticsearchAdapter{}
+	if err := wrongType.Connect(context.Background(), DBConfig{Type: "qdrant"}); err == nil {
+		t.Error("Expected error for invalid type, got nil")
+	}
+
+	t.Log("✓ ElasticsearchAdapter reads vector field from the index mapping")
+}
+
+// TestElasticsearchAdapterRoundTrip tests bulk writes and search_after reads
+func TestElasticsearchAdapterRoundTrip(t *testing.T) {
+	for _, openSearch := range []bool{false, true} {
+		fake, server := newFakeSearch(t, openSearch)
+		adapter := connectSearch(t, "elasticsearch", server.URL)
+		ctx := context.Background()
+
+		var records []Record
+		for i := 0; i < 17; i++ {
+			records = append(records, Record{
+				ID:       fmt.Sprintf("sku-%02d", i),
+				Vector:   []float32{float32(i), 0, 1},
+				Metadata: map[string]interface{}{"name": fmt.Sprintf("product %d", i)},
+			})
+		}
+
+		if err := adapter.UpsertBatch(ctx, records); err != nil {
+			t.Fatalf("Failed to upsert: %v", err)
+		}
+
+		var seen []Record
+		afterID := ""
+		for {
+			batch, err := adapter.GetBatch(ctx, afterID, 5)
+			if err != nil {
+				t.Fatalf("Failed to get batch: %v", err)
+			}
+			if len(batch) == 0 {
+				break
+			}
+			seen = append(seen, batch...)
+			afterID = batch[len(batch)-1].ID
+		}
+
+		if len(seen) != 17 {
+			t.Fatalf("Expected 17 records, got %d", len(seen))
+		}
+
+		if seen[16].ID != "sku-16" || seen[16].Vector[0] != 16 || seen[16].Metadata["name"] != "product 16" {
+			t.Errorf("Unexpected last record: %+v", seen[16])
+		}
+
+		if _, exists := seen[0].Metadata["embedding"]; exists {
+			t.Error("Expected vector field to be removed from metadata")
+		}
+
+		if fake.pitCount != 1 {
+			t.Errorf("Expected one point-in-time for the scan, got %d", fake.pitCount)
+		}
+
+		adapter.Close()
+		if len(fake.pits) != 0 {
+			t.Errorf("Expected point-in-time to be released on Close, %d still open", len(fake.pits))
+		}
+	}
+
+	t.Log("✓ ElasticsearchAdapter pages with search_after over a point-in-time")
+}
+
+// TestElasticsearchAdapterBulkErrors tests per-item bulk failures and stats
+func TestElasticsearchAdapterBulkErrors(t *testing.T) {
+	_, server := newFakeSearch(t, false)
+	adapter := connectSearch(t, "elasticsearch", server.URL)
+	defer adapter.Close()
+	ctx := context.Background()
+
+	err := adapter.UpsertBatch(ctx, []Record{
+		{ID: "ok", Vector: []float32{1, 2, 3}},
+		{ID: "bad", Vector: []float32{1, 2}},
+	})
+	if err == nil {
+		t.Fatal("Expected error for rejected bulk item")
+	}
+
+	if err := adapter.DeleteBatch(ctx, []string{"ok", "missing"}); err != nil {
+		t.Errorf("Expected delete of missing document to succeed, got %v", err)
+	}
+
+	stats, err := adapter.GetStats(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get stats: %v", err)
+	}
+
+	if stats.TotalRecords != 0 || stats.Dimensions != 3 {
+		t.Errorf("Expected 0 records with 3 dimensions, got %+v", stats)
+	}
+
+	mapping, ok := stats.Details["mapping"].(map[string]interface{})
+	if !ok || mapping["properties"] == nil {
+		t.Errorf("Expected index mapping in stats details, got %v", stats.Details)
+	}
+
+	t.Log("✓ ElasticsearchAdapter reports bulk item failures and exposes its mapping")
+}
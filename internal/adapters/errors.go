@@ -0,0 +1,111 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ErrorKind says whether a failed call may succeed if repeated
+type ErrorKind string
+
+const (
+	// ErrorRetryable marks transient failures: timeouts, dropped
+	// connections and server errors such as 503
+	ErrorRetryable ErrorKind = "retryable"
+
+	// ErrorRateLimited marks calls the database throttled (429); retry
+	// after Error.RetryAfter if it is set
+	ErrorRateLimited ErrorKind = "rate_limited"
+
+	// ErrorPermanent marks failures repeating the call cannot fix, such as
+	// invalid requests and authentication errors
+	ErrorPermanent ErrorKind = "permanent"
+)
+
+// Error is an adapter error classified for retrying
+type Error struct {
+	Kind ErrorKind
+
+	// StatusCode is the HTTP status of the failed call, if any
+	StatusCode int
+
+	// RetryAfter is how long the database asked callers to wait, if it did
+	RetryAfter time.Duration
+
+	Err error
+}
+
+// Error returns the underlying error's message
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// ClassifyError returns the kind of err and how long to wait before
+// retrying, if the database said. Network errors are retryable; context
+// cancellation and errors adapters did not classify are permanent.
+func ClassifyError(err error) (ErrorKind, time.Duration) {
+	var adapterErr *Error
+	if errors.As(err, &adapterErr) {
+		return adapterErr.Kind, adapterErr.RetryAfter
+	}
+	if errors.Is(err, context.Canceled) {
+		return ErrorPermanent, 0
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return ErrorRetryable, 0
+	}
+	return ErrorPermanent, 0
+}
+
+// apiError reads a failed response into an Error whose message names the
+// API, e.g. "Qdrant API error (503): ...". 429 is rate limited; 408, 500,
+// 502, 503 and 504 are retryable; any other status is permanent.
+func apiError(api string, resp *http.Response) error {
+	body, _ := io.ReadAll(resp.Body)
+	return &Error{
+		Kind:       statusKind(resp.StatusCode),
+		StatusCode: resp.StatusCode,
+		RetryAfter: retryAfter(resp.Header.Get("Retry-After"), time.Now()),
+		Err:        fmt.Errorf("%s API error (%d): %s", api, resp.StatusCode, string(body)),
+	}
+}
+
+// statusKind classifies an HTTP error status
+func statusKind(status int) ErrorKind {
+	switch status {
+	case http.StatusTooManyRequests:
+		return ErrorRateLimited
+	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return ErrorRetryable
+	}
+	return ErrorPermanent
+}
+
+// retryAfter parses a Retry-After header, given in seconds or as an HTTP
+// date, into a wait from now
+func retryAfter(header string, now time.Time) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(header); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(header); err == nil && at.After(now) {
+		return at.Sub(now)
+	}
+	return 0
+}
@@ -0,0 +1,157 @@
+package adapters
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strconv"
+)
+
+// faissIndex is the content of a FAISS IndexFlat, optionally wrapped in an
+// IndexIDMap. Vectors point into the mapped file.
+type faissIndex struct {
+	dim     int
+	rows    int
+	metric  string
+	vectors []byte
+	ids     []string
+}
+
+// faissReader walks a serialized FAISS index
+type faissReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *faissReader) take(n int) ([]byte, error) {
+	if n < 0 || r.pos+n > len(r.data) {
+		return nil, fmt.Errorf("truncated FAISS index at offset %d", r.pos)
+	}
+	b := r.data[r.pos : r.pos+n]
+	r.pos += n
+	return b, nil
+}
+
+func (r *faissReader) int32() (int32, error) {
+	b, err := r.take(4)
+	if err != nil {
+		return 0, err
+	}
+	return int32(binary.LittleEndian.Uint32(b)), nil
+}
+
+func (r *faissReader) int64() (int64, error) {
+	b, err := r.take(8)
+	if err != nil {
+		return 0, err
+	}
+	return int64(binary.LittleEndian.Uint64(b)), nil
+}
+
+// parseFaissIndex decodes an IndexFlat ("IxFI", "IxF2", "IxFl") or an
+// IndexIDMap/IndexIDMap2 ("IxMp", "IxM2") around one
+func parseFaissIndex(data []byte) (*faissIndex, error) {
+	r := &faissReader{data: data}
+	return r.index()
+}
+
+func (r *faissReader) index() (*faissIndex, error) {
+	fourcc, err := r.take(4)
+	if err != nil {
+		return nil, err
+	}
+
+	switch string(fourcc) {
+	case "IxFI", "IxF2", "IxFl":
+		dim, rows, metric, err := r.header()
+		if err != nil {
+			return nil, err
+		}
+
+		count, err := r.int64()
+		if err != nil {
+			return nil, err
+		}
+		if count != int64(dim)*int64(rows) {
+			return nil, fmt.Errorf("FAISS index stores %d floats, expected %d x %d", count, rows, dim)
+		}
+
+		vectors, err := r.take(int(count) * 4)
+		if err != nil {
+			return nil, err
+		}
+
+		return &faissIndex{dim: dim, rows: rows, metric: metric, vectors: vectors}, nil
+
+	case "IxMp", "IxM2":
+		if _, _, _, err := r.header(); err != nil {
+			return nil, err
+		}
+
+		inner, err := r.index()
+		if err != nil {
+			return nil, err
+		}
+
+		count, err := r.int64()
+		if err != nil {
+			return nil, err
+		}
+		if count != int64(inner.rows) {
+			return nil, fmt.Errorf("FAISS ID map has %d IDs for %d vectors", count, inner.rows)
+		}
+
+		inner.ids = make([]string, inner.rows)
+		for i := range inner.ids {
+			id, err := r.int64()
+			if err != nil {
+				return nil, err
+			}
+			inner.ids[i] = strconv.FormatInt(id, 10)
+		}
+
+		return inner, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported FAISS index type %q, only IndexFlat and IndexIDMap are supported", fourcc)
+	}
+}
+
+// header reads the fields every FAISS index starts with
+func (r *faissReader) header() (int, int, string, error) {
+	dim, err := r.int32()
+	if err != nil {
+		return 0, 0, "", err
+	}
+
+	rows, err := r.int64()
+	if err != nil {
+		return 0, 0, "", err
+	}
+
+	// Two unused int64 fields and the is_trained flag
+	if _, err := r.take(17); err != nil {
+		return 0, 0, "", err
+	}
+
+	metricType, err := r.int32()
+	if err != nil {
+		return 0, 0, "", err
+	}
+	if metricType > 1 {
+		// metric_arg follows for metrics other than inner product and L2
+		if _, err := r.take(4); err != nil {
+			return 0, 0, "", err
+		}
+	}
+
+	metric := map[int32]string{0: "inner_product", 1: "l2"}[metricType]
+	if metric == "" {
+		metric = fmt.Sprintf("metric-%d", metricType)
+	}
+
+	if dim <= 0 || rows < 0 {
+		return 0, 0, "", fmt.Errorf("invalid FAISS header: d=%d ntotal=%d", dim, rows)
+	}
+
+	return int(dim), int(rows), metric, nil
+}
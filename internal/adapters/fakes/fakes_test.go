@@ -0,0 +1,300 @@
+package fakes
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// call sends a JSON request and decodes the JSON response into out
+func call(t *testing.T, method, url string, body interface{}, out interface{}) int {
+	t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("marshal request: %v", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, url, reader)
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, url, err)
+	}
+	defer resp.Body.Close()
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			t.Fatalf("decode %s %s: %v", method, url, err)
+		}
+	}
+	return resp.StatusCode
+}
+
+func TestServerLatencyAndFaults(t *testing.T) {
+	p := NewPinecone(t, "docs", 2)
+
+	p.SetLatency(50 * time.Millisecond)
+	start := time.Now()
+	call(t, "GET", p.URL+"/indexes/docs", nil, nil)
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("Expected latency of at least 50ms, got %v", elapsed)
+	}
+	p.SetLatency(0)
+
+	p.Inject(Fault{Method: "POST", Path: "/vectors/upsert", Status: http.StatusTooManyRequests, Body: `{"message":"slow down"}`, Times: 2})
+	upsert := map[string]interface{}{"vectors": []PineconeVector{{ID: "a", Values: []float32{1, 2}}}}
+	for i := 0; i < 2; i++ {
+		if status := call(t, "POST", p.URL+"/vectors/upsert", upsert, nil); status != http.StatusTooManyRequests {
+			t.Fatalf("Attempt %d: expected 429, got %d", i, status)
+		}
+	}
+	if status := call(t, "POST", p.URL+"/vectors/upsert", upsert, nil); status != http.StatusOK {
+		t.Fatalf("Expected fault to expire after 2 uses, got %d", status)
+	}
+	if got := p.Vectors(""); len(got) != 1 {
+		t.Errorf("Expected 1 stored vector, got %d", len(got))
+	}
+
+	if exchanges := p.Exchanges(); len(exchanges) != 4 || exchanges[1].Status != http.StatusTooManyRequests {
+		t.Errorf("Unexpected recorded exchanges: %+v", exchanges)
+	}
+
+	t.Log("✓ Latency and fault injection work")
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	q := NewQdrant(t)
+	q.CreateCollection("docs", map[string]interface{}{"size": 2, "distance": "Cosine"})
+
+	recorder := NewRecorder(t, q.URL)
+	upsert := map[string]interface{}{"points": []QdrantPoint{{ID: 7, Vector: []float32{0.5, 1}}}}
+	call(t, "PUT", recorder.URL+"/collections/docs/points", upsert, nil)
+
+	var live map[string]interface{}
+	call(t, "POST", recorder.URL+"/collections/docs/points/scroll", map[string]interface{}{"limit": 10, "with_vector": true}, &live)
+
+	path := filepath.Join(t.TempDir(), "qdrant.json")
+	if err := recorder.SaveFixture(path); err != nil {
+		t.Fatalf("SaveFixture failed: %v", err)
+	}
+	fixture, err := LoadFixture(path)
+	if err != nil {
+		t.Fatalf("LoadFixture failed: %v", err)
+	}
+	if len(fixture.Exchanges) != 2 {
+		t.Fatalf("Expected 2 recorded exchanges, got %d", len(fixture.Exchanges))
+	}
+
+	replay := NewReplay(t, fixture)
+	call(t, "PUT", replay.URL+"/collections/docs/points", upsert, nil)
+
+	var replayed map[string]interface{}
+	call(t, "POST", replay.URL+"/collections/docs/points/scroll", map[string]interface{}{"with_vector": true, "limit": 10}, &replayed)
+
+	liveJSON, _ := json.Marshal(live)
+	replayedJSON, _ := json.Marshal(replayed)
+	if !bytes.Equal(liveJSON, replayedJSON) {
+		t.Errorf("Replayed response differs:\nlive:     %s\nreplayed: %s", liveJSON, replayedJSON)
+	}
+
+	t.Log("✓ Recorded exchanges replay")
+}
+
+func TestPineconeListPagination(t *testing.T) {
+	p := NewPinecone(t, "docs", 2)
+	p.RequireAPIKey("secret")
+	for _, id := range []string{"c", "a", "e", "b", "d"} {
+		p.Seed("ns1", PineconeVector{ID: id, Values: []float32{1, 0}})
+	}
+
+	req, _ := http.NewRequest("GET", p.URL+"/vectors/list?namespace=ns1", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected 401 without API key, got %d", resp.StatusCode)
+	}
+	p.RequireAPIKey("")
+
+	var ids []string
+	token := ""
+	for pages := 0; pages < 10; pages++ {
+		url := p.URL + "/vectors/list?namespace=ns1&limit=2"
+		if token != "" {
+			url += "&paginationToken=" + token
+		}
+
+		var page struct {
+			Vectors    []struct{ ID string }  `json:"vectors"`
+			Pagination *struct{ Next string } `json:"pagination"`
+		}
+		call(t, "GET", url, nil, &page)
+		for _, v := range page.Vectors {
+			ids = append(ids, v.ID)
+		}
+		if page.Pagination == nil {
+			break
+		}
+		if page.Pagination.Next == page.Vectors[len(page.Vectors)-1].ID {
+			t.Fatalf("Pagination token should be opaque, got raw ID %q", page.Pagination.Next)
+		}
+		token = page.Pagination.Next
+	}
+
+	if got, _ := json.Marshal(ids); string(got) != `["a","b","c","d","e"]` {
+		t.Errorf("Expected all IDs in order, got %s", got)
+	}
+
+	var fetched struct {
+		Vectors map[string]PineconeVector `json:"vectors"`
+	}
+	call(t, "GET", p.URL+"/vectors/fetch?namespace=ns1&ids=b&ids=zz", nil, &fetched)
+	if len(fetched.Vectors) != 1 || fetched.Vectors["b"].Values[0] != 1 {
+		t.Errorf("Unexpected fetch result: %+v", fetched.Vectors)
+	}
+
+	t.Log("✓ Pinecone list pagination is opaque and complete")
+}
+
+func TestQdrantIDsAndScroll(t *testing.T) {
+	q := NewQdrant(t)
+	q.CreateCollection("docs", map[string]interface{}{"size": 2, "distance": "Cosine"})
+
+	var failed map[string]interface{}
+	status := call(t, "PUT", q.URL+"/collections/docs/points", map[string]interface{}{
+		"points": []QdrantPoint{{ID: "doc-1", Vector: []float32{1, 0}}},
+	}, &failed)
+	if status != http.StatusBadRequest {
+		t.Errorf("Expected 400 for a non-UUID string ID, got %d: %v", status, failed)
+	}
+
+	uuid := "550e8400-e29b-41d4-a716-446655440000"
+	call(t, "PUT", q.URL+"/collections/docs/points", map[string]interface{}{
+		"points": []QdrantPoint{
+			{ID: 2, Vector: []float32{1, 0}},
+			{ID: uuid, Vector: []float32{0, 1}},
+			{ID: 1, Vector: []float32{1, 1}},
+		},
+	}, nil)
+
+	var page struct {
+		Result struct {
+			Points         []QdrantPoint `json:"points"`
+			NextPageOffset interface{}   `json:"next_page_offset"`
+		} `json:"result"`
+	}
+	call(t, "POST", q.URL+"/collections/docs/points/scroll", map[string]interface{}{"limit": 2}, &page)
+	if len(page.Result.Points) != 2 || page.Result.NextPageOffset != uuid {
+		t.Fatalf("Unexpected first page: %+v", page.Result)
+	}
+
+	// The offset is inclusive: the next page starts with the offset point
+	call(t, "POST", q.URL+"/collections/docs/points/scroll", map[string]interface{}{"limit": 2, "offset": page.Result.NextPageOffset}, &page)
+	if len(page.Result.Points) != 1 || page.Result.Points[0].ID != uuid || page.Result.NextPageOffset != nil {
+		t.Errorf("Unexpected second page: %+v", page.Result)
+	}
+
+	t.Log("✓ Qdrant validates IDs and scrolls with inclusive offsets")
+}
+
+func TestWeaviateGraphQLAndBatch(t *testing.T) {
+	wv := NewWeaviate(t)
+	if err := wv.CreateClass(map[string]interface{}{"class": "Article"}); err != nil {
+		t.Fatalf("CreateClass failed: %v", err)
+	}
+
+	ids := []string{
+		"00000000-0000-4000-8000-000000000001",
+		"00000000-0000-4000-8000-000000000002",
+		"00000000-0000-4000-8000-000000000003",
+	}
+	objects := []map[string]interface{}{{"class": "Article", "id": "not-a-uuid", "vector": []float32{1, 0}}}
+	for i, id := range ids {
+		objects = append(objects, map[string]interface{}{
+			"class":      "Article",
+			"id":         id,
+			"vector":     []float32{float32(i), 1},
+			"properties": map[string]interface{}{"title": id[len(id)-1:]},
+		})
+	}
+
+	var results []struct {
+		ID     string `json:"id"`
+		Result struct {
+			Errors *struct {
+				Error []struct{ Message string } `json:"error"`
+			} `json:"errors"`
+		} `json:"result"`
+	}
+	if status := call(t, "POST", wv.URL+"/v1/batch/objects", map[string]interface{}{"objects": objects}, &results); status != http.StatusOK {
+		t.Fatalf("Expected 200 for a partially failed batch, got %d", status)
+	}
+	if len(results) != 4 || results[0].Result.Errors == nil || results[1].Result.Errors != nil {
+		t.Fatalf("Expected only the invalid UUID to fail: %+v", results)
+	}
+	if got := wv.Objects("Article", ""); len(got) != 3 {
+		t.Fatalf("Expected 3 stored objects, got %d", len(got))
+	}
+
+	query := `{ Get { Article(limit: 2, after: "` + ids[0] + `") { title _additional { id vector } } } }`
+	var get struct {
+		Data struct {
+			Get map[string][]struct {
+				Title      string `json:"title"`
+				Additional struct {
+					ID     string    `json:"id"`
+					Vector []float32 `json:"vector"`
+				} `json:"_additional"`
+			} `json:"Get"`
+		} `json:"data"`
+	}
+	call(t, "POST", wv.URL+"/v1/graphql", map[string]string{"query": query}, &get)
+	articles := get.Data.Get["Article"]
+	if len(articles) != 2 || articles[0].Additional.ID != ids[1] || articles[0].Title != "2" || len(articles[1].Additional.Vector) != 2 {
+		t.Errorf("Unexpected Get result: %+v", articles)
+	}
+
+	var failedQuery struct {
+		Errors []struct{ Message string } `json:"errors"`
+	}
+	call(t, "POST", wv.URL+"/v1/graphql", map[string]string{"query": `{ Get { Missing { _additional { id } } } }`}, &failedQuery)
+	if len(failedQuery.Errors) != 1 {
+		t.Errorf("Expected a GraphQL error for an unknown class, got %+v", failedQuery)
+	}
+
+	var aggregate struct {
+		Data struct {
+			Aggregate map[string][]struct {
+				Meta struct{ Count int } `json:"meta"`
+			} `json:"Aggregate"`
+		} `json:"data"`
+	}
+	call(t, "POST", wv.URL+"/v1/graphql", map[string]string{"query": `{ Aggregate { Article { meta { count } } } }`}, &aggregate)
+	if got := aggregate.Data.Aggregate["Article"]; len(got) != 1 || got[0].Meta.Count != 3 {
+		t.Errorf("Unexpected Aggregate result: %+v", got)
+	}
+
+	if status := call(t, "DELETE", wv.URL+"/v1/objects/Article/"+ids[0], nil, nil); status != http.StatusNoContent {
+		t.Errorf("Expected 204 on delete, got %d", status)
+	}
+	if status := call(t, "DELETE", wv.URL+"/v1/objects/Article/"+ids[0], nil, nil); status != http.StatusNotFound {
+		t.Errorf("Expected 404 on repeated delete, got %d", status)
+	}
+
+	t.Log("✓ Weaviate GraphQL and batch semantics work")
+}
@@ -0,0 +1,129 @@
+package fakes
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Fixture is a recorded sequence of exchanges that NewReplay can serve
+type Fixture struct {
+	Exchanges []Exchange `json:"exchanges"`
+}
+
+// LoadFixture reads a fixture written by Fixture.Save
+func LoadFixture(path string) (*Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture: %w", err)
+	}
+
+	var fixture Fixture
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		return nil, fmt.Errorf("failed to decode fixture %s: %w", path, err)
+	}
+
+	return &fixture, nil
+}
+
+// Save writes the fixture as indented JSON
+func (f *Fixture) Save(path string) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode fixture: %w", err)
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// NewReplay serves a fixture. Each request is answered with the first
+// unused exchange that has the same method, path, query and (JSON-equal)
+// body. Unmatched requests fail the test and get a 599 response.
+func NewReplay(t testing.TB, fixture *Fixture) *Server {
+	var mu sync.Mutex
+	used := make([]bool, len(fixture.Exchanges))
+
+	return newServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		for i, exchange := range fixture.Exchanges {
+			if used[i] || exchange.Method != r.Method || exchange.Path != r.URL.Path || exchange.Query != r.URL.RawQuery {
+				continue
+			}
+			if !sameBody(exchange.RequestBody, string(body)) {
+				continue
+			}
+
+			used[i] = true
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(exchange.Status)
+			io.WriteString(w, exchange.ResponseBody)
+			return
+		}
+
+		t.Errorf("fakes: no recorded exchange for %s %s?%s %s", r.Method, r.URL.Path, r.URL.RawQuery, body)
+		http.Error(w, "no recorded exchange", 599)
+	}))
+}
+
+// NewRecorder proxies requests to upstream, recording every exchange so
+// it can be saved with SaveFixture and replayed offline later
+func NewRecorder(t testing.TB, upstream string) *Server {
+	client := &http.Client{}
+	upstream = strings.TrimSuffix(upstream, "/")
+
+	return newServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		url := upstream + r.URL.Path
+		if r.URL.RawQuery != "" {
+			url += "?" + r.URL.RawQuery
+		}
+
+		req, err := http.NewRequestWithContext(r.Context(), r.Method, url, bytes.NewReader(body))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		req.Header = r.Header.Clone()
+
+		resp, err := client.Do(req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		defer resp.Body.Close()
+
+		for key, values := range resp.Header {
+			for _, value := range values {
+				w.Header().Add(key, value)
+			}
+		}
+		w.WriteHeader(resp.StatusCode)
+		io.Copy(w, resp.Body)
+	}))
+}
+
+// sameBody compares bodies as JSON when both parse, and as text otherwise
+func sameBody(a, b string) bool {
+	if a == b {
+		return true
+	}
+
+	var va, vb interface{}
+	if json.Unmarshal([]byte(a), &va) != nil || json.Unmarshal([]byte(b), &vb) != nil {
+		return false
+	}
+
+	ea, _ := json.Marshal(va)
+	eb, _ := json.Marshal(vb)
+	return bytes.Equal(ea, eb)
+}
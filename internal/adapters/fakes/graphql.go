@@ -0,0 +1,239 @@
+package fakes
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// gqlField is a parsed GraphQL field with its arguments and selections
+type gqlField struct {
+	Name      string
+	Args      map[string]interface{}
+	Selection []*gqlField
+}
+
+// field returns the selected child with the given name, or nil
+func (f *gqlField) field(name string) *gqlField {
+	for _, child := range f.Selection {
+		if child.Name == name {
+			return child
+		}
+	}
+	return nil
+}
+
+// parseGraphQL parses the anonymous or named query operation of a
+// document into its top-level selection set. It covers the subset of the
+// language Weaviate clients send: fields, arguments, nested selections and
+// string, number, enum, list and object values.
+func parseGraphQL(query string) ([]*gqlField, error) {
+	p := &gqlParser{src: query}
+	p.skip()
+	if strings.HasPrefix(p.src[p.pos:], "query") {
+		p.pos += len("query")
+		p.skip()
+		if p.pos < len(p.src) && p.src[p.pos] != '{' {
+			p.name()
+		}
+	}
+	fields, err := p.selection()
+	if err != nil {
+		return nil, err
+	}
+	p.skip()
+	if p.pos != len(p.src) {
+		return nil, p.errorf("unexpected trailing input")
+	}
+	return fields, nil
+}
+
+type gqlParser struct {
+	src string
+	pos int
+}
+
+func (p *gqlParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("graphql: "+format+" at offset %d", append(args, p.pos)...)
+}
+
+// skip advances past whitespace, commas and comments
+func (p *gqlParser) skip() {
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			p.pos++
+		case c == '#':
+			for p.pos < len(p.src) && p.src[p.pos] != '\n' {
+				p.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (p *gqlParser) expect(c byte) error {
+	p.skip()
+	if p.pos >= len(p.src) || p.src[p.pos] != c {
+		return p.errorf("expected %q", c)
+	}
+	p.pos++
+	return nil
+}
+
+func (p *gqlParser) peek() byte {
+	p.skip()
+	if p.pos >= len(p.src) {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+func (p *gqlParser) name() string {
+	p.skip()
+	start := p.pos
+	for p.pos < len(p.src) {
+		c := rune(p.src[p.pos])
+		if c != '_' && !unicode.IsLetter(c) && !unicode.IsDigit(c) {
+			break
+		}
+		p.pos++
+	}
+	return p.src[start:p.pos]
+}
+
+func (p *gqlParser) selection() ([]*gqlField, error) {
+	if err := p.expect('{'); err != nil {
+		return nil, err
+	}
+
+	var fields []*gqlField
+	for p.peek() != '}' {
+		if p.peek() == 0 {
+			return nil, p.errorf("unterminated selection set")
+		}
+
+		field := &gqlField{Name: p.name()}
+		if field.Name == "" {
+			return nil, p.errorf("expected field name")
+		}
+
+		if p.peek() == '(' {
+			p.pos++
+			field.Args = make(map[string]interface{})
+			for p.peek() != ')' {
+				name := p.name()
+				if name == "" {
+					return nil, p.errorf("expected argument name")
+				}
+				if err := p.expect(':'); err != nil {
+					return nil, err
+				}
+				value, err := p.value()
+				if err != nil {
+					return nil, err
+				}
+				field.Args[name] = value
+			}
+			p.pos++
+		}
+
+		if p.peek() == '{' {
+			children, err := p.selection()
+			if err != nil {
+				return nil, err
+			}
+			field.Selection = children
+		}
+
+		fields = append(fields, field)
+	}
+	p.pos++
+
+	return fields, nil
+}
+
+func (p *gqlParser) value() (interface{}, error) {
+	switch c := p.peek(); {
+	case c == '"':
+		start := p.pos
+		p.pos++
+		for p.pos < len(p.src) && p.src[p.pos] != '"' {
+			if p.src[p.pos] == '\\' {
+				p.pos++
+			}
+			p.pos++
+		}
+		if p.pos >= len(p.src) {
+			return nil, p.errorf("unterminated string")
+		}
+		p.pos++
+		var s string
+		if err := json.Unmarshal([]byte(p.src[start:p.pos]), &s); err != nil {
+			return nil, p.errorf("invalid string")
+		}
+		return s, nil
+
+	case c == '-' || (c >= '0' && c <= '9'):
+		start := p.pos
+		p.pos++
+		for p.pos < len(p.src) && strings.ContainsRune("0123456789.eE+-", rune(p.src[p.pos])) {
+			p.pos++
+		}
+		return json.Number(p.src[start:p.pos]), nil
+
+	case c == '[':
+		p.pos++
+		list := []interface{}{}
+		for p.peek() != ']' {
+			if p.peek() == 0 {
+				return nil, p.errorf("unterminated list")
+			}
+			item, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, item)
+		}
+		p.pos++
+		return list, nil
+
+	case c == '{':
+		p.pos++
+		object := map[string]interface{}{}
+		for p.peek() != '}' {
+			name := p.name()
+			if name == "" {
+				return nil, p.errorf("expected object field name")
+			}
+			if err := p.expect(':'); err != nil {
+				return nil, err
+			}
+			item, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			object[name] = item
+		}
+		p.pos++
+		return object, nil
+
+	default:
+		name := p.name()
+		switch name {
+		case "":
+			return nil, p.errorf("expected value")
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		}
+		// Enum values are returned as plain strings
+		return name, nil
+	}
+}
@@ -0,0 +1,369 @@
+package fakes
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// PineconeVector is a vector as stored by the Pinecone data plane
+type PineconeVector struct {
+	ID           string                 `json:"id"`
+	Values       []float32              `json:"values"`
+	SparseValues *PineconeSparseValues  `json:"sparseValues,omitempty"`
+	Metadata     map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// PineconeSparseValues is the sparse part of a hybrid vector
+type PineconeSparseValues struct {
+	Indices []uint32  `json:"indices"`
+	Values  []float32 `json:"values"`
+}
+
+// Pinecone emulates one serverless index: the control-plane describe call
+// and the data-plane vector endpoints, served from the same host
+type Pinecone struct {
+	*Server
+
+	index     string
+	dimension int
+
+	mu         sync.Mutex
+	apiKey     string
+	namespaces map[string]map[string]PineconeVector
+}
+
+// NewPinecone starts a fake for an index with the given dimension
+func NewPinecone(t testing.TB, index string, dimension int) *Pinecone {
+	p := &Pinecone{
+		index:      index,
+		dimension:  dimension,
+		namespaces: make(map[string]map[string]PineconeVector),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /indexes/{name}", p.describeIndex)
+	mux.HandleFunc("POST /describe_index_stats", p.describeIndexStats)
+	mux.HandleFunc("GET /vectors/list", p.list)
+	mux.HandleFunc("GET /vectors/fetch", p.fetch)
+	mux.HandleFunc("POST /vectors/upsert", p.upsert)
+	mux.HandleFunc("POST /vectors/delete", p.delete)
+	mux.HandleFunc("POST /query", p.query)
+
+	p.Server = newServer(t, p.authenticate(mux))
+	return p
+}
+
+// RequireAPIKey rejects requests without a matching Api-Key header
+func (p *Pinecone) RequireAPIKey(key string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.apiKey = key
+}
+
+// Seed stores vectors in a namespace ("" is the default namespace)
+func (p *Pinecone) Seed(namespace string, vectors ...PineconeVector) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, v := range vectors {
+		p.namespace(namespace)[v.ID] = v
+	}
+}
+
+// Vectors returns a namespace's vectors ordered by ID
+func (p *Pinecone) Vectors(namespace string) []PineconeVector {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stored := p.namespaces[namespace]
+	vectors := make([]PineconeVector, 0, len(stored))
+	for _, id := range sortedIDs(stored) {
+		vectors = append(vectors, stored[id])
+	}
+	return vectors
+}
+
+// namespace returns a namespace, creating it. Callers must hold p.mu.
+func (p *Pinecone) namespace(name string) map[string]PineconeVector {
+	ns, ok := p.namespaces[name]
+	if !ok {
+		ns = make(map[string]PineconeVector)
+		p.namespaces[name] = ns
+	}
+	return ns
+}
+
+func (p *Pinecone) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p.mu.Lock()
+		key := p.apiKey
+		p.mu.Unlock()
+
+		if key != "" && r.Header.Get("Api-Key") != key {
+			writeJSON(w, http.StatusUnauthorized, map[string]interface{}{
+				"error": map[string]string{"code": "UNAUTHENTICATED", "message": "Invalid API Key"},
+			})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (p *Pinecone) describeIndex(w http.ResponseWriter, r *http.Request) {
+	if r.PathValue("name") != p.index {
+		writeJSON(w, http.StatusNotFound, map[string]interface{}{
+			"error": map[string]string{"code": "NOT_FOUND", "message": "Resource " + r.PathValue("name") + " not found"},
+		})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"name":      p.index,
+		"dimension": p.dimension,
+		"metric":    "cosine",
+		"host":      strings.TrimPrefix(p.URL, "http://"),
+		"spec": map[string]interface{}{
+			"serverless": map[string]string{"cloud": "aws", "region": "us-east-1"},
+		},
+		"status": map[string]interface{}{"ready": true, "state": "Ready"},
+	})
+}
+
+func (p *Pinecone) describeIndexStats(w http.ResponseWriter, r *http.Request) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	namespaces := make(map[string]interface{})
+	total := 0
+	for name, vectors := range p.namespaces {
+		if len(vectors) == 0 {
+			continue
+		}
+		namespaces[name] = map[string]int{"vectorCount": len(vectors)}
+		total += len(vectors)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"namespaces":       namespaces,
+		"dimension":        p.dimension,
+		"indexFullness":    0,
+		"totalVectorCount": total,
+		"metric":           "cosine",
+	})
+}
+
+// list returns IDs only, paginated with an opaque token
+func (p *Pinecone) list(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	namespace := query.Get("namespace")
+	prefix := query.Get("prefix")
+
+	limit := 100
+	if raw := query.Get("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > 100 {
+			p.badRequest(w, "limit must be between 1 and 100")
+			return
+		}
+		limit = n
+	}
+
+	after := ""
+	if token := query.Get("paginationToken"); token != "" {
+		decoded, err := base64.RawURLEncoding.DecodeString(token)
+		if err != nil {
+			p.badRequest(w, "invalid pagination token")
+			return
+		}
+		after = string(decoded)
+	}
+
+	p.mu.Lock()
+	ids := sortedIDs(p.namespaces[namespace])
+	p.mu.Unlock()
+
+	page := []map[string]string{}
+	next := ""
+	for _, id := range ids {
+		if id <= after || !strings.HasPrefix(id, prefix) {
+			continue
+		}
+		if len(page) == limit {
+			next = base64.RawURLEncoding.EncodeToString([]byte(page[len(page)-1]["id"]))
+			break
+		}
+		page = append(page, map[string]string{"id": id})
+	}
+
+	response := map[string]interface{}{
+		"vectors":   page,
+		"namespace": namespace,
+		"usage":     map[string]int{"readUnits": 1},
+	}
+	if next != "" {
+		response["pagination"] = map[string]string{"next": next}
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (p *Pinecone) fetch(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	namespace := query.Get("namespace")
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	vectors := make(map[string]PineconeVector)
+	for _, id := range query["ids"] {
+		if v, ok := p.namespaces[namespace][id]; ok {
+			vectors[id] = v
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"vectors":   vectors,
+		"namespace": namespace,
+		"usage":     map[string]int{"readUnits": 1},
+	})
+}
+
+func (p *Pinecone) upsert(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Vectors   []PineconeVector `json:"vectors"`
+		Namespace string           `json:"namespace"`
+	}
+	if err := decodeJSON(r, &req); err != nil {
+		p.badRequest(w, err.Error())
+		return
+	}
+
+	for _, v := range req.Vectors {
+		if v.ID == "" {
+			p.badRequest(w, "vector ID must not be empty")
+			return
+		}
+		if len(v.Values) != p.dimension {
+			p.badRequest(w, fmt.Sprintf("Vector dimension %d does not match the dimension of the index %d", len(v.Values), p.dimension))
+			return
+		}
+		if v.SparseValues != nil && len(v.SparseValues.Indices) != len(v.SparseValues.Values) {
+			p.badRequest(w, "sparse vector indices and values must have the same length")
+			return
+		}
+	}
+
+	p.mu.Lock()
+	ns := p.namespace(req.Namespace)
+	for _, v := range req.Vectors {
+		ns[v.ID] = v
+	}
+	p.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]int{"upsertedCount": len(req.Vectors)})
+}
+
+func (p *Pinecone) delete(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		IDs       []string `json:"ids"`
+		DeleteAll bool     `json:"deleteAll"`
+		Namespace string   `json:"namespace"`
+	}
+	if err := decodeJSON(r, &req); err != nil {
+		p.badRequest(w, err.Error())
+		return
+	}
+
+	p.mu.Lock()
+	if req.DeleteAll {
+		delete(p.namespaces, req.Namespace)
+	} else {
+		for _, id := range req.IDs {
+			delete(p.namespaces[req.Namespace], id)
+		}
+	}
+	p.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{})
+}
+
+// query ranks a namespace's vectors by cosine similarity with an exact
+// scan. Filters support field: value and field: {"$eq": value}.
+func (p *Pinecone) query(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Vector    []interface{}          `json:"vector"`
+		TopK      int                    `json:"topK"`
+		Namespace string                 `json:"namespace"`
+		Filter    map[string]interface{} `json:"filter"`
+	}
+	if err := decodeJSON(r, &req); err != nil {
+		p.badRequest(w, err.Error())
+		return
+	}
+	if req.TopK < 1 || req.TopK > 10000 {
+		p.badRequest(w, "topK must be between 1 and 10000")
+		return
+	}
+	if len(req.Vector) != p.dimension {
+		p.badRequest(w, fmt.Sprintf("Vector dimension %d does not match the dimension of the index %d", len(req.Vector), p.dimension))
+		return
+	}
+	query := floats(req.Vector)
+
+	p.mu.Lock()
+	var hits []searchHit
+	for id, v := range p.namespaces[req.Namespace] {
+		if !pineconeFilterMatches(req.Filter, v.Metadata) {
+			continue
+		}
+		if similarity, ok := cosine(query, floats(v.Values)); ok {
+			hits = append(hits, searchHit{id: id, similarity: similarity})
+		}
+	}
+	p.mu.Unlock()
+
+	matches := []map[string]interface{}{}
+	for _, hit := range rankHits(hits, req.TopK) {
+		matches = append(matches, map[string]interface{}{"id": hit.id, "score": hit.similarity, "values": []float32{}})
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"matches":   matches,
+		"namespace": req.Namespace,
+		"usage":     map[string]int{"readUnits": 5},
+	})
+}
+
+// pineconeFilterMatches evaluates the equality subset of Pinecone's
+// metadata filter language
+func pineconeFilterMatches(filter, metadata map[string]interface{}) bool {
+	for field, condition := range filter {
+		want := condition
+		if operators, ok := condition.(map[string]interface{}); ok {
+			want = operators["$eq"]
+		}
+		got, exists := metadata[field]
+		if !exists || !jsonEqual(got, want) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *Pinecone) badRequest(w http.ResponseWriter, message string) {
+	writeJSON(w, http.StatusBadRequest, map[string]interface{}{"code": 3, "message": message, "details": []interface{}{}})
+}
+
+// sortedIDs returns the keys of m in ascending order
+func sortedIDs[V any](m map[string]V) []string {
+	ids := make([]string, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
@@ -0,0 +1,682 @@
+package fakes
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// QdrantPoint is a point as returned by the Qdrant points API. ID is a
+// uint64 or a UUID string; Vector is a dense vector or a map of named
+// dense ({"title": [...]}) and sparse ({"indices": [...], "values": [...]})
+// vectors.
+type QdrantPoint struct {
+	ID      interface{}            `json:"id"`
+	Vector  interface{}            `json:"vector,omitempty"`
+	Payload map[string]interface{} `json:"payload,omitempty"`
+}
+
+// qdrantCollection holds a collection's config and points keyed by ID
+type qdrantCollection struct {
+	vectors       interface{}
+	sparseVectors map[string]interface{}
+	hnswConfig    map[string]int
+	points        map[string]QdrantPoint
+
+	// shards maps point keys to the shard key they were written with, for
+	// collections sharded by custom shard keys
+	shards map[string]string
+}
+
+// Qdrant emulates the Qdrant collections and points REST API
+type Qdrant struct {
+	*Server
+
+	mu          sync.Mutex
+	apiKey      string
+	collections map[string]*qdrantCollection
+}
+
+var qdrantUUIDPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-?[0-9a-fA-F]{4}-?[0-9a-fA-F]{4}-?[0-9a-fA-F]{4}-?[0-9a-fA-F]{12}$`)
+
+// NewQdrant starts an empty Qdrant fake
+func NewQdrant(t testing.TB) *Qdrant {
+	q := &Qdrant{collections: make(map[string]*qdrantCollection)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /cluster", q.cluster)
+	mux.HandleFunc("GET /collections", q.listCollections)
+	mux.HandleFunc("GET /collections/{name}", q.getCollection)
+	mux.HandleFunc("GET /collections/{name}/cluster", q.collectionCluster)
+	mux.HandleFunc("PUT /collections/{name}", q.createCollection)
+	mux.HandleFunc("PUT /collections/{name}/points", q.upsert)
+	mux.HandleFunc("POST /collections/{name}/points", q.retrieve)
+	mux.HandleFunc("POST /collections/{name}/points/scroll", q.scroll)
+	mux.HandleFunc("POST /collections/{name}/points/delete", q.delete)
+	mux.HandleFunc("POST /collections/{name}/points/search", q.search)
+
+	q.Server = newServer(t, q.authenticate(mux))
+	return q
+}
+
+// RequireAPIKey rejects requests without a matching api-key header
+func (q *Qdrant) RequireAPIKey(key string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.apiKey = key
+}
+
+// CreateCollection adds a collection. vectors is the "vectors" config,
+// e.g. map[string]interface{}{"size": 3, "distance": "Cosine"}, or a map of
+// named vector configs; sparse lists sparse vector names.
+func (q *Qdrant) CreateCollection(name string, vectors interface{}, sparse ...string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	collection := &qdrantCollection{
+		vectors:       normalizeJSON(vectors),
+		sparseVectors: make(map[string]interface{}),
+		hnswConfig:    map[string]int{"m": 16, "ef_construct": 100},
+		points:        make(map[string]QdrantPoint),
+		shards:        make(map[string]string),
+	}
+	for _, s := range sparse {
+		collection.sparseVectors[s] = map[string]interface{}{}
+	}
+	q.collections[name] = collection
+}
+
+// Seed stores points in a collection without validation
+func (q *Qdrant) Seed(collection string, points ...QdrantPoint) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, p := range points {
+		p = normalizeJSON(p).(QdrantPoint)
+		key, _ := qdrantPointKey(p.ID)
+		q.collections[collection].points[key] = p
+	}
+}
+
+// SeedShard stores points under a custom shard key without validation
+func (q *Qdrant) SeedShard(collection, shardKey string, points ...QdrantPoint) {
+	q.Seed(collection, points...)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, p := range points {
+		key, _ := qdrantPointKey(normalizeJSON(p.ID))
+		q.collections[collection].shards[key] = shardKey
+	}
+}
+
+// Points returns a collection's points in Qdrant's ID order
+func (q *Qdrant) Points(collection string) []QdrantPoint {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	c := q.collections[collection]
+	if c == nil {
+		return nil
+	}
+	points := make([]QdrantPoint, 0, len(c.points))
+	for _, key := range c.orderedKeys() {
+		points = append(points, c.points[key])
+	}
+	return points
+}
+
+func (q *Qdrant) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q.mu.Lock()
+		key := q.apiKey
+		q.mu.Unlock()
+
+		if key != "" && r.Header.Get("api-key") != key {
+			http.Error(w, "Invalid api-key", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (q *Qdrant) cluster(w http.ResponseWriter, r *http.Request) {
+	qdrantOK(w, map[string]string{"status": "disabled"})
+}
+
+// collectionCluster reports one local shard per custom shard key in use
+func (q *Qdrant) collectionCluster(w http.ResponseWriter, r *http.Request) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	c, ok := q.collection(w, r)
+	if !ok {
+		return
+	}
+
+	keys := make(map[string]bool)
+	for _, shardKey := range c.shards {
+		keys[shardKey] = true
+	}
+	shards := []map[string]interface{}{}
+	for i, shardKey := range sortedIDs(keys) {
+		shards = append(shards, map[string]interface{}{
+			"shard_id":  i,
+			"shard_key": shardKey,
+			"state":     "Active",
+		})
+	}
+
+	qdrantOK(w, map[string]interface{}{
+		"peer_id":       1,
+		"shard_count":   len(shards),
+		"local_shards":  shards,
+		"remote_shards": []interface{}{},
+	})
+}
+
+func (q *Qdrant) listCollections(w http.ResponseWriter, r *http.Request) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	collections := []map[string]string{}
+	for _, name := range sortedIDs(q.collections) {
+		collections = append(collections, map[string]string{"name": name})
+	}
+	qdrantOK(w, map[string]interface{}{"collections": collections})
+}
+
+func (q *Qdrant) getCollection(w http.ResponseWriter, r *http.Request) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	c, ok := q.collection(w, r)
+	if !ok {
+		return
+	}
+
+	params := map[string]interface{}{"vectors": c.vectors}
+	if len(c.sparseVectors) > 0 {
+		params["sparse_vectors"] = c.sparseVectors
+	}
+
+	qdrantOK(w, map[string]interface{}{
+		"status":                "green",
+		"optimizer_status":      "ok",
+		"points_count":          len(c.points),
+		"vectors_count":         len(c.points),
+		"indexed_vectors_count": len(c.points),
+		"segments_count":        1,
+		"config": map[string]interface{}{
+			"params":      params,
+			"hnsw_config": c.hnswConfig,
+		},
+		"payload_schema": map[string]interface{}{},
+	})
+}
+
+func (q *Qdrant) createCollection(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Vectors       interface{}            `json:"vectors"`
+		SparseVectors map[string]interface{} `json:"sparse_vectors"`
+		HNSWConfig    map[string]int         `json:"hnsw_config"`
+	}
+	if err := decodeJSON(r, &req); err != nil {
+		qdrantError(w, http.StatusBadRequest, "Format error in JSON body: "+err.Error())
+		return
+	}
+
+	name := r.PathValue("name")
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if _, exists := q.collections[name]; exists {
+		qdrantError(w, http.StatusConflict, fmt.Sprintf("Wrong input: Collection `%s` already exists!", name))
+		return
+	}
+
+	collection := &qdrantCollection{
+		vectors:       normalizeJSON(req.Vectors),
+		sparseVectors: make(map[string]interface{}),
+		hnswConfig:    map[string]int{"m": 16, "ef_construct": 100},
+		points:        make(map[string]QdrantPoint),
+	}
+	for key, value := range req.HNSWConfig {
+		collection.hnswConfig[key] = value
+	}
+	for sparseName, config := range req.SparseVectors {
+		collection.sparseVectors[sparseName] = normalizeJSON(config)
+	}
+	q.collections[name] = collection
+
+	qdrantOK(w, true)
+}
+
+func (q *Qdrant) upsert(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Points   []QdrantPoint `json:"points"`
+		ShardKey interface{}   `json:"shard_key"`
+	}
+	if err := decodeJSON(r, &req); err != nil {
+		qdrantError(w, http.StatusBadRequest, "Format error in JSON body: "+err.Error())
+		return
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	c, ok := q.collection(w, r)
+	if !ok {
+		return
+	}
+
+	keys := make([]string, len(req.Points))
+	for i, p := range req.Points {
+		p = normalizeJSON(p).(QdrantPoint)
+		key, err := qdrantPointKey(p.ID)
+		if err != nil {
+			qdrantError(w, http.StatusBadRequest, "Format error in JSON body: "+err.Error())
+			return
+		}
+		if err := c.validateVector(p.Vector); err != nil {
+			qdrantError(w, http.StatusBadRequest, "Wrong input: "+err.Error())
+			return
+		}
+		keys[i] = key
+		req.Points[i] = p
+	}
+
+	for i, p := range req.Points {
+		p.ID = qdrantPointID(keys[i])
+		c.points[keys[i]] = p
+		if req.ShardKey != nil {
+			c.shards[keys[i]] = fmt.Sprint(normalizeJSON(req.ShardKey))
+		} else {
+			delete(c.shards, keys[i])
+		}
+	}
+
+	qdrantOK(w, map[string]interface{}{"operation_id": 1, "status": "completed"})
+}
+
+func (q *Qdrant) retrieve(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		IDs         []interface{} `json:"ids"`
+		WithPayload *bool         `json:"with_payload"`
+		WithVector  bool          `json:"with_vector"`
+	}
+	if err := decodeJSON(r, &req); err != nil {
+		qdrantError(w, http.StatusBadRequest, "Format error in JSON body: "+err.Error())
+		return
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	c, ok := q.collection(w, r)
+	if !ok {
+		return
+	}
+
+	points := []QdrantPoint{}
+	for _, id := range req.IDs {
+		key, err := qdrantPointKey(normalizeJSON(id))
+		if err != nil {
+			qdrantError(w, http.StatusBadRequest, "Format error in JSON body: "+err.Error())
+			return
+		}
+		if p, exists := c.points[key]; exists {
+			points = append(points, projectQdrantPoint(p, req.WithPayload == nil || *req.WithPayload, req.WithVector))
+		}
+	}
+
+	qdrantOK(w, points)
+}
+
+// scroll pages through points in ID order; offset is inclusive. A
+// shard_key restricts the scroll to points written with that key.
+func (q *Qdrant) scroll(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Limit       int         `json:"limit"`
+		Offset      interface{} `json:"offset"`
+		ShardKey    interface{} `json:"shard_key"`
+		WithPayload *bool       `json:"with_payload"`
+		WithVector  bool        `json:"with_vector"`
+	}
+	if err := decodeJSON(r, &req); err != nil {
+		qdrantError(w, http.StatusBadRequest, "Format error in JSON body: "+err.Error())
+		return
+	}
+	if req.Limit == 0 {
+		req.Limit = 10
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	c, ok := q.collection(w, r)
+	if !ok {
+		return
+	}
+
+	start := ""
+	if req.Offset != nil {
+		key, err := qdrantPointKey(normalizeJSON(req.Offset))
+		if err != nil {
+			qdrantError(w, http.StatusBadRequest, "Format error in JSON body: "+err.Error())
+			return
+		}
+		start = key
+	}
+
+	points := []QdrantPoint{}
+	var next interface{}
+	for _, key := range c.orderedKeys() {
+		if start != "" && qdrantKeyLess(key, start) {
+			continue
+		}
+		if req.ShardKey != nil && c.shards[key] != fmt.Sprint(normalizeJSON(req.ShardKey)) {
+			continue
+		}
+		if len(points) == req.Limit {
+			next = qdrantPointID(key)
+			break
+		}
+		points = append(points, projectQdrantPoint(c.points[key], req.WithPayload == nil || *req.WithPayload, req.WithVector))
+	}
+
+	qdrantOK(w, map[string]interface{}{"points": points, "next_page_offset": next})
+}
+
+// search ranks points by cosine similarity with an exact scan. Filters
+// support must clauses with match value and range conditions.
+func (q *Qdrant) search(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Vector interface{} `json:"vector"`
+		Limit  int         `json:"limit"`
+		Filter *struct {
+			Must []map[string]interface{} `json:"must"`
+		} `json:"filter"`
+	}
+	if err := decodeJSON(r, &req); err != nil {
+		qdrantError(w, http.StatusBadRequest, "Format error in JSON body: "+err.Error())
+		return
+	}
+	if req.Limit == 0 {
+		req.Limit = 10
+	}
+
+	name := ""
+	query := req.Vector
+	if named, ok := req.Vector.(map[string]interface{}); ok {
+		name, _ = named["name"].(string)
+		query = named["vector"]
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	c, ok := q.collection(w, r)
+	if !ok {
+		return
+	}
+
+	var hits []searchHit
+	for key, p := range c.points {
+		if req.Filter != nil && !qdrantFilterMatches(req.Filter.Must, p.Payload) {
+			continue
+		}
+		vector := p.Vector
+		if named, ok := p.Vector.(map[string]interface{}); ok {
+			vector = named[name]
+		} else if name != "" {
+			vector = nil
+		}
+		if similarity, ok := cosine(floats(query), floats(vector)); ok {
+			hits = append(hits, searchHit{id: key, similarity: similarity})
+		} else if vector != nil {
+			qdrantError(w, http.StatusBadRequest, "Wrong input: Vector dimension error")
+			return
+		}
+	}
+
+	result := []map[string]interface{}{}
+	for _, hit := range rankHits(hits, req.Limit) {
+		result = append(result, map[string]interface{}{"id": qdrantPointID(hit.id), "version": 0, "score": hit.similarity})
+	}
+	qdrantOK(w, result)
+}
+
+// qdrantFilterMatches evaluates must clauses of match value and range
+// conditions against a payload
+func qdrantFilterMatches(must []map[string]interface{}, payload map[string]interface{}) bool {
+	for _, condition := range must {
+		key, _ := condition["key"].(string)
+		value, exists := payload[key]
+		if !exists {
+			return false
+		}
+		if match, ok := condition["match"].(map[string]interface{}); ok && !jsonEqual(value, match["value"]) {
+			return false
+		}
+		if bounds, ok := condition["range"].(map[string]interface{}); ok {
+			n, isNumber := normalizeJSON(value).(json.Number)
+			if !isNumber {
+				return false
+			}
+			f, _ := n.Float64()
+			if gte, ok := bounds["gte"].(json.Number); ok {
+				if min, _ := gte.Float64(); f < min {
+					return false
+				}
+			}
+			if lte, ok := bounds["lte"].(json.Number); ok {
+				if max, _ := lte.Float64(); f > max {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+func (q *Qdrant) delete(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Points []interface{} `json:"points"`
+	}
+	if err := decodeJSON(r, &req); err != nil {
+		qdrantError(w, http.StatusBadRequest, "Format error in JSON body: "+err.Error())
+		return
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	c, ok := q.collection(w, r)
+	if !ok {
+		return
+	}
+
+	for _, id := range req.Points {
+		key, err := qdrantPointKey(normalizeJSON(id))
+		if err != nil {
+			qdrantError(w, http.StatusBadRequest, "Format error in JSON body: "+err.Error())
+			return
+		}
+		delete(c.points, key)
+		delete(c.shards, key)
+	}
+
+	qdrantOK(w, map[string]interface{}{"operation_id": 2, "status": "completed"})
+}
+
+// collection looks up the collection named in the path, writing a 404 if
+// it is missing. Callers must hold q.mu.
+func (q *Qdrant) collection(w http.ResponseWriter, r *http.Request) (*qdrantCollection, bool) {
+	name := r.PathValue("name")
+	c, ok := q.collections[name]
+	if !ok {
+		qdrantError(w, http.StatusNotFound, fmt.Sprintf("Not found: Collection `%s` doesn't exist!", name))
+	}
+	return c, ok
+}
+
+// validateVector checks a point's vector against the collection config
+func (c *qdrantCollection) validateVector(vector interface{}) error {
+	if dense, ok := vector.([]interface{}); ok {
+		config, _ := c.vectors.(map[string]interface{})
+		if _, unnamed := config["size"]; !unnamed {
+			return fmt.Errorf("Not existing vector name error: collection has only named vectors")
+		}
+		if size := jsonInt(config["size"]); len(dense) != size {
+			return fmt.Errorf("Vector dimension error: expected dim: %d, got %d", size, len(dense))
+		}
+		return nil
+	}
+
+	named, ok := vector.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("vector must be a list of floats or a map of named vectors")
+	}
+
+	configs, _ := c.vectors.(map[string]interface{})
+	for name, value := range named {
+		if _, sparse := c.sparseVectors[name]; sparse {
+			sv, _ := value.(map[string]interface{})
+			indices, _ := sv["indices"].([]interface{})
+			values, _ := sv["values"].([]interface{})
+			if len(indices) != len(values) {
+				return fmt.Errorf("Sparse vector %s has %d indices and %d values", name, len(indices), len(values))
+			}
+			continue
+		}
+
+		config, exists := configs[name].(map[string]interface{})
+		if name == "" {
+			// The unnamed vector, alongside sparse vectors
+			_, exists = configs["size"]
+			config = configs
+		}
+		if !exists {
+			return fmt.Errorf("Not existing vector name error: %s", name)
+		}
+		dense, _ := value.([]interface{})
+		if size := jsonInt(config["size"]); len(dense) != size {
+			return fmt.Errorf("Vector dimension error: expected dim: %d, got %d", size, len(dense))
+		}
+	}
+	return nil
+}
+
+// orderedKeys returns point keys with integer IDs first in numeric order,
+// then UUIDs in lexical order, matching Qdrant's scroll order
+func (c *qdrantCollection) orderedKeys() []string {
+	keys := make([]string, 0, len(c.points))
+	for key := range c.points {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return qdrantKeyLess(keys[i], keys[j]) })
+	return keys
+}
+
+// qdrantPointKey validates an ID and returns its canonical string form:
+// the decimal integer, or the lowercase hyphenated UUID
+func qdrantPointKey(id interface{}) (string, error) {
+	switch v := id.(type) {
+	case json.Number:
+		n, err := strconv.ParseUint(v.String(), 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("value %s is not a valid point ID, valid values are either an unsigned integer or a UUID", v)
+		}
+		return strconv.FormatUint(n, 10), nil
+	case string:
+		if !qdrantUUIDPattern.MatchString(v) {
+			return "", fmt.Errorf("value %s is not a valid point ID, valid values are either an unsigned integer or a UUID", v)
+		}
+		hex := strings.ToLower(strings.ReplaceAll(v, "-", ""))
+		return hex[0:8] + "-" + hex[8:12] + "-" + hex[12:16] + "-" + hex[16:20] + "-" + hex[20:], nil
+	default:
+		return "", fmt.Errorf("value %v is not a valid point ID, valid values are either an unsigned integer or a UUID", id)
+	}
+}
+
+// qdrantPointID converts a canonical key back to its JSON ID
+func qdrantPointID(key string) interface{} {
+	if n, err := strconv.ParseUint(key, 10, 64); err == nil {
+		return json.Number(strconv.FormatUint(n, 10))
+	}
+	return key
+}
+
+func qdrantKeyLess(a, b string) bool {
+	na, errA := strconv.ParseUint(a, 10, 64)
+	nb, errB := strconv.ParseUint(b, 10, 64)
+	switch {
+	case errA == nil && errB == nil:
+		return na < nb
+	case errA == nil:
+		return true
+	case errB == nil:
+		return false
+	default:
+		return a < b
+	}
+}
+
+func projectQdrantPoint(p QdrantPoint, withPayload, withVector bool) QdrantPoint {
+	out := QdrantPoint{ID: p.ID}
+	if withPayload {
+		out.Payload = p.Payload
+		if out.Payload == nil {
+			out.Payload = map[string]interface{}{}
+		}
+	}
+	if withVector {
+		out.Vector = p.Vector
+	}
+	return out
+}
+
+func qdrantOK(w http.ResponseWriter, result interface{}) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{"result": result, "status": "ok", "time": 0.0001})
+}
+
+func qdrantError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{"status": map[string]string{"error": message}, "time": 0.0001})
+}
+
+// normalizeJSON round-trips a value through JSON so numbers are
+// json.Number and structs keep their concrete types
+func normalizeJSON(v interface{}) interface{} {
+	data, _ := json.Marshal(v)
+
+	switch v.(type) {
+	case QdrantPoint:
+		var p QdrantPoint
+		decodeJSONBytes(data, &p)
+		return p
+	default:
+		var out interface{}
+		decodeJSONBytes(data, &out)
+		return out
+	}
+}
+
+func jsonInt(v interface{}) int {
+	switch n := v.(type) {
+	case json.Number:
+		i, _ := n.Int64()
+		return int(i)
+	case float64:
+		return int(n)
+	case int:
+		return n
+	}
+	return 0
+}
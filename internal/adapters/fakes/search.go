@@ -0,0 +1,83 @@
+package fakes
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+)
+
+// searchHit is one candidate of an exact nearest-neighbour scan
+type searchHit struct {
+	id         string
+	similarity float64
+}
+
+// rankHits orders hits by descending similarity, then ID, and keeps the
+// first k
+func rankHits(hits []searchHit, k int) []searchHit {
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].similarity != hits[j].similarity {
+			return hits[i].similarity > hits[j].similarity
+		}
+		return hits[i].id < hits[j].id
+	})
+	if len(hits) > k {
+		hits = hits[:k]
+	}
+	return hits
+}
+
+// cosine returns the cosine similarity of two vectors of JSON or float
+// values, and false if their dimensions differ
+func cosine(a, b []float64) (float64, bool) {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0, false
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+	if normA == 0 || normB == 0 {
+		return 0, true
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB)), true
+}
+
+// floats converts a []float32 or a decoded JSON list of numbers
+func floats(v interface{}) []float64 {
+	switch values := v.(type) {
+	case []float32:
+		out := make([]float64, len(values))
+		for i, f := range values {
+			out[i] = float64(f)
+		}
+		return out
+	case []interface{}:
+		out := make([]float64, 0, len(values))
+		for _, item := range values {
+			n, ok := item.(json.Number)
+			if !ok {
+				return nil
+			}
+			f, _ := n.Float64()
+			out = append(out, f)
+		}
+		return out
+	}
+	return nil
+}
+
+// jsonEqual compares two values after a JSON round trip, numbers by value
+func jsonEqual(a, b interface{}) bool {
+	a, b = normalizeJSON(a), normalizeJSON(b)
+	na, okA := a.(json.Number)
+	nb, okB := b.(json.Number)
+	if okA && okB {
+		fa, _ := na.Float64()
+		fb, _ := nb.Float64()
+		return fa == fb
+	}
+	return a == b
+}
@@ -0,0 +1,203 @@
+// Package fakes provides stateful in-process stand-ins for the vendor APIs
+// the adapters talk to, so adapter and orchestrator tests can run offline.
+//
+// Each fake implements the subset of its vendor's API used by the adapters,
+// with the vendor's semantics (opaque pagination tokens, ID formats,
+// per-item batch errors). All fakes share Server, which adds configurable
+// latency, fault injection and request/response recording; recordings can
+// be saved as fixtures and served back with NewReplay.
+//
+// This package must not import internal/adapters, whose tests import it.
+package fakes
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Fault makes matching requests fail instead of reaching the fake
+type Fault struct {
+	Method string // empty matches any method
+	Path   string // path prefix; empty matches any path
+	Status int
+	Body   string
+	Times  int // number of requests to fail; 0 fails every match
+
+	// RetryAfter, if set, is sent as the Retry-After header
+	RetryAfter string
+}
+
+// Exchange is one recorded request and its response
+type Exchange struct {
+	Method       string `json:"method"`
+	Path         string `json:"path"`
+	Query        string `json:"query,omitempty"`
+	RequestBody  string `json:"request_body,omitempty"`
+	Status       int    `json:"status"`
+	ResponseBody string `json:"response_body,omitempty"`
+}
+
+// Server wraps a vendor handler with latency, faults and recording
+type Server struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	handler   http.Handler
+	latency   time.Duration
+	faults    []*Fault
+	exchanges []Exchange
+}
+
+// newServer starts a server that is closed when the test ends
+func newServer(t testing.TB, handler http.Handler) *Server {
+	s := &Server{handler: handler}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Server.Close)
+	return s
+}
+
+// SetLatency delays every response by d
+func (s *Server) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency = d
+}
+
+// Inject adds a fault; faults are checked in the order they were added
+func (s *Server) Inject(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fault := f
+	s.faults = append(s.faults, &fault)
+}
+
+// ClearFaults removes all injected faults
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// Exchanges returns the requests served so far
+func (s *Server) Exchanges() []Exchange {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Exchange(nil), s.exchanges...)
+}
+
+// Fixture returns the recorded exchanges as a fixture
+func (s *Server) Fixture() *Fixture {
+	return &Fixture{Exchanges: s.Exchanges()}
+}
+
+// SaveFixture writes the recorded exchanges to path
+func (s *Server) SaveFixture(path string) error {
+	return s.Fixture().Save(path)
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	s.mu.Lock()
+	latency := s.latency
+	fault := s.matchFault(r)
+	s.mu.Unlock()
+
+	if latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	recorder := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+	if fault != nil {
+		recorder.Header().Set("Content-Type", "application/json")
+		if fault.RetryAfter != "" {
+			recorder.Header().Set("Retry-After", fault.RetryAfter)
+		}
+		recorder.WriteHeader(fault.Status)
+		recorder.Write([]byte(fault.Body))
+	} else {
+		s.handler.ServeHTTP(recorder, r)
+	}
+
+	s.mu.Lock()
+	s.exchanges = append(s.exchanges, Exchange{
+		Method:       r.Method,
+		Path:         r.URL.Path,
+		Query:        r.URL.RawQuery,
+		RequestBody:  string(body),
+		Status:       recorder.status,
+		ResponseBody: recorder.body.String(),
+	})
+	s.mu.Unlock()
+}
+
+// matchFault returns the first fault matching r, consuming one use of it.
+// Callers must hold s.mu.
+func (s *Server) matchFault(r *http.Request) *Fault {
+	for i, f := range s.faults {
+		if f.Method != "" && f.Method != r.Method {
+			continue
+		}
+		if !strings.HasPrefix(r.URL.Path, f.Path) {
+			continue
+		}
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+			}
+		}
+		return f
+	}
+	return nil
+}
+
+// responseRecorder captures the status and body written by a handler
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+// writeJSON writes v as a JSON response
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// decodeJSON decodes a request body, preserving number precision
+func decodeJSON(r *http.Request, v interface{}) error {
+	decoder := json.NewDecoder(r.Body)
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+// decodeJSONBytes decodes data, preserving number precision
+func decodeJSONBytes(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
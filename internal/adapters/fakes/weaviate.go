@@ -0,0 +1,741 @@
+package fakes
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// WeaviateObject is an object as stored by Weaviate
+type WeaviateObject struct {
+	Class              string                 `json:"class"`
+	ID                 string                 `json:"id"`
+	Tenant             string                 `json:"tenant,omitempty"`
+	Vector             []float32              `json:"vector,omitempty"`
+	Properties         map[string]interface{} `json:"properties,omitempty"`
+	CreationTimeUnix   int64                  `json:"creationTimeUnix,omitempty"`
+	LastUpdateTimeUnix int64                  `json:"lastUpdateTimeUnix,omitempty"`
+}
+
+// weaviateClass holds a class definition and its objects by tenant;
+// single-tenant classes keep objects under ""
+type weaviateClass struct {
+	schema    map[string]interface{}
+	dimension int
+	tenants   map[string]map[string]WeaviateObject
+}
+
+func (c *weaviateClass) multiTenant() bool {
+	config, _ := c.schema["multiTenancyConfig"].(map[string]interface{})
+	enabled, _ := config["enabled"].(bool)
+	return enabled
+}
+
+// Weaviate emulates the Weaviate REST schema/objects/batch endpoints and
+// the Get and Aggregate GraphQL queries
+type Weaviate struct {
+	*Server
+
+	mu      sync.Mutex
+	apiKey  string
+	classes map[string]*weaviateClass
+}
+
+var weaviateUUIDPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+
+// NewWeaviate starts an empty Weaviate fake
+func NewWeaviate(t testing.TB) *Weaviate {
+	wv := &Weaviate{classes: make(map[string]*weaviateClass)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/.well-known/ready", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("GET /v1/meta", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"hostname": "http://[::]:8080", "version": "1.25.0"})
+	})
+	mux.HandleFunc("GET /v1/schema", wv.getSchema)
+	mux.HandleFunc("POST /v1/schema", wv.createClassHandler)
+	mux.HandleFunc("GET /v1/schema/{class}", wv.getClass)
+	mux.HandleFunc("GET /v1/schema/{class}/tenants", wv.getTenants)
+	mux.HandleFunc("POST /v1/schema/{class}/tenants", wv.addTenants)
+	mux.HandleFunc("POST /v1/batch/objects", wv.batchObjects)
+	mux.HandleFunc("DELETE /v1/batch/objects", wv.batchDelete)
+	mux.HandleFunc("GET /v1/objects/{class}/{id}", wv.getObject)
+	mux.HandleFunc("DELETE /v1/objects/{class}/{id}", wv.deleteObject)
+	mux.HandleFunc("POST /v1/graphql", wv.graphql)
+
+	wv.Server = newServer(t, wv.authenticate(mux))
+	return wv
+}
+
+// RequireAPIKey rejects requests without a matching bearer token
+func (wv *Weaviate) RequireAPIKey(key string) {
+	wv.mu.Lock()
+	defer wv.mu.Unlock()
+	wv.apiKey = key
+}
+
+// CreateClass adds a class from a schema definition as accepted by
+// POST /v1/schema, e.g. {"class": "Article", "properties": [...]}
+func (wv *Weaviate) CreateClass(class map[string]interface{}) error {
+	wv.mu.Lock()
+	defer wv.mu.Unlock()
+	return wv.createClass(class)
+}
+
+// AddTenants adds tenants to a multi-tenant class
+func (wv *Weaviate) AddTenants(class string, tenants ...string) {
+	wv.mu.Lock()
+	defer wv.mu.Unlock()
+	for _, tenant := range tenants {
+		if _, exists := wv.classes[class].tenants[tenant]; !exists {
+			wv.classes[class].tenants[tenant] = make(map[string]WeaviateObject)
+		}
+	}
+}
+
+// Seed stores objects without validation; their classes must exist
+func (wv *Weaviate) Seed(objects ...WeaviateObject) {
+	wv.mu.Lock()
+	defer wv.mu.Unlock()
+	for _, obj := range objects {
+		class := wv.classes[obj.Class]
+		if class.tenants[obj.Tenant] == nil {
+			class.tenants[obj.Tenant] = make(map[string]WeaviateObject)
+		}
+		class.tenants[obj.Tenant][obj.ID] = obj
+		if class.dimension == 0 {
+			class.dimension = len(obj.Vector)
+		}
+	}
+}
+
+// Objects returns a class's objects for a tenant ("" if single-tenant) in ID order
+func (wv *Weaviate) Objects(class, tenant string) []WeaviateObject {
+	wv.mu.Lock()
+	defer wv.mu.Unlock()
+
+	c := wv.classes[class]
+	if c == nil {
+		return nil
+	}
+	stored := c.tenants[tenant]
+	objects := make([]WeaviateObject, 0, len(stored))
+	for _, id := range sortedIDs(stored) {
+		objects = append(objects, stored[id])
+	}
+	return objects
+}
+
+func (wv *Weaviate) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		wv.mu.Lock()
+		key := wv.apiKey
+		wv.mu.Unlock()
+
+		if key != "" && r.URL.Path != "/v1/.well-known/ready" && r.Header.Get("Authorization") != "Bearer "+key {
+			weaviateError(w, http.StatusUnauthorized, "anonymous access not enabled, please provide an auth scheme such as OIDC")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// createClass validates and stores a class. Callers must hold wv.mu.
+func (wv *Weaviate) createClass(class map[string]interface{}) error {
+	class, _ = normalizeJSON(class).(map[string]interface{})
+	name, _ := class["class"].(string)
+	if name == "" || strings.ToUpper(name[:1]) != name[:1] {
+		return fmt.Errorf("class name %q must start with a capital letter", name)
+	}
+	if _, exists := wv.classes[name]; exists {
+		return fmt.Errorf("class name %q already exists", name)
+	}
+
+	if _, ok := class["properties"]; !ok {
+		class["properties"] = []interface{}{}
+	}
+	if _, ok := class["vectorIndexType"]; !ok {
+		class["vectorIndexType"] = "hnsw"
+	}
+	if _, ok := class["vectorIndexConfig"]; !ok {
+		class["vectorIndexConfig"] = map[string]interface{}{"distance": "cosine"}
+	}
+	if _, ok := class["vectorizer"]; !ok {
+		class["vectorizer"] = "none"
+	}
+
+	c := &weaviateClass{schema: class, tenants: make(map[string]map[string]WeaviateObject)}
+	if !c.multiTenant() {
+		c.tenants[""] = make(map[string]WeaviateObject)
+	}
+	wv.classes[name] = c
+	return nil
+}
+
+func (wv *Weaviate) getSchema(w http.ResponseWriter, r *http.Request) {
+	wv.mu.Lock()
+	defer wv.mu.Unlock()
+
+	classes := []interface{}{}
+	for _, name := range sortedIDs(wv.classes) {
+		classes = append(classes, wv.classes[name].schema)
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"classes": classes})
+}
+
+func (wv *Weaviate) createClassHandler(w http.ResponseWriter, r *http.Request) {
+	var class map[string]interface{}
+	if err := decodeJSON(r, &class); err != nil {
+		weaviateError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	wv.mu.Lock()
+	defer wv.mu.Unlock()
+
+	if err := wv.createClass(class); err != nil {
+		weaviateError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, wv.classes[class["class"].(string)].schema)
+}
+
+func (wv *Weaviate) getClass(w http.ResponseWriter, r *http.Request) {
+	wv.mu.Lock()
+	defer wv.mu.Unlock()
+
+	c, ok := wv.classes[r.PathValue("class")]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, c.schema)
+}
+
+func (wv *Weaviate) getTenants(w http.ResponseWriter, r *http.Request) {
+	wv.mu.Lock()
+	defer wv.mu.Unlock()
+
+	c, ok := wv.classes[r.PathValue("class")]
+	if !ok || !c.multiTenant() {
+		weaviateError(w, http.StatusUnprocessableEntity, "multi-tenancy is not enabled for class "+r.PathValue("class"))
+		return
+	}
+
+	tenants := []map[string]string{}
+	for _, name := range sortedIDs(c.tenants) {
+		tenants = append(tenants, map[string]string{"name": name, "activityStatus": "HOT"})
+	}
+	writeJSON(w, http.StatusOK, tenants)
+}
+
+func (wv *Weaviate) addTenants(w http.ResponseWriter, r *http.Request) {
+	var tenants []map[string]string
+	if err := decodeJSON(r, &tenants); err != nil {
+		weaviateError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	wv.mu.Lock()
+	defer wv.mu.Unlock()
+
+	c, ok := wv.classes[r.PathValue("class")]
+	if !ok || !c.multiTenant() {
+		weaviateError(w, http.StatusUnprocessableEntity, "multi-tenancy is not enabled for class "+r.PathValue("class"))
+		return
+	}
+	for _, tenant := range tenants {
+		if _, exists := c.tenants[tenant["name"]]; !exists {
+			c.tenants[tenant["name"]] = make(map[string]WeaviateObject)
+		}
+	}
+	writeJSON(w, http.StatusOK, tenants)
+}
+
+// batchObjects stores objects, reporting failures per object
+func (wv *Weaviate) batchObjects(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Objects []WeaviateObject `json:"objects"`
+	}
+	if err := decodeJSON(r, &req); err != nil {
+		weaviateError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	wv.mu.Lock()
+	defer wv.mu.Unlock()
+
+	results := make([]map[string]interface{}, len(req.Objects))
+	for i, obj := range req.Objects {
+		if obj.ID == "" {
+			obj.ID = newUUID()
+		}
+
+		result := map[string]interface{}{}
+		if err := wv.putObject(obj); err != nil {
+			result["errors"] = map[string]interface{}{
+				"error": []map[string]string{{"message": err.Error()}},
+			}
+		}
+
+		results[i] = map[string]interface{}{
+			"class":      obj.Class,
+			"id":         obj.ID,
+			"tenant":     obj.Tenant,
+			"properties": obj.Properties,
+			"result":     result,
+		}
+	}
+
+	writeJSON(w, http.StatusOK, results)
+}
+
+// putObject validates and stores one object. Callers must hold wv.mu.
+func (wv *Weaviate) putObject(obj WeaviateObject) error {
+	c, ok := wv.classes[obj.Class]
+	if !ok {
+		return fmt.Errorf("class %q not found in schema", obj.Class)
+	}
+	if !weaviateUUIDPattern.MatchString(obj.ID) {
+		return fmt.Errorf("id %q is not a valid uuid", obj.ID)
+	}
+
+	objects, err := c.objects(obj.Class, obj.Tenant)
+	if err != nil {
+		return err
+	}
+
+	if len(obj.Vector) > 0 {
+		if c.dimension == 0 {
+			c.dimension = len(obj.Vector)
+		} else if len(obj.Vector) != c.dimension {
+			return fmt.Errorf("new node has a vector with length %d. Existing nodes have vectors with length %d", len(obj.Vector), c.dimension)
+		}
+	}
+
+	// Auto-schema: add properties the class does not define yet
+	properties, _ := c.schema["properties"].([]interface{})
+	defined := make(map[string]bool)
+	for _, p := range properties {
+		prop, _ := p.(map[string]interface{})
+		name, _ := prop["name"].(string)
+		defined[name] = true
+	}
+	for _, name := range sortedIDs(obj.Properties) {
+		if !defined[name] {
+			properties = append(properties, map[string]interface{}{
+				"name":     name,
+				"dataType": []interface{}{weaviateDataType(obj.Properties[name])},
+			})
+		}
+	}
+	c.schema["properties"] = properties
+
+	now := time.Now().UnixMilli()
+	obj.CreationTimeUnix = now
+	if existing, exists := objects[obj.ID]; exists {
+		obj.CreationTimeUnix = existing.CreationTimeUnix
+	}
+	obj.LastUpdateTimeUnix = now
+	obj.Properties, _ = normalizeJSON(obj.Properties).(map[string]interface{})
+	objects[obj.ID] = obj
+	return nil
+}
+
+// objects resolves the object map for a tenant, enforcing multi-tenancy
+func (c *weaviateClass) objects(class, tenant string) (map[string]WeaviateObject, error) {
+	if c.multiTenant() {
+		if tenant == "" {
+			return nil, fmt.Errorf("has multi-tenancy enabled, but request was without tenant")
+		}
+		objects, ok := c.tenants[tenant]
+		if !ok {
+			return nil, fmt.Errorf("tenant not found: %q", tenant)
+		}
+		return objects, nil
+	}
+
+	if tenant != "" {
+		return nil, fmt.Errorf("class %s has multi-tenancy disabled, but request was with tenant", class)
+	}
+	return c.tenants[""], nil
+}
+
+// batchDelete deletes objects matched by an id filter
+func (wv *Weaviate) batchDelete(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Match struct {
+			Class string `json:"class"`
+			Where struct {
+				Operator       string   `json:"operator"`
+				Path           []string `json:"path"`
+				ValueText      string   `json:"valueText"`
+				ValueTextArray []string `json:"valueTextArray"`
+			} `json:"where"`
+		} `json:"match"`
+		DryRun bool `json:"dryRun"`
+	}
+	if err := decodeJSON(r, &req); err != nil {
+		weaviateError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	where := req.Match.Where
+	if len(where.Path) != 1 || where.Path[0] != "id" {
+		weaviateError(w, http.StatusUnprocessableEntity, "fake only supports where filters on id")
+		return
+	}
+
+	ids := where.ValueTextArray
+	switch where.Operator {
+	case "Equal":
+		ids = []string{where.ValueText}
+	case "ContainsAny":
+	default:
+		weaviateError(w, http.StatusUnprocessableEntity, "fake only supports Equal and ContainsAny on id")
+		return
+	}
+
+	wv.mu.Lock()
+	defer wv.mu.Unlock()
+
+	c, ok := wv.classes[req.Match.Class]
+	if !ok {
+		weaviateError(w, http.StatusUnprocessableEntity, fmt.Sprintf("class %q not found in schema", req.Match.Class))
+		return
+	}
+	objects, err := c.objects(req.Match.Class, r.URL.Query().Get("tenant"))
+	if err != nil {
+		weaviateError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	matches := 0
+	for _, id := range ids {
+		if _, exists := objects[id]; exists {
+			matches++
+			if !req.DryRun {
+				delete(objects, id)
+			}
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"match":  req.Match,
+		"dryRun": req.DryRun,
+		"output": "minimal",
+		"results": map[string]int{
+			"matches":    matches,
+			"limit":      10000,
+			"successful": matches,
+			"failed":     0,
+		},
+	})
+}
+
+func (wv *Weaviate) getObject(w http.ResponseWriter, r *http.Request) {
+	wv.mu.Lock()
+	defer wv.mu.Unlock()
+
+	c, ok := wv.classes[r.PathValue("class")]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	objects, err := c.objects(r.PathValue("class"), r.URL.Query().Get("tenant"))
+	if err != nil {
+		weaviateError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	obj, exists := objects[r.PathValue("id")]
+	if !exists {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if !strings.Contains(r.URL.Query().Get("include"), "vector") {
+		obj.Vector = nil
+	}
+	writeJSON(w, http.StatusOK, obj)
+}
+
+func (wv *Weaviate) deleteObject(w http.ResponseWriter, r *http.Request) {
+	wv.mu.Lock()
+	defer wv.mu.Unlock()
+
+	c, ok := wv.classes[r.PathValue("class")]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	objects, err := c.objects(r.PathValue("class"), r.URL.Query().Get("tenant"))
+	if err != nil {
+		weaviateError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	if _, exists := objects[r.PathValue("id")]; !exists {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	delete(objects, r.PathValue("id"))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// graphql answers Get and Aggregate queries. Errors are reported in the
+// response body with status 200, as Weaviate does.
+func (wv *Weaviate) graphql(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Query string `json:"query"`
+	}
+	if err := decodeJSON(r, &req); err != nil {
+		weaviateError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	fields, err := parseGraphQL(req.Query)
+	if err != nil {
+		graphqlError(w, err.Error())
+		return
+	}
+
+	wv.mu.Lock()
+	defer wv.mu.Unlock()
+
+	data := map[string]interface{}{}
+	for _, field := range fields {
+		result := map[string]interface{}{}
+		for _, classField := range field.Selection {
+			var value interface{}
+			var err error
+			switch field.Name {
+			case "Get":
+				value, err = wv.graphqlGet(classField)
+			case "Aggregate":
+				value, err = wv.graphqlAggregate(classField)
+			default:
+				err = fmt.Errorf("Cannot query field %q on type \"WeaviateObj\".", field.Name)
+			}
+			if err != nil {
+				graphqlError(w, err.Error())
+				return
+			}
+			result[classField.Name] = value
+		}
+		data[field.Name] = result
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": data})
+}
+
+func (wv *Weaviate) graphqlGet(query *gqlField) (interface{}, error) {
+	c, ok := wv.classes[query.Name]
+	if !ok {
+		return nil, fmt.Errorf("Cannot query field %q on type \"GetObjectsObj\".", query.Name)
+	}
+
+	tenant, _ := query.Args["tenant"].(string)
+	objects, err := c.objects(query.Name, tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := 10
+	if n, ok := query.Args["limit"].(json.Number); ok {
+		limit = jsonInt(n)
+	}
+	after, hasAfter := query.Args["after"].(string)
+	if hasAfter && after != "" && !weaviateUUIDPattern.MatchString(after) {
+		return nil, fmt.Errorf("after: %q is not a valid uuid", after)
+	}
+
+	defined := make(map[string]bool)
+	properties, _ := c.schema["properties"].([]interface{})
+	for _, p := range properties {
+		prop, _ := p.(map[string]interface{})
+		name, _ := prop["name"].(string)
+		defined[name] = true
+	}
+	for _, field := range query.Selection {
+		if field.Name != "_additional" && !defined[field.Name] {
+			return nil, fmt.Errorf("Cannot query field %q on type %q.", field.Name, query.Name)
+		}
+	}
+
+	ids := sortedIDs(objects)
+	distances := make(map[string]float64)
+	if where, ok := query.Args["where"].(map[string]interface{}); ok {
+		var matched []string
+		for _, id := range ids {
+			match, err := weaviateWhereMatches(where, objects[id])
+			if err != nil {
+				return nil, err
+			}
+			if match {
+				matched = append(matched, id)
+			}
+		}
+		ids = matched
+	}
+	if near, ok := query.Args["nearVector"].(map[string]interface{}); ok {
+		if hasAfter {
+			return nil, fmt.Errorf("cursor api: invalid 'after' parameter: other params cannot be set together with 'after' parameter")
+		}
+		vector := floats(near["vector"])
+		var hits []searchHit
+		for _, id := range ids {
+			similarity, ok := cosine(vector, floats(objects[id].Vector))
+			if !ok {
+				return nil, fmt.Errorf("vector search: knn search: distance between entrypoint and query node: vector lengths don't match: %d vs %d", len(objects[id].Vector), len(vector))
+			}
+			hits = append(hits, searchHit{id: id, similarity: similarity})
+		}
+		ids = ids[:0]
+		for _, hit := range rankHits(hits, limit) {
+			ids = append(ids, hit.id)
+			distances[hit.id] = 1 - hit.similarity
+		}
+	}
+
+	results := []interface{}{}
+	for _, id := range ids {
+		if len(results) == limit {
+			break
+		}
+		if id <= after {
+			continue
+		}
+
+		obj := objects[id]
+		item := map[string]interface{}{}
+		for _, field := range query.Selection {
+			if field.Name != "_additional" {
+				item[field.Name] = obj.Properties[field.Name]
+				continue
+			}
+
+			additional := map[string]interface{}{}
+			for _, extra := range field.Selection {
+				switch extra.Name {
+				case "id":
+					additional["id"] = obj.ID
+				case "vector":
+					additional["vector"] = obj.Vector
+				case "distance":
+					if distance, ok := distances[id]; ok {
+						additional["distance"] = distance
+					}
+				case "creationTimeUnix":
+					additional["creationTimeUnix"] = fmt.Sprint(obj.CreationTimeUnix)
+				case "lastUpdateTimeUnix":
+					additional["lastUpdateTimeUnix"] = fmt.Sprint(obj.LastUpdateTimeUnix)
+				}
+			}
+			item["_additional"] = additional
+		}
+		results = append(results, item)
+	}
+
+	return results, nil
+}
+
+// weaviateWhereMatches evaluates a where filter of Equal conditions,
+// combined with And, against an object's properties
+func weaviateWhereMatches(where map[string]interface{}, obj WeaviateObject) (bool, error) {
+	switch operator, _ := where["operator"].(string); operator {
+	case "And":
+		operands, _ := where["operands"].([]interface{})
+		for _, operand := range operands {
+			condition, _ := operand.(map[string]interface{})
+			match, err := weaviateWhereMatches(condition, obj)
+			if err != nil || !match {
+				return false, err
+			}
+		}
+		return true, nil
+
+	case "Equal":
+		path, _ := where["path"].([]interface{})
+		if len(path) != 1 {
+			return false, fmt.Errorf("fake only supports single-element where paths")
+		}
+		name, _ := path[0].(string)
+		value, exists := obj.Properties[name]
+		if !exists {
+			return false, nil
+		}
+		for _, key := range []string{"valueText", "valueString", "valueInt", "valueNumber", "valueBoolean"} {
+			if want, ok := where[key]; ok {
+				return jsonEqual(value, want), nil
+			}
+		}
+		return false, fmt.Errorf("where filter on %s has no value", name)
+
+	default:
+		return false, fmt.Errorf("fake only supports And and Equal where operators, got %q", operator)
+	}
+}
+
+func (wv *Weaviate) graphqlAggregate(query *gqlField) (interface{}, error) {
+	c, ok := wv.classes[query.Name]
+	if !ok {
+		return nil, fmt.Errorf("Cannot query field %q on type \"AggregateObjectsObj\".", query.Name)
+	}
+
+	tenant, _ := query.Args["tenant"].(string)
+	objects, err := c.objects(query.Name, tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	return []interface{}{
+		map[string]interface{}{"meta": map[string]int{"count": len(objects)}},
+	}, nil
+}
+
+// weaviateDataType infers a property data type the way auto-schema does
+func weaviateDataType(value interface{}) string {
+	switch v := value.(type) {
+	case bool:
+		return "boolean"
+	case json.Number, float64, int, int64:
+		return "number"
+	case []interface{}:
+		if len(v) > 0 {
+			return weaviateDataType(v[0]) + "[]"
+		}
+		return "text[]"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return "text"
+	}
+}
+
+func weaviateError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{"error": []map[string]string{{"message": message}}})
+}
+
+func graphqlError(w http.ResponseWriter, message string) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"data":   nil,
+		"errors": []map[string]interface{}{{"message": message}},
+	})
+}
+
+// newUUID returns a random version 4 UUID
+func newUUID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
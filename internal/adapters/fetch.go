@@ -0,0 +1,47 @@
+package adapters
+
+// fetchByIDs implements FetchByIDs over a native lookup. fetch looks up
+// one chunk of at most size IDs and returns the records it found in any
+// order; fetchByIDs returns them in the order of ids, each ID once, with
+// the IDs no chunk returned as missing. A size of 0 fetches all IDs at once.
+func fetchByIDs(ids []string, size int, fetch func(chunk []string) ([]Record, error)) ([]Record, []string, error) {
+	unique := make([]string, 0, len(ids))
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	if size <= 0 {
+		size = len(unique)
+	}
+
+	found := make(map[string]Record, len(unique))
+	for start := 0; start < len(unique); start += size {
+		end := start + size
+		if end > len(unique) {
+			end = len(unique)
+		}
+
+		records, err := fetch(unique[start:end])
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, record := range records {
+			found[record.ID] = record
+		}
+	}
+
+	records := make([]Record, 0, len(found))
+	var missing []string
+	for _, id := range unique {
+		if record, ok := found[id]; ok {
+			records = append(records, record)
+		} else {
+			missing = append(missing, id)
+		}
+	}
+	return records, missing, nil
+}
@@ -0,0 +1,97 @@
+package adapters
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// resolveFilePath maps a file adapter config to a concrete file path.
+// URL may be a file path (optionally file://) or a directory; for a
+// directory the file is <Index><ext> inside it.
+func resolveFilePath(config DBConfig, ext string) (string, error) {
+	path := strings.TrimPrefix(config.URL, "file://")
+	if path == "" {
+		return "", fmt.Errorf("%s adapter requires a file or directory URL", config.Type)
+	}
+
+	info, err := os.Stat(path)
+	if err == nil && info.IsDir() || strings.HasSuffix(path, string(os.PathSeparator)) {
+		name := config.Index
+		if name == "" {
+			name = "records"
+		}
+		if !strings.HasSuffix(name, ext) {
+			name += ext
+		}
+		path = filepath.Join(path, name)
+	}
+
+	return path, nil
+}
+
+// validateFilePath checks that the directory holding a file adapter's file exists
+func validateFilePath(path string) error {
+	dir := filepath.Dir(path)
+	info, err := os.Stat(dir)
+	if err != nil {
+		return fmt.Errorf("directory %s is not accessible: %w", dir, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", dir)
+	}
+	return nil
+}
+
+// replaceFile atomically replaces path with the contents written by write
+func replaceFile(path string, write func(f *os.File) error) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := write(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to sync temp file: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close temp file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// planUpsert keeps the last record of each ID in records and returns the
+// IDs among them that stored already holds, which must be deleted before
+// the records are appended
+func planUpsert(stored map[string]bool, records []Record) ([]Record, []string) {
+	last := make(map[string]int, len(records))
+	for i, r := range records {
+		last[r.ID] = i
+	}
+
+	var kept []Record
+	var existing []string
+	for i, r := range records {
+		if last[r.ID] != i {
+			continue
+		}
+		kept = append(kept, r)
+		if stored[r.ID] {
+			existing = append(existing, r.ID)
+		}
+	}
+	return kept, existing
+}
@@ -0,0 +1,411 @@
+package adapters
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// JSONLAdapter implements Database interface over a JSON Lines file.
+// Each line is a JSON-encoded Record; reads stream in file order and
+// writes append, so the file doubles as an offline snapshot format.
+// Upserting an ID the file already holds rewrites the file without the
+// old line, so records are never duplicated.
+type JSONLAdapter struct {
+	config    DBConfig
+	path      string
+	sourceURL string
+
+	// IDs in the file, loaded on the first upsert; nil when unknown
+	ids map[string]bool
+
+	// Streaming read state; GetBatch continues from here when afterID is
+	// the last ID it returned and rescans the file otherwise
+	file   *os.File
+	reader *bufio.Reader
+	lastID string
+}
+
+// Connect resolves the file path
+func (a *JSONLAdapter) Connect(ctx context.Context, config DBConfig) error {
+	if config.Type != "jsonl" {
+		return fmt.Errorf("expected type 'jsonl', got '%s'", config.Type)
+	}
+
+	path, err := resolveFilePath(config, ".jsonl")
+	if err != nil {
+		return err
+	}
+
+	a.config = config
+	a.path = path
+	a.sourceURL = "file://" + path
+
+	// Validate connection
+	return a.ValidateConnection(ctx)
+}
+
+// Close closes the read handle
+func (a *JSONLAdapter) Close() error {
+	return a.closeReader()
+}
+
+// GetBatch streams the next records after afterID in file order
+func (a *JSONLAdapter) GetBatch(ctx context.Context, afterID string, limit int) ([]Record, error) {
+	if afterID == "" || afterID != a.lastID || a.reader == nil {
+		// Nothing has been written yet
+		if _, err := os.Stat(a.path); afterID == "" && errors.Is(err, os.ErrNotExist) {
+			return []Record{}, nil
+		}
+		if err := a.openReader(afterID); err != nil {
+			return nil, err
+		}
+	}
+
+	records := make([]Record, 0, limit)
+	for len(records) < limit {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		record, err := a.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+
+	if len(records) > 0 {
+		a.lastID = records[len(records)-1].ID
+	}
+
+	return records, nil
+}
+
+// FetchByIDs scans the file for the given IDs
+func (a *JSONLAdapter) FetchByIDs(ctx context.Context, ids []string) ([]Record, []string, error) {
+	return fetchByIDs(ids, 0, func(chunk []string) ([]Record, error) {
+		wanted := make(map[string]bool, len(chunk))
+		for _, id := range chunk {
+			wanted[id] = true
+		}
+
+		file, err := os.Open(a.path)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", a.path, err)
+		}
+		defer file.Close()
+
+		var records []Record
+		reader := bufio.NewReader(file)
+		for {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			record, err := readJSONLRecord(reader)
+			if err == io.EOF {
+				return records, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			if wanted[record.ID] {
+				records = append(records, record)
+			}
+		}
+	})
+}
+
+// UpsertBatch appends records to the file, first removing the lines of
+// IDs it already holds
+func (a *JSONLAdapter) UpsertBatch(ctx context.Context, records []Record) error {
+	if err := a.loadIDs(ctx); err != nil {
+		return err
+	}
+	records, existing := planUpsert(a.ids, records)
+	if len(existing) > 0 {
+		if err := a.DeleteBatch(ctx, existing); err != nil {
+			return err
+		}
+	}
+
+	if err := a.appendRecords(records); err != nil {
+		// Part of the batch may have been written
+		a.ids = nil
+		return err
+	}
+
+	for _, r := range records {
+		a.ids[r.ID] = true
+	}
+	return nil
+}
+
+// appendRecords appends records to the file
+func (a *JSONLAdapter) appendRecords(records []Record) error {
+	file, err := os.OpenFile(a.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", a.path, err)
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	if err := writeJSONL(writer, records); err != nil {
+		return err
+	}
+
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", a.path, err)
+	}
+
+	return file.Sync()
+}
+
+// loadIDs reads the IDs in the file unless they are already known
+func (a *JSONLAdapter) loadIDs(ctx context.Context) error {
+	if a.ids != nil {
+		return nil
+	}
+
+	ids := make(map[string]bool)
+	file, err := os.Open(a.path)
+	if errors.Is(err, os.ErrNotExist) {
+		a.ids = ids
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", a.path, err)
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		record, err := readJSONLRecord(reader)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		ids[record.ID] = true
+	}
+
+	a.ids = ids
+	return nil
+}
+
+// DeleteBatch rewrites the file without the given IDs
+func (a *JSONLAdapter) DeleteBatch(ctx context.Context, ids []string) error {
+	remove := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		remove[id] = true
+	}
+
+	if err := a.closeReader(); err != nil {
+		return err
+	}
+	for id := range remove {
+		delete(a.ids, id)
+	}
+
+	source, err := os.Open(a.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", a.path, err)
+	}
+	defer source.Close()
+
+	return replaceFile(a.path, func(f *os.File) error {
+		reader := bufio.NewReader(source)
+		writer := bufio.NewWriter(f)
+		for {
+			record, err := readJSONLRecord(reader)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return err
+			}
+			if remove[record.ID] {
+				continue
+			}
+			if err := writeJSONL(writer, []Record{record}); err != nil {
+				return err
+			}
+		}
+		return writer.Flush()
+	})
+}
+
+// ValidateConnection checks that the file's directory is accessible
+func (a *JSONLAdapter) ValidateConnection(ctx context.Context) error {
+	return validateFilePath(a.path)
+}
+
+// GetStats counts records and takes the dimension from the first vector
+func (a *JSONLAdapter) GetStats(ctx context.Context) (*DBStats, error) {
+	stats := &DBStats{
+		IndexType: "jsonl",
+	}
+
+	file, err := os.Open(a.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return stats, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", a.path, err)
+	}
+	defer file.Close()
+
+	if info, err := file.Stat(); err == nil {
+		stats.MemoryUsage = float64(info.Size()) / (1024 * 1024)
+	}
+
+	reader := bufio.NewReader(file)
+	for {
+		record, err := readJSONLRecord(reader)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		stats.TotalRecords++
+		if stats.Dimensions == 0 {
+			stats.Dimensions = len(record.Vector)
+		}
+	}
+
+	return stats, nil
+}
+
+// GetSourceURL returns the file URL
+func (a *JSONLAdapter) GetSourceURL() string {
+	return a.sourceURL
+}
+
+// jsonlCapabilities: JSONL files hold records exactly as they are
+var jsonlCapabilities = Capabilities{
+	NestedMetadata:     true,
+	IDFormats:          []IDFormat{IDString},
+	NamedVectors:       true,
+	SparseVector:       true,
+	NamedSparseVectors: true,
+}
+
+// Capabilities returns what the file can store
+func (a *JSONLAdapter) Capabilities() Capabilities {
+	return jsonlCapabilities
+}
+
+// openReader reopens the file and skips past afterID
+func (a *JSONLAdapter) openReader(afterID string) error {
+	if err := a.closeReader(); err != nil {
+		return err
+	}
+
+	file, err := os.Open(a.path)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", a.path, err)
+	}
+
+	a.file = file
+	a.reader = bufio.NewReader(file)
+	a.lastID = ""
+
+	if afterID == "" {
+		return nil
+	}
+
+	for {
+		record, err := a.next()
+		if err == io.EOF {
+			return fmt.Errorf("record %q not found in %s", afterID, a.path)
+		}
+		if err != nil {
+			return err
+		}
+		if record.ID == afterID {
+			a.lastID = afterID
+			return nil
+		}
+	}
+}
+
+// closeReader releases the read handle
+func (a *JSONLAdapter) closeReader() error {
+	a.reader = nil
+	a.lastID = ""
+	if a.file == nil {
+		return nil
+	}
+	err := a.file.Close()
+	a.file = nil
+	return err
+}
+
+// next reads the next record from the open reader
+func (a *JSONLAdapter) next() (Record, error) {
+	return readJSONLRecord(a.reader)
+}
+
+// readJSONLRecord reads one non-empty line and decodes it
+func readJSONLRecord(reader *bufio.Reader) (Record, error) {
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) == 0 && err != nil {
+			return Record{}, err
+		}
+
+		trimmed := line
+		for len(trimmed) > 0 && (trimmed[len(trimmed)-1] == '\n' || trimmed[len(trimmed)-1] == '\r') {
+			trimmed = trimmed[:len(trimmed)-1]
+		}
+		if len(trimmed) == 0 {
+			if err != nil {
+				return Record{}, err
+			}
+			continue
+		}
+
+		var record Record
+		if err := json.Unmarshal(trimmed, &record); err != nil {
+			return Record{}, fmt.Errorf("failed to decode JSONL record: %w", err)
+		}
+		return record, nil
+	}
+}
+
+// writeJSONL encodes records one per line
+func writeJSONL(writer io.Writer, records []Record) error {
+	encoder := json.NewEncoder(writer)
+	for _, r := range records {
+		if err := encoder.Encode(r); err != nil {
+			return fmt.Errorf("failed to encode record %s: %w", r.ID, err)
+		}
+	}
+	return nil
+}
+
+// Ensure JSONLAdapter implements Database interface
+var _ Database = (*JSONLAdapter)(nil)
+
+// Ensure JSONLAdapter implements CapabilityReporter interface
+var _ CapabilityReporter = (*JSONLAdapter)(nil)
@@ -0,0 +1,205 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// MemoryAdapter implements Database interface in memory. It is the
+// reference implementation of the Database contract: records are returned
+// in ID order, GetBatch is exclusive of afterID and returns an empty slice
+// at the end, upserts replace whole records and deleting a missing ID is
+// not an error.
+type MemoryAdapter struct {
+	config  DBConfig
+	mu      sync.Mutex
+	records map[string]Record
+	ids     []string // sorted; nil when stale
+}
+
+// NewMemoryAdapter creates a connected, empty in-memory database
+func NewMemoryAdapter() *MemoryAdapter {
+	return &MemoryAdapter{
+		config:  DBConfig{Type: "memory"},
+		records: make(map[string]Record),
+	}
+}
+
+// Connect resets the adapter to an empty database
+func (a *MemoryAdapter) Connect(ctx context.Context, config DBConfig) error {
+	if config.Type != "memory" {
+		return fmt.Errorf("expected type 'memory', got '%s'", config.Type)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.config = config
+	a.records = make(map[string]Record)
+	a.ids = nil
+	return nil
+}
+
+// Close is a no-op; records are kept until the adapter is discarded
+func (a *MemoryAdapter) Close() error {
+	return nil
+}
+
+// GetBatch returns up to limit records with IDs greater than afterID
+func (a *MemoryAdapter) GetBatch(ctx context.Context, afterID string, limit int) ([]Record, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.ids == nil {
+		a.ids = make([]string, 0, len(a.records))
+		for id := range a.records {
+			a.ids = append(a.ids, id)
+		}
+		sort.Strings(a.ids)
+	}
+
+	start := sort.SearchStrings(a.ids, afterID)
+	if start < len(a.ids) && a.ids[start] == afterID {
+		start++
+	}
+
+	records := make([]Record, 0, limit)
+	for i := start; i < len(a.ids) && len(records) < limit; i++ {
+		records = append(records, copyRecord(a.records[a.ids[i]]))
+	}
+
+	return records, nil
+}
+
+// FetchByIDs returns the stored records with the given IDs
+func (a *MemoryAdapter) FetchByIDs(ctx context.Context, ids []string) ([]Record, []string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return fetchByIDs(ids, 0, func(chunk []string) ([]Record, error) {
+		records := make([]Record, 0, len(chunk))
+		for _, id := range chunk {
+			if r, exists := a.records[id]; exists {
+				records = append(records, copyRecord(r))
+			}
+		}
+		return records, nil
+	})
+}
+
+// UpsertBatch inserts or replaces records
+func (a *MemoryAdapter) UpsertBatch(ctx context.Context, records []Record) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for _, r := range records {
+		if r.ID == "" {
+			return fmt.Errorf("record has empty ID")
+		}
+		if _, exists := a.records[r.ID]; !exists {
+			a.ids = nil
+		}
+		a.records[r.ID] = copyRecord(r)
+	}
+
+	return nil
+}
+
+// DeleteBatch removes records; missing IDs are ignored
+func (a *MemoryAdapter) DeleteBatch(ctx context.Context, ids []string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for _, id := range ids {
+		if _, exists := a.records[id]; exists {
+			delete(a.records, id)
+			a.ids = nil
+		}
+	}
+
+	return nil
+}
+
+// ValidateConnection always succeeds
+func (a *MemoryAdapter) ValidateConnection(ctx context.Context) error {
+	return nil
+}
+
+// GetStats reports the record count and the dimension of any stored vector
+func (a *MemoryAdapter) GetStats(ctx context.Context) (*DBStats, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	stats := &DBStats{
+		TotalRecords: int64(len(a.records)),
+		IndexType:    "memory",
+	}
+	for _, r := range a.records {
+		stats.Dimensions = len(r.Vector)
+		break
+	}
+
+	return stats, nil
+}
+
+// GetSourceURL returns a memory:// URL naming the index
+func (a *MemoryAdapter) GetSourceURL() string {
+	return "memory://" + a.config.Index
+}
+
+// copyRecord copies a record so callers cannot alias stored data
+func copyRecord(r Record) Record {
+	out := Record{ID: r.ID}
+	if r.Vector != nil {
+		out.Vector = append([]float32(nil), r.Vector...)
+	}
+	if r.Metadata != nil {
+		out.Metadata = make(map[string]interface{}, len(r.Metadata))
+		for k, v := range r.Metadata {
+			out.Metadata[k] = v
+		}
+	}
+	if r.NamedVectors != nil {
+		out.NamedVectors = make(map[string][]float32, len(r.NamedVectors))
+		for name, v := range r.NamedVectors {
+			out.NamedVectors[name] = append([]float32(nil), v...)
+		}
+	}
+	if r.Sparse != nil {
+		out.Sparse = &SparseVector{
+			Indices: append([]uint32(nil), r.Sparse.Indices...),
+			Values:  append([]float32(nil), r.Sparse.Values...),
+		}
+	}
+	if r.SparseVectors != nil {
+		out.SparseVectors = make(map[string]SparseVector, len(r.SparseVectors))
+		for name, v := range r.SparseVectors {
+			out.SparseVectors[name] = SparseVector{
+				Indices: append([]uint32(nil), v.Indices...),
+				Values:  append([]float32(nil), v.Values...),
+			}
+		}
+	}
+	return out
+}
+
+// Ensure MemoryAdapter implements Database interface
+var _ Database = (*MemoryAdapter)(nil)
@@ -0,0 +1,642 @@
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MilvusAdapter implements Database interface for Milvus (REST API v2)
+type MilvusAdapter struct {
+	config      DBConfig
+	httpClient  *http.Client
+	baseURL     string
+	sourceURL   string
+	collection  string
+	dbName      string
+	idField     string
+	idIsInt     bool
+	vectorField string
+	dimension   int
+	dynamic     bool
+	indexName   string
+	fields      map[string]bool
+}
+
+// milvusResponse is the envelope returned by every Milvus v2 endpoint.
+// Milvus answers HTTP 200 for application errors and reports them via Code.
+type milvusResponse struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// milvusField describes a collection field from collections/describe
+type milvusField struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	PrimaryKey bool   `json:"primaryKey"`
+	Nullable   bool   `json:"nullable"`
+
+	// ElementType is the type of an Array field's elements
+	ElementType string `json:"elementType"`
+
+	Params []struct {
+		Key   string `json:"key"`
+		Value string `json:"value"`
+	} `json:"params"`
+}
+
+// milvusCollection represents Milvus collections/describe data
+type milvusCollection struct {
+	CollectionName     string        `json:"collectionName"`
+	EnableDynamicField bool          `json:"enableDynamicField"`
+	Fields             []milvusField `json:"fields"`
+	Indexes            []struct {
+		FieldName  string `json:"fieldName"`
+		IndexName  string `json:"indexName"`
+		MetricType string `json:"metricType"`
+	} `json:"indexes"`
+}
+
+// Connect establishes connection to Milvus and loads the collection schema
+func (a *MilvusAdapter) Connect(ctx context.Context, config DBConfig) error {
+	if config.Type != "milvus" {
+		return fmt.Errorf("expected type 'milvus', got '%s'", config.Type)
+	}
+
+	a.config = config
+	a.sourceURL = config.URL
+	a.baseURL = strings.TrimRight(config.URL, "/")
+	a.collection = config.Index
+	a.dbName = config.Extra["db_name"]
+
+	// Create HTTP client with timeout
+	timeout := time.Duration(config.Timeout) * time.Second
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+
+	a.httpClient = &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			MaxIdleConns:        10,
+			MaxIdleConnsPerHost: 5,
+			IdleConnTimeout:     90 * time.Second,
+		},
+	}
+
+	// Validate connection
+	if err := a.ValidateConnection(ctx); err != nil {
+		return err
+	}
+
+	return a.loadSchema(ctx)
+}
+
+// loadSchema resolves the primary key, vector field and dynamic field setting
+func (a *MilvusAdapter) loadSchema(ctx context.Context) error {
+	var collection milvusCollection
+	if err := a.post(ctx, "/v2/vectordb/collections/describe", a.request(nil), &collection); err != nil {
+		return fmt.Errorf("failed to describe Milvus collection: %w", err)
+	}
+
+	a.dynamic = collection.EnableDynamicField
+	a.idField = a.config.Extra["id_field"]
+	a.vectorField = a.config.Extra["vector_field"]
+	a.fields = make(map[string]bool)
+
+	for _, field := range collection.Fields {
+		a.fields[field.Name] = true
+
+		if a.idField == "" && field.PrimaryKey {
+			a.idField = field.Name
+		}
+		if field.Name == a.idField {
+			a.idIsInt = field.Type == "Int64"
+		}
+
+		if field.Type != "FloatVector" {
+			continue
+		}
+		if a.vectorField == "" {
+			a.vectorField = field.Name
+		}
+		if field.Name == a.vectorField {
+			for _, p := range field.Params {
+				if p.Key == "dim" {
+					a.dimension, _ = strconv.Atoi(p.Value)
+				}
+			}
+		}
+	}
+
+	if a.idField == "" {
+		return fmt.Errorf("Milvus collection %s has no primary key field", a.collection)
+	}
+	if a.vectorField == "" {
+		return fmt.Errorf("Milvus collection %s has no FloatVector field", a.collection)
+	}
+
+	for _, index := range collection.Indexes {
+		if index.FieldName == a.vectorField {
+			a.indexName = index.IndexName
+		}
+	}
+
+	return nil
+}
+
+// Close closes the HTTP client
+func (a *MilvusAdapter) Close() error {
+	if a.httpClient != nil {
+		a.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+// GetBatch retrieves a batch of entities ordered by primary key.
+// Paging follows the Milvus query iterator approach: each page filters on
+// primary keys greater than the last one returned.
+func (a *MilvusAdapter) GetBatch(ctx context.Context, afterID string, limit int) ([]Record, error) {
+	filter := ""
+	if afterID != "" {
+		literal, err := a.idLiteral(afterID)
+		if err != nil {
+			return nil, err
+		}
+		filter = fmt.Sprintf("%s > %s", a.idField, literal)
+	}
+
+	var entities []map[string]interface{}
+	err := a.post(ctx, "/v2/vectordb/entities/query", a.request(map[string]interface{}{
+		"filter":       filter,
+		"limit":        limit,
+		"outputFields": []string{"*"},
+	}), &entities)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query Milvus: %w", err)
+	}
+
+	records := make([]Record, 0, len(entities))
+	for _, entity := range entities {
+		record, err := a.toRecord(entity)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+
+	// Segments are merged by primary key on the server, but keep the
+	// cursor monotonic regardless of server version.
+	sort.Slice(records, func(i, j int) bool {
+		return a.idLess(records[i].ID, records[j].ID)
+	})
+
+	return records, nil
+}
+
+// FetchByIDs reads entities by primary key with entities/get. IDs that
+// are not valid keys for the collection cannot exist and are reported
+// missing.
+func (a *MilvusAdapter) FetchByIDs(ctx context.Context, ids []string) ([]Record, []string, error) {
+	return fetchByIDs(ids, milvusCapabilities.MaxBatchSize, func(chunk []string) ([]Record, error) {
+		keys := make([]interface{}, 0, len(chunk))
+		for _, id := range chunk {
+			if key, err := a.idValue(id); err == nil {
+				keys = append(keys, key)
+			}
+		}
+		if len(keys) == 0 {
+			return nil, nil
+		}
+
+		var entities []map[string]interface{}
+		err := a.post(ctx, "/v2/vectordb/entities/get", a.request(map[string]interface{}{
+			"id":           keys,
+			"outputFields": []string{"*"},
+		}), &entities)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get entities from Milvus: %w", err)
+		}
+
+		records := make([]Record, 0, len(entities))
+		for _, entity := range entities {
+			record, err := a.toRecord(entity)
+			if err != nil {
+				return nil, err
+			}
+			records = append(records, record)
+		}
+		return records, nil
+	})
+}
+
+// UpsertBatch inserts or updates entities in Milvus
+func (a *MilvusAdapter) UpsertBatch(ctx context.Context, records []Record) error {
+	data := make([]map[string]interface{}, len(records))
+	for i, r := range records {
+		entity := make(map[string]interface{}, len(r.Metadata)+2)
+		for key, value := range r.Metadata {
+			// Without dynamic fields Milvus rejects keys missing from the schema
+			if !a.dynamic && !a.fields[key] {
+				continue
+			}
+			entity[key] = value
+		}
+
+		id, err := a.idValue(r.ID)
+		if err != nil {
+			return err
+		}
+		entity[a.idField] = id
+		entity[a.vectorField] = r.Vector
+
+		data[i] = entity
+	}
+
+	err := a.post(ctx, "/v2/vectordb/entities/upsert", a.request(map[string]interface{}{
+		"data": data,
+	}), nil)
+	if err != nil {
+		return fmt.Errorf("failed to upsert to Milvus: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteBatch deletes entities from Milvus by primary key
+func (a *MilvusAdapter) DeleteBatch(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	filter, err := a.idInFilter(ids)
+	if err != nil {
+		return err
+	}
+
+	err = a.post(ctx, "/v2/vectordb/entities/delete", a.request(map[string]interface{}{
+		"filter": filter,
+	}), nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete from Milvus: %w", err)
+	}
+
+	return nil
+}
+
+// ValidateConnection checks if Milvus is accessible
+func (a *MilvusAdapter) ValidateConnection(ctx context.Context) error {
+	var exists struct {
+		Has bool `json:"has"`
+	}
+	if err := a.post(ctx, "/v2/vectordb/collections/has", a.request(nil), &exists); err != nil {
+		return fmt.Errorf("failed to connect to Milvus: %w", err)
+	}
+
+	if !exists.Has {
+		return fmt.Errorf("Milvus collection %s does not exist", a.collection)
+	}
+
+	return nil
+}
+
+// GetStats returns Milvus collection statistics
+func (a *MilvusAdapter) GetStats(ctx context.Context) (*DBStats, error) {
+	var collectionStats struct {
+		RowCount int64 `json:"rowCount"`
+	}
+	if err := a.post(ctx, "/v2/vectordb/collections/get_stats", a.request(nil), &collectionStats); err != nil {
+		return nil, fmt.Errorf("failed to get stats from Milvus: %w", err)
+	}
+
+	stats := &DBStats{
+		TotalRecords: collectionStats.RowCount,
+		Dimensions:   a.dimension,
+		IndexType:    "milvus",
+		MemoryUsage:  0, // Not available via API
+	}
+
+	// Index details are best effort; an unindexed collection is still readable
+	if a.indexName != "" {
+		var indexes []struct {
+			IndexType string `json:"indexType"`
+		}
+		err := a.post(ctx, "/v2/vectordb/indexes/describe", a.request(map[string]interface{}{
+			"indexName": a.indexName,
+		}), &indexes)
+		if err == nil && len(indexes) > 0 && indexes[0].IndexType != "" {
+			stats.IndexType = "milvus-" + strings.ToLower(indexes[0].IndexType)
+		}
+	}
+
+	return stats, nil
+}
+
+// GetSchema reads the scalar fields of the collection schema. The primary
+// key and vector fields are not metadata and are left out. Dynamic fields
+// have no declared schema, so for dynamic collections they are inferred
+// by sampling; Extra["schema_sample"] sets the sample size.
+func (a *MilvusAdapter) GetSchema(ctx context.Context) (*Schema, error) {
+	var collection milvusCollection
+	if err := a.post(ctx, "/v2/vectordb/collections/describe", a.request(nil), &collection); err != nil {
+		return nil, fmt.Errorf("failed to get schema from Milvus: %w", err)
+	}
+
+	schema := &Schema{Fields: []FieldSchema{}}
+	native := make(map[string]bool)
+	for _, field := range collection.Fields {
+		native[field.Name] = true
+		if field.Name == a.idField || field.Name == a.vectorField {
+			continue
+		}
+
+		typ, array := field.Type, false
+		if typ == "Array" {
+			typ, array = field.ElementType, true
+		}
+		propertyType, ok := milvusPropertyTypes[typ]
+		if !ok {
+			continue
+		}
+		schema.Fields = append(schema.Fields, FieldSchema{
+			Path:     []string{field.Name},
+			Type:     propertyType,
+			Array:    array,
+			Nullable: field.Nullable,
+		})
+	}
+
+	if collection.EnableDynamicField {
+		sampled, err := SampleSchema(ctx, a, schemaSampleSize(a.config))
+		if err != nil {
+			return nil, fmt.Errorf("failed to sample Milvus dynamic fields: %w", err)
+		}
+		for _, field := range sampled.Fields {
+			if !native[field.Path[0]] {
+				schema.Fields = append(schema.Fields, field)
+			}
+		}
+		schema.Sampled = sampled.Sampled
+	}
+
+	sort.SliceStable(schema.Fields, func(i, j int) bool {
+		return pathKey(schema.Fields[i].Path) < pathKey(schema.Fields[j].Path)
+	})
+	return schema, nil
+}
+
+// milvusPropertyTypes maps Milvus scalar data types to property types
+var milvusPropertyTypes = map[string]PropertyType{
+	"Bool":    PropertyBool,
+	"Int8":    PropertyInt,
+	"Int16":   PropertyInt,
+	"Int32":   PropertyInt,
+	"Int64":   PropertyInt,
+	"Float":   PropertyNumber,
+	"Double":  PropertyNumber,
+	"VarChar": PropertyText,
+	"JSON":    PropertyObject,
+}
+
+// GetSourceURL returns the Milvus source URL
+func (a *MilvusAdapter) GetSourceURL() string {
+	return a.sourceURL
+}
+
+// milvusCapabilities: collections have typed fields plus a JSON dynamic
+// field, and queries return at most 16384 entities
+var milvusCapabilities = Capabilities{
+	NestedMetadata:  true,
+	MaxBatchSize:    16384,
+	IDFormats:       []IDFormat{IDString},
+	TypedProperties: true,
+}
+
+// Capabilities returns what the collection can store; Int64 primary keys
+// take integer IDs only
+func (a *MilvusAdapter) Capabilities() Capabilities {
+	caps := milvusCapabilities
+	if a.idIsInt {
+		caps.IDFormats = []IDFormat{IDInt}
+	}
+	return caps
+}
+
+// request builds a request body scoped to the configured collection
+func (a *MilvusAdapter) request(fields map[string]interface{}) map[string]interface{} {
+	body := map[string]interface{}{
+		"collectionName": a.collection,
+	}
+	if a.dbName != "" {
+		body["dbName"] = a.dbName
+	}
+	for key, value := range fields {
+		body[key] = value
+	}
+	return body
+}
+
+// post sends a request to a Milvus v2 endpoint and decodes the data field into out
+func (a *MilvusAdapter) post(ctx context.Context, path string, body interface{}, out interface{}) error {
+	jsonData, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", a.baseURL+path, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if a.config.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+a.config.APIKey)
+	}
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return apiError("Milvus", resp)
+	}
+
+	decoder := json.NewDecoder(resp.Body)
+	decoder.UseNumber()
+
+	var envelope milvusResponse
+	if err := decoder.Decode(&envelope); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if envelope.Code != 0 {
+		return &Error{
+			Kind: milvusCodeKind(envelope.Code, envelope.Message),
+			Err:  fmt.Errorf("Milvus API error (code %d): %s", envelope.Code, envelope.Message),
+		}
+	}
+
+	if out == nil || len(envelope.Data) == 0 {
+		return nil
+	}
+
+	dataDecoder := json.NewDecoder(bytes.NewReader(envelope.Data))
+	dataDecoder.UseNumber()
+	if err := dataDecoder.Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response data: %w", err)
+	}
+
+	return nil
+}
+
+// milvusCodeKind classifies an application error code like statusKind
+// classifies HTTP statuses. Milvus reports throttling as code 8 and an
+// unready or unavailable service as 1, 2 or 12; the message is checked too
+// for servers that answer with a generic code.
+func milvusCodeKind(code int, message string) ErrorKind {
+	message = strings.ToLower(message)
+	switch {
+	case code == 8 || strings.Contains(message, "rate limit") || strings.Contains(message, "ratelimit"):
+		return ErrorRateLimited
+	case code == 1 || code == 2 || code == 12 ||
+		strings.Contains(message, "service unavailable") || strings.Contains(message, "not ready"):
+		return ErrorRetryable
+	}
+	return ErrorPermanent
+}
+
+// toRecord converts a Milvus entity into a Record.
+// Scalar schema fields and dynamic fields both end up in Metadata.
+func (a *MilvusAdapter) toRecord(entity map[string]interface{}) (Record, error) {
+	record := Record{
+		Metadata: make(map[string]interface{}),
+	}
+
+	switch id := entity[a.idField].(type) {
+	case json.Number:
+		record.ID = id.String()
+	case string:
+		record.ID = id
+	default:
+		return record, fmt.Errorf("Milvus entity has unsupported primary key %v", entity[a.idField])
+	}
+
+	if values, ok := entity[a.vectorField].([]interface{}); ok {
+		record.Vector = make([]float32, len(values))
+		for i, v := range values {
+			if n, ok := v.(json.Number); ok {
+				f, _ := n.Float64()
+				record.Vector[i] = float32(f)
+			}
+		}
+	}
+
+	for key, value := range entity {
+		if key == a.idField || key == a.vectorField {
+			continue
+		}
+
+		// Older servers nest dynamic fields under $meta
+		if key == "$meta" {
+			if meta, ok := value.(map[string]interface{}); ok {
+				for metaKey, metaValue := range meta {
+					record.Metadata[metaKey] = normalizeMilvusValue(metaValue)
+				}
+			}
+			continue
+		}
+
+		record.Metadata[key] = normalizeMilvusValue(value)
+	}
+
+	return record, nil
+}
+
+// idLiteral renders a primary key as a Milvus filter literal
+func (a *MilvusAdapter) idLiteral(id string) (string, error) {
+	if a.idIsInt {
+		if _, err := strconv.ParseInt(id, 10, 64); err != nil {
+			return "", fmt.Errorf("invalid Int64 primary key %q: %w", id, err)
+		}
+		return id, nil
+	}
+	return strconv.Quote(id), nil
+}
+
+// idValue converts a record ID to the JSON value expected by the primary key field
+func (a *MilvusAdapter) idValue(id string) (interface{}, error) {
+	if !a.idIsInt {
+		return id, nil
+	}
+	n, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Int64 primary key %q: %w", id, err)
+	}
+	return n, nil
+}
+
+// idInFilter builds an "id in [...]" filter expression
+func (a *MilvusAdapter) idInFilter(ids []string) (string, error) {
+	literals := make([]string, len(ids))
+	for i, id := range ids {
+		literal, err := a.idLiteral(id)
+		if err != nil {
+			return "", err
+		}
+		literals[i] = literal
+	}
+	return fmt.Sprintf("%s in [%s]", a.idField, strings.Join(literals, ", ")), nil
+}
+
+// idLess orders primary keys the way Milvus compares them
+func (a *MilvusAdapter) idLess(x, y string) bool {
+	if a.idIsInt {
+		xi, _ := strconv.ParseInt(x, 10, 64)
+		yi, _ := strconv.ParseInt(y, 10, 64)
+		return xi < yi
+	}
+	return x < y
+}
+
+// normalizeMilvusValue converts json.Number values to int64 or float64
+func normalizeMilvusValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case []interface{}:
+		for i := range v {
+			v[i] = normalizeMilvusValue(v[i])
+		}
+		return v
+	case map[string]interface{}:
+		for key := range v {
+			v[key] = normalizeMilvusValue(v[key])
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+// Ensure MilvusAdapter implements Database interface
+var _ Database = (*MilvusAdapter)(nil)
+
+// Ensure MilvusAdapter implements SchemaReader interface
+var _ SchemaReader = (*MilvusAdapter)(nil)
+
+// Ensure MilvusAdapter implements CapabilityReporter interface
+var _ CapabilityReporter = (*MilvusAdapter)(nil)
@@ -0,0 +1,431 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeMilvus is a minimal in-process stand-in for the Milvus REST v2 API
+type fakeMilvus struct {
+	mu       sync.Mutex
+	entities map[int64]map[string]interface{}
+	dynamic  bool
+
+	// failCode, if set, answers the next failTimes requests with this
+	// envelope code and failMessage over HTTP 200
+	failCode    int
+	failMessage string
+	failTimes   int
+}
+
+func newFakeMilvus(t *testing.T, dynamic bool) (*fakeMilvus, *httptest.Server) {
+	fake := &fakeMilvus{
+		entities: make(map[int64]map[string]interface{}),
+		dynamic:  dynamic,
+	}
+	server := httptest.NewServer(http.HandlerFunc(fake.handle))
+	t.Cleanup(server.Close)
+	return fake, server
+}
+
+func (f *fakeMilvus) handle(w http.ResponseWriter, r *http.Request) {
+	var body map[string]interface{}
+	decoder := json.NewDecoder(r.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(&body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if body["collectionName"] != "docs" {
+		writeMilvus(w, 100, "collection not found", nil)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.failTimes > 0 {
+		f.failTimes--
+		writeMilvus(w, f.failCode, f.failMessage, nil)
+		return
+	}
+
+	switch r.URL.Path {
+	case "/v2/vectordb/collections/has":
+		writeMilvus(w, 0, "", map[string]bool{"has": true})
+
+	case "/v2/vectordb/collections/describe":
+		writeMilvus(w, 0, "", map[string]interface{}{
+			"collectionName":     "docs",
+			"enableDynamicField": f.dynamic,
+			"fields": []map[string]interface{}{
+				{"name": "pk", "type": "Int64", "primaryKey": true},
+				{"name": "embedding", "type": "FloatVector", "params": []map[string]string{{"key": "dim", "value": "3"}}},
+				{"name": "title", "type": "VarChar"},
+				{"name": "year", "type": "Int32", "nullable": true},
+				{"name": "tags", "type": "Array", "elementType": "VarChar"},
+			},
+			"indexes": []map[string]string{
+				{"fieldName": "embedding", "indexName": "embedding_idx", "metricType": "COSINE"},
+			},
+		})
+
+	case "/v2/vectordb/collections/get_stats":
+		writeMilvus(w, 0, "", map[string]int{"rowCount": len(f.entities)})
+
+	case "/v2/vectordb/indexes/describe":
+		writeMilvus(w, 0, "", []map[string]string{{"indexType": "HNSW"}})
+
+	case "/v2/vectordb/entities/query":
+		var after int64 = -1 << 63
+		if filter, _ := body["filter"].(string); filter != "" {
+			after, _ = strconv.ParseInt(strings.TrimPrefix(filter, "pk > "), 10, 64)
+		}
+		limit, _ := body["limit"].(json.Number).Int64()
+
+		keys := make([]int64, 0, len(f.entities))
+		for k := range f.entities {
+			if k > after {
+				keys = append(keys, k)
+			}
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		if int64(len(keys)) > limit {
+			keys = keys[:limit]
+		}
+
+		data := make([]map[string]interface{}, len(keys))
+		for i, k := range keys {
+			data[i] = f.entities[k]
+		}
+		writeMilvus(w, 0, "", data)
+
+	case "/v2/vectordb/entities/get":
+		ids, _ := body["id"].([]interface{})
+		data := []map[string]interface{}{}
+		for _, id := range ids {
+			pk, _ := id.(json.Number).Int64()
+			if entity, exists := f.entities[pk]; exists {
+				data = append(data, entity)
+			}
+		}
+		writeMilvus(w, 0, "", data)
+
+	case "/v2/vectordb/entities/upsert":
+		data, _ := body["data"].([]interface{})
+		for _, item := range data {
+			entity := item.(map[string]interface{})
+			pk, _ := entity["pk"].(json.Number).Int64()
+			if !f.dynamic {
+				for key := range entity {
+					if !milvusFakeFields[key] {
+						writeMilvus(w, 1100, "field "+key+" not in schema", nil)
+						return
+					}
+				}
+			}
+			f.entities[pk] = entity
+		}
+		writeMilvus(w, 0, "", map[string]int{"upsertCount": len(data)})
+
+	case "/v2/vectordb/entities/delete":
+		filter, _ := body["filter"].(string)
+		list := strings.TrimSuffix(strings.TrimPrefix(filter, "pk in ["), "]")
+		for _, part := range strings.Split(list, ",") {
+			pk, _ := strconv.ParseInt(strings.TrimSpace(part), 10, 64)
+			delete(f.entities, pk)
+		}
+		writeMilvus(w, 0, "", map[string]interface{}{})
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// milvusFakeFields are the fields of the fake's collection schema
+var milvusFakeFields = map[string]bool{"pk": true, "embedding": true, "title": true, "year": true, "tags": true}
+
+func writeMilvus(w http.ResponseWriter, code int, message string, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"code":    code,
+		"message": message,
+		"data":    data,
+	})
+}
+
+func connectMilvus(t *testing.T, url string) *MilvusAdapter {
+	adapter := &MilvusAdapter{}
+	err := adapter.Connect(context.Background(), DBConfig{
+		Type:  "milvus",
+		URL:   url,
+		Index: "docs",
+	})
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	return adapter
+}
+
+// TestMilvusAdapterConnect tests schema discovery on connect
+func TestMilvusAdapterConnect(t *testing.T) {
+	_, server := newFakeMilvus(t, true)
+	adapter := connectMilvus(t, server.URL)
+	defer adapter.Close()
+
+	if adapter.idField != "pk" || !adapter.idIsInt {
+		t.Errorf("Expected Int64 primary key 'pk', got '%s' (int=%v)", adapter.idField, adapter.idIsInt)
+	}
+
+	if adapter.vectorField != "embedding" || adapter.dimension != 3 {
+		t.Errorf("Expected vector field 'embedding' with dim 3, got '%s' with dim %d", adapter.vectorField, adapter.dimension)
+	}
+
+	wrongType := &MilvusAdapter{}
+	if err := wrongType.Connect(context.Background(), DBConfig{Type: "qdrant"}); err == nil {
+		t.Error("Expected error for invalid type, got nil")
+	}
+
+	missing := &MilvusAdapter{}
+	if err := missing.Connect(context.Background(), DBConfig{Type: "milvus", URL: server.URL, Index: "nope"}); err == nil {
+		t.Error("Expected error for unknown collection, got nil")
+	}
+
+	t.Log("✓ MilvusAdapter discovers primary key and vector field")
+}
+
+// TestMilvusAdapterPagination tests primary-key pagination and dynamic fields
+func TestMilvusAdapterPagination(t *testing.T) {
+	_, server := newFakeMilvus(t, true)
+	adapter := connectMilvus(t, server.URL)
+	defer adapter.Close()
+	ctx := context.Background()
+
+	var records []Record
+	for i := 1; i <= 25; i++ {
+		records = append(records, Record{
+			ID:     strconv.Itoa(i),
+			Vector: []float32{float32(i), 0.5, 0.25},
+			Metadata: map[string]interface{}{
+				"title":    "doc " + strconv.Itoa(i),
+				"category": "dynamic",
+			},
+		})
+	}
+
+	if err := adapter.UpsertBatch(ctx, records); err != nil {
+		t.Fatalf("Failed to upsert: %v", err)
+	}
+
+	var seen []Record
+	afterID := ""
+	for {
+		batch, err := adapter.GetBatch(ctx, afterID, 10)
+		if err != nil {
+			t.Fatalf("Failed to get batch: %v", err)
+		}
+		if len(batch) == 0 {
+			break
+		}
+		seen = append(seen, batch...)
+		afterID = batch[len(batch)-1].ID
+	}
+
+	if len(seen) != 25 {
+		t.Fatalf("Expected 25 records, got %d", len(seen))
+	}
+
+	if seen[9].ID != "10" || seen[10].ID != "11" {
+		t.Errorf("Expected records ordered by primary key, got %s then %s", seen[9].ID, seen[10].ID)
+	}
+
+	if seen[0].Metadata["category"] != "dynamic" {
+		t.Errorf("Expected dynamic field in metadata, got %v", seen[0].Metadata)
+	}
+
+	if seen[0].Metadata["title"] != "doc 1" {
+		t.Errorf("Expected title 'doc 1', got '%v'", seen[0].Metadata["title"])
+	}
+
+	if len(seen[0].Vector) != 3 || seen[0].Vector[0] != 1 {
+		t.Errorf("Expected vector [1 0.5 0.25], got %v", seen[0].Vector)
+	}
+
+	t.Log("✓ MilvusAdapter paginates by primary key")
+}
+
+// TestMilvusAdapterErrorCodes tests classifying errors Milvus reports in
+// the response envelope over HTTP 200
+func TestMilvusAdapterErrorCodes(t *testing.T) {
+	fake, server := newFakeMilvus(t, true)
+	adapter := connectMilvus(t, server.URL)
+	defer adapter.Close()
+	ctx := context.Background()
+
+	tests := []struct {
+		code    int
+		message string
+		kind    ErrorKind
+	}{
+		{8, "rate limit exceeded[rate=10]", ErrorRateLimited},
+		{65535, "request is rejected by grpc RateLimiter middleware, please retry later", ErrorRateLimited},
+		{2, "service unavailable", ErrorRetryable},
+		{1100, "invalid parameter", ErrorPermanent},
+	}
+
+	for _, tt := range tests {
+		fake.mu.Lock()
+		fake.failCode, fake.failMessage, fake.failTimes = tt.code, tt.message, 1
+		fake.mu.Unlock()
+
+		_, err := adapter.GetBatch(ctx, "", 10)
+		if err == nil {
+			t.Fatalf("Expected code %d to fail", tt.code)
+		}
+		if kind, _ := ClassifyError(err); kind != tt.kind {
+			t.Errorf("Expected code %d to be %s, got %s (%v)", tt.code, tt.kind, kind, err)
+		}
+	}
+
+	// The throttled call succeeds once repeated
+	if _, err := adapter.GetBatch(ctx, "", 10); err != nil {
+		t.Errorf("Expected the repeated call to succeed, got %v", err)
+	}
+
+	t.Log("✓ MilvusAdapter classifies envelope error codes")
+}
+
+// TestMilvusAdapterStaticSchema tests that unknown fields are dropped without dynamic fields
+func TestMilvusAdapterStaticSchema(t *testing.T) {
+	_, server := newFakeMilvus(t, false)
+	adapter := connectMilvus(t, server.URL)
+	defer adapter.Close()
+	ctx := context.Background()
+
+	err := adapter.UpsertBatch(ctx, []Record{{
+		ID:       "7",
+		Vector:   []float32{0.1, 0.2, 0.3},
+		Metadata: map[string]interface{}{"title": "kept", "extra": "dropped"},
+	}})
+	if err != nil {
+		t.Fatalf("Failed to upsert: %v", err)
+	}
+
+	batch, err := adapter.GetBatch(ctx, "", 10)
+	if err != nil {
+		t.Fatalf("Failed to get batch: %v", err)
+	}
+
+	if len(batch) != 1 || batch[0].Metadata["title"] != "kept" {
+		t.Fatalf("Expected one record with title 'kept', got %v", batch)
+	}
+
+	if _, exists := batch[0].Metadata["extra"]; exists {
+		t.Error("Expected non-schema field to be dropped")
+	}
+
+	if err := adapter.UpsertBatch(ctx, []Record{{ID: "not-a-number"}}); err == nil {
+		t.Error("Expected error for non-numeric Int64 primary key")
+	}
+
+	t.Log("✓ MilvusAdapter respects static schemas")
+}
+
+// TestMilvusAdapterStatsAndDelete tests collection stats and deletes
+func TestMilvusAdapterStatsAndDelete(t *testing.T) {
+	_, server := newFakeMilvus(t, true)
+	adapter := connectMilvus(t, server.URL)
+	defer adapter.Close()
+	ctx := context.Background()
+
+	err := adapter.UpsertBatch(ctx, []Record{
+		{ID: "1", Vector: []float32{1, 0, 0}},
+		{ID: "2", Vector: []float32{0, 1, 0}},
+		{ID: "3", Vector: []float32{0, 0, 1}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to upsert: %v", err)
+	}
+
+	if err := adapter.DeleteBatch(ctx, []string{"1", "3"}); err != nil {
+		t.Fatalf("Failed to delete: %v", err)
+	}
+
+	stats, err := adapter.GetStats(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get stats: %v", err)
+	}
+
+	if stats.TotalRecords != 1 {
+		t.Errorf("Expected 1 record after delete, got %d", stats.TotalRecords)
+	}
+
+	if stats.Dimensions != 3 {
+		t.Errorf("Expected 3 dimensions, got %d", stats.Dimensions)
+	}
+
+	if stats.IndexType != "milvus-hnsw" {
+		t.Errorf("Expected index type 'milvus-hnsw', got '%s'", stats.IndexType)
+	}
+
+	t.Log("✓ MilvusAdapter reports stats and deletes by primary key")
+}
+
+// TestMilvusAdapterGetSchema tests schema reads with and without dynamic fields
+func TestMilvusAdapterGetSchema(t *testing.T) {
+	ctx := context.Background()
+
+	_, static := newFakeMilvus(t, false)
+	adapter := connectMilvus(t, static.URL)
+	defer adapter.Close()
+
+	schema, err := adapter.GetSchema(ctx)
+	if err != nil {
+		t.Fatalf("GetSchema failed: %v", err)
+	}
+	want := []FieldSchema{
+		{Path: []string{"tags"}, Type: PropertyText, Array: true},
+		{Path: []string{"title"}, Type: PropertyText},
+		{Path: []string{"year"}, Type: PropertyInt, Nullable: true},
+	}
+	if !reflect.DeepEqual(schema.Fields, want) || schema.Sampled != 0 {
+		t.Errorf("Expected native fields %+v, got %+v", want, schema)
+	}
+
+	_, dynamic := newFakeMilvus(t, true)
+	adapter = connectMilvus(t, dynamic.URL)
+	defer adapter.Close()
+
+	err = adapter.UpsertBatch(ctx, []Record{
+		{ID: "1", Vector: []float32{1, 0, 0}, Metadata: map[string]interface{}{"title": "a", "category": "x"}},
+		{ID: "2", Vector: []float32{0, 1, 0}, Metadata: map[string]interface{}{"title": "b"}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to upsert: %v", err)
+	}
+
+	schema, err = adapter.GetSchema(ctx)
+	if err != nil {
+		t.Fatalf("GetSchema failed: %v", err)
+	}
+	want = []FieldSchema{
+		{Path: []string{"category"}, Type: PropertyText, Nullable: true, Cardinality: 1},
+		{Path: []string{"tags"}, Type: PropertyText, Array: true},
+		{Path: []string{"title"}, Type: PropertyText},
+		{Path: []string{"year"}, Type: PropertyInt, Nullable: true},
+	}
+	if !reflect.DeepEqual(schema.Fields, want) || schema.Sampled != 2 {
+		t.Errorf("Expected native and sampled dynamic fields %+v, got %+v", want, schema)
+	}
+
+	t.Log("✓ MilvusAdapter reads its schema and samples dynamic fields")
+}
@@ -0,0 +1,24 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd)
+
+package adapters
+
+import (
+	"fmt"
+	"os"
+)
+
+// mappedFile is a read-only view of a file's contents
+type mappedFile struct {
+	data  []byte
+	close func() error
+}
+
+// mapFile reads path into memory on platforms without mmap
+func mapFile(path string) (*mappedFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	return &mappedFile{data: data, close: func() error { return nil }}, nil
+}
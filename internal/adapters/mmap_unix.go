@@ -0,0 +1,43 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd
+
+package adapters
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// mappedFile is a read-only view of a file's contents
+type mappedFile struct {
+	data  []byte
+	close func() error
+}
+
+// mapFile memory-maps path read-only
+func mapFile(path string) (*mappedFile, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+
+	if info.Size() == 0 {
+		return &mappedFile{close: func() error { return nil }}, nil
+	}
+
+	data, err := syscall.Mmap(int(file.Fd()), 0, int(info.Size()), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mmap %s: %w", path, err)
+	}
+
+	return &mappedFile{
+		data:  data,
+		close: func() error { return syscall.Munmap(data) },
+	}, nil
+}
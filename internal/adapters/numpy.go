@@ -0,0 +1,681 @@
+package adapters
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// NumpyAdapter implements Database interface as a read-only source over
+// embedding files produced by research tooling: a .npy matrix, a .npz
+// archive, or a FAISS IndexFlat/IndexIDMap file. Vectors are memory-mapped;
+// IDs and metadata come from a sidecar JSONL with one line per row.
+//
+// Extra settings:
+//   - sidecar: path to the ID/metadata JSONL (default: same name, .jsonl)
+//   - array: .npz member holding the vectors (default: embeddings, or the only 2-D array)
+//   - ids_array: .npz member holding IDs (default: ids, if present)
+type NumpyAdapter struct {
+	config    DBConfig
+	path      string
+	sourceURL string
+	format    string
+	metric    string
+
+	mapped  *mappedFile
+	vectors []byte
+	dtype   string
+	rows    int
+	dim     int
+
+	// IDs by row; nil means the row number is the ID
+	ids       []string
+	positions map[string]int
+
+	// Sidecar line offsets by row, with a final end offset
+	sidecar *os.File
+	offsets []int64
+
+	pos    int
+	lastID string
+}
+
+// Connect maps the file and indexes the sidecar
+func (a *NumpyAdapter) Connect(ctx context.Context, config DBConfig) error {
+	if config.Type != "npy" {
+		return fmt.Errorf("expected type 'npy', got '%s'", config.Type)
+	}
+
+	a.config = config
+	a.path = strings.TrimPrefix(config.URL, "file://")
+	a.sourceURL = "file://" + a.path
+
+	mapped, err := mapFile(a.path)
+	if err != nil {
+		return err
+	}
+	a.mapped = mapped
+
+	if err := a.load(); err != nil {
+		a.Close()
+		return err
+	}
+
+	if err := a.openSidecar(); err != nil {
+		a.Close()
+		return err
+	}
+
+	// Validate connection
+	return a.ValidateConnection(ctx)
+}
+
+// Close unmaps the file and closes the sidecar
+func (a *NumpyAdapter) Close() error {
+	var err error
+	if a.sidecar != nil {
+		err = a.sidecar.Close()
+		a.sidecar = nil
+	}
+	if a.mapped != nil {
+		if closeErr := a.mapped.close(); err == nil {
+			err = closeErr
+		}
+		a.mapped = nil
+	}
+	return err
+}
+
+// GetBatch returns the next rows after afterID in file order
+func (a *NumpyAdapter) GetBatch(ctx context.Context, afterID string, limit int) ([]Record, error) {
+	if a.mapped == nil {
+		return nil, fmt.Errorf("not connected")
+	}
+
+	start := 0
+	if afterID != "" {
+		if afterID == a.lastID {
+			start = a.pos
+		} else {
+			row, ok := a.rowOf(afterID)
+			if !ok {
+				return nil, fmt.Errorf("record %q not found in %s", afterID, a.path)
+			}
+			start = row + 1
+		}
+	}
+
+	end := start + limit
+	if end > a.rows {
+		end = a.rows
+	}
+	if start >= end {
+		return []Record{}, nil
+	}
+
+	metadata, err := a.readMetadata(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]Record, 0, end-start)
+	for row := start; row < end; row++ {
+		records = append(records, Record{
+			ID:       a.idOf(row),
+			Vector:   a.vector(row),
+			Metadata: metadata[row-start],
+		})
+	}
+
+	a.pos = end
+	a.lastID = records[len(records)-1].ID
+
+	return records, nil
+}
+
+// FetchByIDs looks rows up by ID and reads their sidecar lines
+func (a *NumpyAdapter) FetchByIDs(ctx context.Context, ids []string) ([]Record, []string, error) {
+	if a.mapped == nil {
+		return nil, nil, fmt.Errorf("not connected")
+	}
+
+	return fetchByIDs(ids, 0, func(chunk []string) ([]Record, error) {
+		records := make([]Record, 0, len(chunk))
+		for _, id := range chunk {
+			row, ok := a.rowOf(id)
+			if !ok || a.idOf(row) != id {
+				continue
+			}
+			metadata, err := a.readMetadata(row, row+1)
+			if err != nil {
+				return nil, err
+			}
+			records = append(records, Record{
+				ID:       a.idOf(row),
+				Vector:   a.vector(row),
+				Metadata: metadata[0],
+			})
+		}
+		return records, nil
+	})
+}
+
+// UpsertBatch is not supported; the adapter is read-only
+func (a *NumpyAdapter) UpsertBatch(ctx context.Context, records []Record) error {
+	return fmt.Errorf("npy adapter is read-only")
+}
+
+// DeleteBatch is not supported; the adapter is read-only
+func (a *NumpyAdapter) DeleteBatch(ctx context.Context, ids []string) error {
+	return fmt.Errorf("npy adapter is read-only")
+}
+
+// ValidateConnection checks the vectors and sidecar agree
+func (a *NumpyAdapter) ValidateConnection(ctx context.Context) error {
+	if a.mapped == nil {
+		return fmt.Errorf("not connected")
+	}
+
+	if len(a.vectors) < a.rows*a.dim*dtypeSize(a.dtype) {
+		return fmt.Errorf("%s is truncated: expected %d x %d vectors", a.path, a.rows, a.dim)
+	}
+
+	if a.ids != nil && len(a.ids) != a.rows {
+		return fmt.Errorf("%s has %d IDs for %d vectors", a.path, len(a.ids), a.rows)
+	}
+
+	return nil
+}
+
+// GetStats reports the matrix shape
+func (a *NumpyAdapter) GetStats(ctx context.Context) (*DBStats, error) {
+	stats := &DBStats{
+		TotalRecords: int64(a.rows),
+		Dimensions:   a.dim,
+		IndexType:    a.format,
+		MemoryUsage:  float64(len(a.vectors)) / (1024 * 1024),
+		Details: map[string]interface{}{
+			"dtype": a.dtype,
+		},
+	}
+	if a.metric != "" {
+		stats.Details["metric"] = a.metric
+	}
+	if a.sidecar != nil {
+		stats.Details["sidecar"] = a.sidecar.Name()
+	}
+	return stats, nil
+}
+
+// GetSourceURL returns the file URL
+func (a *NumpyAdapter) GetSourceURL() string {
+	return a.sourceURL
+}
+
+// numpyCapabilities: sidecar metadata is JSON
+var numpyCapabilities = Capabilities{
+	NestedMetadata: true,
+	IDFormats:      []IDFormat{IDString},
+}
+
+// Capabilities returns what the files hold
+func (a *NumpyAdapter) Capabilities() Capabilities {
+	return numpyCapabilities
+}
+
+// load locates the vector matrix according to the file format
+func (a *NumpyAdapter) load() error {
+	data := a.mapped.data
+
+	switch {
+	case bytes.HasPrefix(data, []byte(npyMagic)):
+		array, err := parseNpy(data)
+		if err != nil {
+			return fmt.Errorf("%s: %w", a.path, err)
+		}
+		a.format = "npy"
+		return a.useMatrix(array)
+
+	case bytes.HasPrefix(data, []byte("PK\x03\x04")):
+		a.format = "npz"
+		return a.loadNpz(data)
+
+	case len(data) >= 4 && strings.HasPrefix(string(data[:4]), "Ix"):
+		index, err := parseFaissIndex(data)
+		if err != nil {
+			return fmt.Errorf("%s: %w", a.path, err)
+		}
+		a.format = "faiss-flat"
+		a.metric = index.metric
+		a.vectors = index.vectors
+		a.dtype = "<f4"
+		a.rows = index.rows
+		a.dim = index.dim
+		a.ids = index.ids
+		return nil
+
+	default:
+		return fmt.Errorf("%s is not a .npy, .npz or FAISS index file", a.path)
+	}
+}
+
+// loadNpz picks the vector and ID arrays out of an .npz archive
+func (a *NumpyAdapter) loadNpz(data []byte) error {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", a.path, err)
+	}
+
+	members := make(map[string]*zip.File)
+	var matrices []string
+	for _, f := range archive.File {
+		name := strings.TrimSuffix(f.Name, ".npy")
+		members[name] = f
+	}
+
+	arrays := make(map[string]*npyArray)
+	member := func(name string) (*npyArray, error) {
+		if array, ok := arrays[name]; ok {
+			return array, nil
+		}
+		array, err := readNpzMember(data, members[name])
+		if err != nil {
+			return nil, fmt.Errorf("%s[%s]: %w", a.path, name, err)
+		}
+		arrays[name] = array
+		return array, nil
+	}
+
+	vectorsName := a.config.Extra["array"]
+	if vectorsName == "" {
+		if _, ok := members["embeddings"]; ok {
+			vectorsName = "embeddings"
+		} else {
+			for name := range members {
+				array, err := member(name)
+				if err != nil {
+					return err
+				}
+				if len(array.shape) == 2 {
+					matrices = append(matrices, name)
+				}
+			}
+			if len(matrices) != 1 {
+				return fmt.Errorf("%s has %d 2-D arrays %v; set the 'array' extra setting", a.path, len(matrices), matrices)
+			}
+			vectorsName = matrices[0]
+		}
+	}
+	if members[vectorsName] == nil {
+		return fmt.Errorf("%s has no array named %q", a.path, vectorsName)
+	}
+
+	vectors, err := member(vectorsName)
+	if err != nil {
+		return err
+	}
+	if err := a.useMatrix(vectors); err != nil {
+		return fmt.Errorf("%s[%s]: %w", a.path, vectorsName, err)
+	}
+
+	idsName := a.config.Extra["ids_array"]
+	if idsName == "" {
+		idsName = "ids"
+	}
+	if members[idsName] == nil {
+		if a.config.Extra["ids_array"] != "" {
+			return fmt.Errorf("%s has no array named %q", a.path, idsName)
+		}
+		return nil
+	}
+
+	idArray, err := member(idsName)
+	if err != nil {
+		return err
+	}
+	a.ids, err = idArray.strings()
+	if err != nil {
+		return fmt.Errorf("%s[%s]: %w", a.path, idsName, err)
+	}
+
+	return nil
+}
+
+// useMatrix takes the vectors from a 2-D float array
+func (a *NumpyAdapter) useMatrix(array *npyArray) error {
+	if len(array.shape) != 2 {
+		return fmt.Errorf("expected a 2-D array, got shape %v", array.shape)
+	}
+	if array.descr != "<f4" && array.descr != "<f8" {
+		return fmt.Errorf("unsupported vector dtype %s, expected <f4 or <f8", array.descr)
+	}
+
+	a.vectors = array.data
+	a.dtype = array.descr
+	a.rows = array.shape[0]
+	a.dim = array.shape[1]
+	return nil
+}
+
+// openSidecar indexes the ID/metadata JSONL, if there is one
+func (a *NumpyAdapter) openSidecar() error {
+	path, explicit := a.config.Extra["sidecar"]
+	if !explicit {
+		path = strings.TrimSuffix(a.path, filepath.Ext(a.path)) + ".jsonl"
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) && !explicit {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open sidecar: %w", err)
+	}
+	a.sidecar = file
+
+	var ids []string
+	var offset int64
+	hasIDs := false
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 && len(bytes.TrimSpace(line)) > 0 {
+			var entry struct {
+				ID json.RawMessage `json:"id"`
+			}
+			if err := json.Unmarshal(line, &entry); err != nil {
+				return fmt.Errorf("sidecar line %d: %w", len(a.offsets)+1, err)
+			}
+			id := ""
+			if len(entry.ID) > 0 {
+				hasIDs = true
+				if err := json.Unmarshal(entry.ID, &id); err != nil {
+					// Numeric IDs are used as written
+					id = string(entry.ID)
+				}
+			}
+			ids = append(ids, id)
+			a.offsets = append(a.offsets, offset)
+		}
+		offset += int64(len(line))
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read sidecar: %w", err)
+		}
+	}
+	a.offsets = append(a.offsets, offset)
+
+	if len(ids) != a.rows {
+		return fmt.Errorf("sidecar %s has %d lines for %d vectors", path, len(ids), a.rows)
+	}
+	if hasIDs {
+		a.ids = ids
+	}
+
+	return nil
+}
+
+// readMetadata decodes sidecar metadata for rows [start, end)
+func (a *NumpyAdapter) readMetadata(start, end int) ([]map[string]interface{}, error) {
+	metadata := make([]map[string]interface{}, end-start)
+	if a.sidecar == nil {
+		return metadata, nil
+	}
+
+	chunk := make([]byte, a.offsets[end]-a.offsets[start])
+	if _, err := a.sidecar.ReadAt(chunk, a.offsets[start]); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to read sidecar: %w", err)
+	}
+
+	for row := start; row < end; row++ {
+		line := chunk[a.offsets[row]-a.offsets[start] : a.offsets[row+1]-a.offsets[start]]
+
+		var fields map[string]interface{}
+		if err := json.Unmarshal(line, &fields); err != nil {
+			return nil, fmt.Errorf("sidecar row %d: %w", row, err)
+		}
+
+		// Either a nested metadata object or the remaining top-level fields
+		if nested, ok := fields["metadata"].(map[string]interface{}); ok {
+			metadata[row-start] = nested
+			continue
+		}
+		delete(fields, "id")
+		if len(fields) > 0 {
+			metadata[row-start] = fields
+		}
+	}
+
+	return metadata, nil
+}
+
+// idOf returns the ID for a row
+func (a *NumpyAdapter) idOf(row int) string {
+	if a.ids == nil {
+		return strconv.Itoa(row)
+	}
+	return a.ids[row]
+}
+
+// rowOf finds the row for an ID
+func (a *NumpyAdapter) rowOf(id string) (int, bool) {
+	if a.ids == nil {
+		row, err := strconv.Atoi(id)
+		return row, err == nil && row >= 0 && row < a.rows
+	}
+
+	if a.positions == nil {
+		a.positions = make(map[string]int, len(a.ids))
+		for row, existing := range a.ids {
+			a.positions[existing] = row
+		}
+	}
+	row, ok := a.positions[id]
+	return row, ok
+}
+
+// vector decodes one row of the matrix
+func (a *NumpyAdapter) vector(row int) []float32 {
+	vector := make([]float32, a.dim)
+	size := dtypeSize(a.dtype)
+	base := row * a.dim * size
+	for i := range vector {
+		offset := base + i*size
+		if size == 8 {
+			vector[i] = float32(math.Float64frombits(binary.LittleEndian.Uint64(a.vectors[offset:])))
+		} else {
+			vector[i] = math.Float32frombits(binary.LittleEndian.Uint32(a.vectors[offset:]))
+		}
+	}
+	return vector
+}
+
+const npyMagic = "\x93NUMPY"
+
+var (
+	npyDescrPattern   = regexp.MustCompile(`'descr':\s*'([^']*)'`)
+	npyFortranPattern = regexp.MustCompile(`'fortran_order':\s*(True|False)`)
+	npyShapePattern   = regexp.MustCompile(`'shape':\s*\(([^)]*)\)`)
+)
+
+// npyArray is a parsed .npy array whose data points into the source bytes
+type npyArray struct {
+	descr string
+	shape []int
+	data  []byte
+}
+
+// parseNpy parses a .npy header without copying the array data
+func parseNpy(data []byte) (*npyArray, error) {
+	if len(data) < 10 || !bytes.HasPrefix(data, []byte(npyMagic)) {
+		return nil, fmt.Errorf("not a .npy file")
+	}
+
+	var headerLen, offset int
+	switch data[6] {
+	case 1:
+		headerLen = int(binary.LittleEndian.Uint16(data[8:]))
+		offset = 10
+	case 2, 3:
+		if len(data) < 12 {
+			return nil, fmt.Errorf("truncated .npy header")
+		}
+		headerLen = int(binary.LittleEndian.Uint32(data[8:]))
+		offset = 12
+	default:
+		return nil, fmt.Errorf("unsupported .npy version %d", data[6])
+	}
+	if offset+headerLen > len(data) {
+		return nil, fmt.Errorf("truncated .npy header")
+	}
+	header := string(data[offset : offset+headerLen])
+
+	array := &npyArray{}
+	if m := npyDescrPattern.FindStringSubmatch(header); m != nil {
+		array.descr = m[1]
+	} else {
+		return nil, fmt.Errorf("missing dtype in .npy header")
+	}
+
+	if m := npyFortranPattern.FindStringSubmatch(header); m != nil && m[1] == "True" {
+		return nil, fmt.Errorf("fortran-ordered arrays are not supported")
+	}
+
+	m := npyShapePattern.FindStringSubmatch(header)
+	if m == nil {
+		return nil, fmt.Errorf("missing shape in .npy header")
+	}
+	for _, part := range strings.Split(m[1], ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSuffix(part, "L"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid shape %q in .npy header", m[1])
+		}
+		array.shape = append(array.shape, n)
+	}
+
+	count := 1
+	for _, n := range array.shape {
+		count *= n
+	}
+	size := dtypeSize(array.descr)
+	if size == 0 {
+		return nil, fmt.Errorf("unsupported dtype %s", array.descr)
+	}
+
+	array.data = data[offset+headerLen:]
+	if len(array.data) < count*size {
+		return nil, fmt.Errorf("array data is truncated")
+	}
+	array.data = array.data[:count*size]
+
+	return array, nil
+}
+
+// strings converts a 1-D integer or unicode array to IDs
+func (a *npyArray) strings() ([]string, error) {
+	if len(a.shape) != 1 {
+		return nil, fmt.Errorf("expected a 1-D ID array, got shape %v", a.shape)
+	}
+
+	ids := make([]string, a.shape[0])
+	size := dtypeSize(a.descr)
+	for i := range ids {
+		item := a.data[i*size : (i+1)*size]
+		switch {
+		case a.descr == "<i8":
+			ids[i] = strconv.FormatInt(int64(binary.LittleEndian.Uint64(item)), 10)
+		case a.descr == "<u8":
+			ids[i] = strconv.FormatUint(binary.LittleEndian.Uint64(item), 10)
+		case a.descr == "<i4":
+			ids[i] = strconv.FormatInt(int64(int32(binary.LittleEndian.Uint32(item))), 10)
+		case strings.HasPrefix(a.descr, "<U"):
+			// Fixed-width UTF-32, padded with NULs
+			var sb strings.Builder
+			for j := 0; j+4 <= len(item); j += 4 {
+				r := rune(binary.LittleEndian.Uint32(item[j:]))
+				if r == 0 {
+					break
+				}
+				if !utf8.ValidRune(r) {
+					return nil, fmt.Errorf("invalid code point in ID %d", i)
+				}
+				sb.WriteRune(r)
+			}
+			ids[i] = sb.String()
+		default:
+			return nil, fmt.Errorf("unsupported ID dtype %s", a.descr)
+		}
+	}
+
+	return ids, nil
+}
+
+// dtypeSize returns the item size of a little-endian numpy dtype, or 0
+func dtypeSize(descr string) int {
+	switch descr {
+	case "<f4", "<i4":
+		return 4
+	case "<f8", "<i8", "<u8":
+		return 8
+	}
+	if strings.HasPrefix(descr, "<U") {
+		n, err := strconv.Atoi(descr[2:])
+		if err == nil && n > 0 {
+			return n * 4
+		}
+	}
+	return 0
+}
+
+// readNpzMember parses an .npz member. Stored members are used in place;
+// compressed ones are inflated into memory.
+func readNpzMember(data []byte, f *zip.File) (*npyArray, error) {
+	if f.Method == zip.Store {
+		offset, err := f.DataOffset()
+		if err != nil {
+			return nil, err
+		}
+		end := offset + int64(f.UncompressedSize64)
+		if end > int64(len(data)) {
+			return nil, fmt.Errorf("member is truncated")
+		}
+		return parseNpy(data[offset:end])
+	}
+
+	reader, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	inflated, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	return parseNpy(inflated)
+}
+
+// Ensure NumpyAdapter implements Database interface
+var _ Database = (*NumpyAdapter)(nil)
+
+// Ensure NumpyAdapter implements CapabilityReporter interface
+var _ CapabilityReporter = (*NumpyAdapter)(nil)
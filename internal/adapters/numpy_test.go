@@ -0,0 +1,203 @@
+package adapters
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// encodeNpy builds a version 1.0 .npy file
+func encodeNpy(descr string, shape []int, data []byte) []byte {
+	dims := make([]string, len(shape))
+	for i, n := range shape {
+		dims[i] = fmt.Sprint(n)
+	}
+	shapeText := strings.Join(dims, ", ")
+	if len(shape) == 1 {
+		shapeText += ","
+	}
+
+	header := fmt.Sprintf("{'descr': '%s', 'fortran_order': False, 'shape': (%s), }", descr, shapeText)
+	for (10+len(header)+1)%64 != 0 {
+		header += " "
+	}
+	header += "\n"
+
+	var buf bytes.Buffer
+	buf.WriteString(npyMagic)
+	buf.Write([]byte{1, 0})
+	binary.Write(&buf, binary.LittleEndian, uint16(len(header)))
+	buf.WriteString(header)
+	buf.Write(data)
+	return buf.Bytes()
+}
+
+func float32Matrix(rows, dim int) []byte {
+	var buf bytes.Buffer
+	for i := 0; i < rows; i++ {
+		for j := 0; j < dim; j++ {
+			binary.Write(&buf, binary.LittleEndian, float32(i)+float32(j)/10)
+		}
+	}
+	return buf.Bytes()
+}
+
+func connectNumpy(t *testing.T, path string, extra map[string]string) *NumpyAdapter {
+	adapter := &NumpyAdapter{}
+	if err := adapter.Connect(context.Background(), DBConfig{Type: "npy", URL: path, Extra: extra}); err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	t.Cleanup(func() { adapter.Close() })
+	return adapter
+}
+
+// TestNumpyAdapterNpy tests a .npy matrix with a sidecar JSONL
+func TestNumpyAdapterNpy(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "embeddings.npy")
+	os.WriteFile(path, encodeNpy("<f4", []int{5, 3}, float32Matrix(5, 3)), 0o644)
+
+	var sidecar strings.Builder
+	for i := 0; i < 5; i++ {
+		fmt.Fprintf(&sidecar, `{"id": "paper-%d", "title": "Paper %d"}`+"\n", i, i)
+	}
+	os.WriteFile(filepath.Join(dir, "embeddings.jsonl"), []byte(sidecar.String()), 0o644)
+
+	adapter := connectNumpy(t, path, nil)
+	ctx := context.Background()
+
+	seen := readAll(t, adapter, 2)
+	if len(seen) != 5 {
+		t.Fatalf("Expected 5 records, got %d", len(seen))
+	}
+
+	if seen[3].ID != "paper-3" || seen[3].Metadata["title"] != "Paper 3" || seen[3].Vector[1] != 3.1 {
+		t.Errorf("Unexpected record: %+v", seen[3])
+	}
+
+	if _, exists := seen[3].Metadata["id"]; exists {
+		t.Error("Expected id to be removed from metadata")
+	}
+
+	batch, err := adapter.GetBatch(ctx, "paper-1", 2)
+	if err != nil || len(batch) != 2 || batch[0].ID != "paper-2" {
+		t.Errorf("Expected resume at paper-2, got %+v (%v)", batch, err)
+	}
+
+	if err := adapter.UpsertBatch(ctx, seen); err == nil {
+		t.Error("Expected upsert to fail on a read-only adapter")
+	}
+
+	stats, err := adapter.GetStats(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get stats: %v", err)
+	}
+	if stats.TotalRecords != 5 || stats.Dimensions != 3 || stats.IndexType != "npy" {
+		t.Errorf("Expected 5 x 3 npy stats, got %+v", stats)
+	}
+
+	os.WriteFile(filepath.Join(dir, "embeddings.jsonl"), []byte(`{"id": "only-one"}`+"\n"), 0o644)
+	if err := (&NumpyAdapter{}).Connect(ctx, DBConfig{Type: "npy", URL: path}); err == nil {
+		t.Error("Expected error when sidecar and matrix disagree")
+	}
+
+	t.Log("✓ NumpyAdapter reads .npy matrices with sidecar IDs and metadata")
+}
+
+// TestNumpyAdapterNpz tests stored and compressed .npz members with an ID array
+func TestNumpyAdapterNpz(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "bundle.npz")
+
+	var ids bytes.Buffer
+	for _, id := range []string{"α", "beta", "γ"} {
+		item := make([]byte, 16)
+		for i, r := range []rune(id) {
+			binary.LittleEndian.PutUint32(item[i*4:], uint32(r))
+		}
+		ids.Write(item)
+	}
+
+	var f64 bytes.Buffer
+	for i := 0; i < 6; i++ {
+		binary.Write(&f64, binary.LittleEndian, float64(i)*0.5)
+	}
+
+	var archive bytes.Buffer
+	zw := zip.NewWriter(&archive)
+	stored, _ := zw.CreateHeader(&zip.FileHeader{Name: "vectors.npy", Method: zip.Store})
+	stored.Write(encodeNpy("<f8", []int{3, 2}, f64.Bytes()))
+	compressed, _ := zw.CreateHeader(&zip.FileHeader{Name: "ids.npy", Method: zip.Deflate})
+	compressed.Write(encodeNpy("<U4", []int{3}, ids.Bytes()))
+	zw.Close()
+	os.WriteFile(path, archive.Bytes(), 0o644)
+
+	adapter := connectNumpy(t, path, nil)
+	seen := readAll(t, adapter, 10)
+	if len(seen) != 3 || seen[0].ID != "α" || seen[2].ID != "γ" {
+		t.Fatalf("Unexpected records: %+v", seen)
+	}
+
+	if seen[2].Vector[1] != 2.5 {
+		t.Errorf("Expected float64 values to be converted, got %v", seen[2].Vector)
+	}
+
+	stats, _ := adapter.GetStats(context.Background())
+	if stats.IndexType != "npz" || stats.Details["dtype"] != "<f8" {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+
+	t.Log("✓ NumpyAdapter reads .npz archives with an ID array")
+}
+
+// TestNumpyAdapterFaiss tests an IndexIDMap wrapping an IndexFlatL2
+func TestNumpyAdapterFaiss(t *testing.T) {
+	header := func(buf *bytes.Buffer, fourcc string, dim int32, rows int64, metric int32) {
+		buf.WriteString(fourcc)
+		binary.Write(buf, binary.LittleEndian, dim)
+		binary.Write(buf, binary.LittleEndian, rows)
+		binary.Write(buf, binary.LittleEndian, int64(1<<20))
+		binary.Write(buf, binary.LittleEndian, int64(1<<20))
+		buf.WriteByte(1)
+		binary.Write(buf, binary.LittleEndian, metric)
+	}
+
+	var buf bytes.Buffer
+	header(&buf, "IxMp", 4, 3, 1)
+	header(&buf, "IxF2", 4, 3, 1)
+	binary.Write(&buf, binary.LittleEndian, uint64(12))
+	buf.Write(float32Matrix(3, 4))
+	binary.Write(&buf, binary.LittleEndian, uint64(3))
+	for _, id := range []int64{1001, 1002, 1003} {
+		binary.Write(&buf, binary.LittleEndian, id)
+	}
+
+	path := filepath.Join(t.TempDir(), "index.faiss")
+	os.WriteFile(path, buf.Bytes(), 0o644)
+
+	adapter := connectNumpy(t, path, nil)
+	seen := readAll(t, adapter, 2)
+	if len(seen) != 3 || seen[1].ID != "1002" || math.Abs(float64(seen[1].Vector[3]-1.3)) > 1e-6 {
+		t.Fatalf("Unexpected records: %+v", seen)
+	}
+
+	stats, _ := adapter.GetStats(context.Background())
+	if stats.IndexType != "faiss-flat" || stats.Details["metric"] != "l2" || stats.Dimensions != 4 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+
+	unsupported := filepath.Join(t.TempDir(), "ivf.faiss")
+	os.WriteFile(unsupported, []byte("IwFl\x00\x00\x00\x00"), 0o644)
+	if err := (&NumpyAdapter{}).Connect(context.Background(), DBConfig{Type: "npy", URL: unsupported}); err == nil {
+		t.Error("Expected error for an unsupported FAISS index type")
+	}
+
+	t.Log("✓ NumpyAdapter reads FAISS flat indexes and their ID maps")
+}
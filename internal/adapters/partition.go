@@ -0,0 +1,74 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+)
+
+// memoryPartition reads the records of a MemoryAdapter whose ID hashes to
+// one of count buckets
+type memoryPartition struct {
+	*MemoryAdapter
+	bucket, count uint32
+}
+
+// Partitions splits the IDs into n hash buckets with keys of the form
+// "bucket/n"
+func (a *MemoryAdapter) Partitions(ctx context.Context, n int) ([]string, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("partition count must be positive, got %d", n)
+	}
+
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("%d/%d", i, n)
+	}
+	return keys, nil
+}
+
+// WithPartition returns a handle reading the hash bucket named by key
+func (a *MemoryAdapter) WithPartition(key string) (Database, error) {
+	bucketPart, countPart, ok := strings.Cut(key, "/")
+	bucket, bucketErr := strconv.ParseUint(bucketPart, 10, 32)
+	count, countErr := strconv.ParseUint(countPart, 10, 32)
+	if !ok || bucketErr != nil || countErr != nil || bucket >= count {
+		return nil, fmt.Errorf("invalid memory partition %q: expected bucket/count", key)
+	}
+
+	return &memoryPartition{MemoryAdapter: a, bucket: uint32(bucket), count: uint32(count)}, nil
+}
+
+// GetBatch returns up to limit records of the partition with IDs greater
+// than afterID
+func (p *memoryPartition) GetBatch(ctx context.Context, afterID string, limit int) ([]Record, error) {
+	records := make([]Record, 0, limit)
+	for len(records) < limit {
+		batch, err := p.MemoryAdapter.GetBatch(ctx, afterID, limit)
+		if err != nil {
+			return nil, err
+		}
+		if len(batch) == 0 {
+			break
+		}
+		for _, r := range batch {
+			if p.contains(r.ID) && len(records) < limit {
+				records = append(records, r)
+			}
+		}
+		afterID = batch[len(batch)-1].ID
+	}
+	return records, nil
+}
+
+// contains reports whether id hashes to the partition's bucket
+func (p *memoryPartition) contains(id string) bool {
+	h := fnv.New32a()
+	h.Write([]byte(id))
+	return h.Sum32()%p.count == p.bucket
+}
+
+// Ensure MemoryAdapter implements Partitioned interface
+var _ Partitioned = (*MemoryAdapter)(nil)
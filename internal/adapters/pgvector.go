@@ -0,0 +1,778 @@
+package adapters
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	// Registers the "pgx" database/sql driver
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+// PgvectorAdapter implements Database interface for PostgreSQL tables
+// using the pgvector extension.
+//
+// The adapter talks to PostgreSQL through database/sql using the pgx driver;
+// Extra["driver"] selects another registered driver.
+// Table layout is configured through DBConfig.Extra:
+//
+//	table            table name, optionally schema-qualified (default: Index)
+//	id_column        keyset pagination column (default: "id")
+//	vector_column    vector(n) column (default: "embedding")
+//	metadata_column  JSONB column holding metadata
+//	metadata_columns comma-separated columns copied into metadata
+//	distance         cosine, l2 or inner_product (default: cosine)
+//
+// Partitions are id ranges of about equal row counts.
+type PgvectorAdapter struct {
+	config          DBConfig
+	db              *sql.DB
+	sourceURL       string
+	table           string
+	idColumn        string
+	idIsInt         bool
+	vectorColumn    string
+	metadataColumn  string
+	metadataColumns []string
+	distance        string
+
+	// Partition bounds: reads cover ids greater than after and at most
+	// until; empty bounds are open
+	after string
+	until string
+}
+
+// pgvectorDistances maps supported distance settings to Distance constants
+var pgvectorDistances = map[string]string{
+	"cosine":        DistanceCosine,
+	"l2":            DistanceEuclidean,
+	"inner_product": DistanceDot,
+}
+
+// pgvectorMaxParams is PostgreSQL's limit on bind parameters per statement
+const pgvectorMaxParams = 65535
+
+// Connect opens the PostgreSQL connection pool and resolves the table layout
+func (a *PgvectorAdapter) Connect(ctx context.Context, config DBConfig) error {
+	if config.Type != "pgvector" {
+		return fmt.Errorf("expected type 'pgvector', got '%s'", config.Type)
+	}
+
+	if err := a.configure(config); err != nil {
+		return err
+	}
+
+	driver := config.Extra["driver"]
+	if driver == "" {
+		driver = "pgx"
+	}
+
+	db, err := sql.Open(driver, config.URL)
+	if err != nil {
+		return fmt.Errorf("failed to open PostgreSQL connection: %w", err)
+	}
+
+	timeout := time.Duration(config.Timeout) * time.Second
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+
+	db.SetMaxOpenConns(5)
+	db.SetMaxIdleConns(5)
+	db.SetConnMaxIdleTime(90 * time.Second)
+	a.db = db
+
+	connectCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Validate connection
+	if err := a.ValidateConnection(connectCtx); err != nil {
+		db.Close()
+		return err
+	}
+
+	return a.loadIDType(connectCtx)
+}
+
+// configure reads table and column settings from DBConfig
+func (a *PgvectorAdapter) configure(config DBConfig) error {
+	a.config = config
+	a.sourceURL = redactDSN(config.URL)
+
+	a.table = config.Extra["table"]
+	if a.table == "" {
+		a.table = config.Index
+	}
+	if a.table == "" {
+		return fmt.Errorf("pgvector table must be set via index or extra 'table'")
+	}
+
+	a.idColumn = config.Extra["id_column"]
+	if a.idColumn == "" {
+		a.idColumn = "id"
+	}
+
+	a.vectorColumn = config.Extra["vector_column"]
+	if a.vectorColumn == "" {
+		a.vectorColumn = "embedding"
+	}
+
+	a.metadataColumn = config.Extra["metadata_column"]
+	a.metadataColumns = nil
+	for _, column := range strings.Split(config.Extra["metadata_columns"], ",") {
+		if column = strings.TrimSpace(column); column != "" {
+			a.metadataColumns = append(a.metadataColumns, column)
+		}
+	}
+
+	if a.metadataColumn != "" && len(a.metadataColumns) > 0 {
+		return fmt.Errorf("pgvector metadata_column and metadata_columns are mutually exclusive")
+	}
+
+	a.distance = config.Extra["distance"]
+	if a.distance == "" {
+		a.distance = "cosine"
+	}
+	if _, ok := pgvectorDistances[a.distance]; !ok {
+		return fmt.Errorf("unsupported pgvector distance: %s (supported: cosine, l2, inner_product)", a.distance)
+	}
+
+	return nil
+}
+
+// loadIDType checks whether the id column is an integer so cursors compare numerically
+func (a *PgvectorAdapter) loadIDType(ctx context.Context) error {
+	query := `SELECT format_type(atttypid, atttypmod) FROM pg_attribute WHERE attrelid = $1::regclass AND attname = $2`
+
+	var idType string
+	if err := a.db.QueryRowContext(ctx, query, a.table, a.idColumn).Scan(&idType); err != nil {
+		return fmt.Errorf("failed to read type of %s.%s: %w", a.table, a.idColumn, err)
+	}
+
+	switch idType {
+	case "smallint", "integer", "bigint":
+		a.idIsInt = true
+	default:
+		a.idIsInt = false
+	}
+
+	return nil
+}
+
+// Close closes the connection pool
+func (a *PgvectorAdapter) Close() error {
+	if a.db != nil {
+		return a.db.Close()
+	}
+	return nil
+}
+
+// GetBatch retrieves a batch of rows using keyset pagination on the id column
+func (a *PgvectorAdapter) GetBatch(ctx context.Context, afterID string, limit int) ([]Record, error) {
+	if afterID == "" {
+		afterID = a.after
+	}
+	query := a.selectQuery(afterID != "")
+
+	args := []interface{}{}
+	for _, bound := range []string{afterID, a.until} {
+		if bound == "" {
+			continue
+		}
+		id, err := a.idArg(bound)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, id)
+	}
+	args = append(args, limit)
+
+	rows, err := a.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pgvector table: %w", err)
+	}
+	defer rows.Close()
+
+	records := []Record{}
+	for rows.Next() {
+		record, err := a.scanRecord(rows)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read pgvector rows: %w", err)
+	}
+
+	return records, nil
+}
+
+// pgvectorFetchLimit is the number of rows looked up per query
+const pgvectorFetchLimit = 1000
+
+// FetchByIDs selects rows by id. IDs that are not valid for an integer id
+// column cannot exist and are reported missing.
+func (a *PgvectorAdapter) FetchByIDs(ctx context.Context, ids []string) ([]Record, []string, error) {
+	return fetchByIDs(ids, pgvectorFetchLimit, func(chunk []string) ([]Record, error) {
+		args := make([]interface{}, 0, len(chunk))
+		for _, id := range chunk {
+			if arg, err := a.idArg(id); err == nil {
+				args = append(args, arg)
+			}
+		}
+		if len(args) == 0 {
+			return nil, nil
+		}
+
+		rows, err := a.db.QueryContext(ctx, a.fetchQuery(len(args)), args...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query pgvector table: %w", err)
+		}
+		defer rows.Close()
+
+		records := []Record{}
+		for rows.Next() {
+			record, err := a.scanRecord(rows)
+			if err != nil {
+				return nil, err
+			}
+			records = append(records, record)
+		}
+
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read pgvector rows: %w", err)
+		}
+
+		return records, nil
+	})
+}
+
+// UpsertBatch inserts or updates rows with INSERT ... ON CONFLICT. A
+// statement cannot update a row twice, so only the last record of each ID
+// is written, and large batches are split to stay within PostgreSQL's
+// bind parameter limit.
+func (a *PgvectorAdapter) UpsertBatch(ctx context.Context, records []Record) error {
+	records = lastByID(records)
+
+	rowsPerStatement := pgvectorMaxParams / a.upsertColumnCount()
+	for start := 0; start < len(records); start += rowsPerStatement {
+		end := start + rowsPerStatement
+		if end > len(records) {
+			end = len(records)
+		}
+		if err := a.upsertRows(ctx, records[start:end]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// upsertRows writes records with one INSERT ... ON CONFLICT statement
+func (a *PgvectorAdapter) upsertRows(ctx context.Context, records []Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	query := a.upsertQuery(len(records))
+
+	args := make([]interface{}, 0, len(records)*a.upsertColumnCount())
+	for _, r := range records {
+		id, err := a.idArg(r.ID)
+		if err != nil {
+			return err
+		}
+		args = append(args, id, formatPgvector(r.Vector))
+
+		switch {
+		case a.metadataColumn != "":
+			metadata := r.Metadata
+			if metadata == nil {
+				metadata = map[string]interface{}{}
+			}
+			jsonData, err := json.Marshal(metadata)
+			if err != nil {
+				return fmt.Errorf("failed to marshal metadata for %s: %w", r.ID, err)
+			}
+			args = append(args, string(jsonData))
+		default:
+			for _, column := range a.metadataColumns {
+				args = append(args, r.Metadata[column])
+			}
+		}
+	}
+
+	if _, err := a.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to upsert to pgvector table: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteBatch deletes rows by id
+func (a *PgvectorAdapter) DeleteBatch(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		arg, err := a.idArg(id)
+		if err != nil {
+			return err
+		}
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = arg
+	}
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE %s IN (%s)",
+		quoteQualified(a.table), quoteIdent(a.idColumn), strings.Join(placeholders, ", "))
+
+	if _, err := a.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to delete from pgvector table: %w", err)
+	}
+
+	return nil
+}
+
+// ValidateConnection checks that PostgreSQL is reachable and pgvector is installed
+func (a *PgvectorAdapter) ValidateConnection(ctx context.Context) error {
+	if err := a.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("failed to connect to PostgreSQL: %w", err)
+	}
+
+	var installed bool
+	query := `SELECT EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'vector')`
+	if err := a.db.QueryRowContext(ctx, query).Scan(&installed); err != nil {
+		return fmt.Errorf("failed to check pgvector extension: %w", err)
+	}
+
+	if !installed {
+		return fmt.Errorf("pgvector extension is not installed")
+	}
+
+	return nil
+}
+
+// GetStats returns row count, vector dimension and index type
+func (a *PgvectorAdapter) GetStats(ctx context.Context) (*DBStats, error) {
+	var count int64
+	countQuery := fmt.Sprintf("SELECT count(*) FROM %s", quoteQualified(a.table))
+	if err := a.db.QueryRowContext(ctx, countQuery).Scan(&count); err != nil {
+		return nil, fmt.Errorf("failed to count pgvector rows: %w", err)
+	}
+
+	// vector(n) stores its dimension as the column type modifier
+	var dimensions int
+	dimQuery := `SELECT atttypmod FROM pg_attribute WHERE attrelid = $1::regclass AND attname = $2`
+	if err := a.db.QueryRowContext(ctx, dimQuery, a.table, a.vectorColumn).Scan(&dimensions); err != nil {
+		return nil, fmt.Errorf("failed to read vector dimension: %w", err)
+	}
+	if dimensions < 0 {
+		dimensions = 0 // Unconstrained vector column
+	}
+
+	indexType := "pgvector-flat"
+	indexQuery := `SELECT indexdef FROM pg_indexes WHERE schemaname || '.' || tablename = $1 OR tablename = $1`
+	rows, err := a.db.QueryContext(ctx, indexQuery, a.table)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pgvector indexes: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var indexDef string
+		if err := rows.Scan(&indexDef); err != nil {
+			return nil, fmt.Errorf("failed to scan index definition: %w", err)
+		}
+		if !strings.Contains(indexDef, a.vectorColumn) {
+			continue
+		}
+		switch {
+		case strings.Contains(indexDef, "USING hnsw"):
+			indexType = "pgvector-hnsw"
+		case strings.Contains(indexDef, "USING ivfflat"):
+			indexType = "pgvector-ivfflat"
+		}
+	}
+
+	return &DBStats{
+		TotalRecords: count,
+		Dimensions:   dimensions,
+		IndexType:    indexType,
+		Distance:     pgvectorDistances[a.distance],
+		MemoryUsage:  0, // Not tracked
+	}, nil
+}
+
+// GetSourceURL returns the PostgreSQL DSN with credentials removed
+func (a *PgvectorAdapter) GetSourceURL() string {
+	return a.sourceURL
+}
+
+// pgvectorCapabilities: metadata is stored as JSONB
+var pgvectorCapabilities = Capabilities{
+	NestedMetadata: true,
+	IDFormats:      []IDFormat{IDString},
+}
+
+// Capabilities returns what the table can store; integer id columns take
+// integer IDs only
+func (a *PgvectorAdapter) Capabilities() Capabilities {
+	caps := pgvectorCapabilities
+	if a.idIsInt {
+		caps.IDFormats = []IDFormat{IDInt}
+	}
+	return caps
+}
+
+// Partitions splits the table into at most n id ranges of about equal row
+// counts. Finding the bounds sorts every id once. The last range is open
+// above, so rows inserted during the migration are still read.
+func (a *PgvectorAdapter) Partitions(ctx context.Context, n int) ([]string, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("partition count must be positive, got %d", n)
+	}
+
+	rows, err := a.db.QueryContext(ctx, a.partitionQuery(), n)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute pgvector id ranges: %w", err)
+	}
+	defer rows.Close()
+
+	var bounds []string
+	for rows.Next() {
+		var bound string
+		if err := rows.Scan(&bound); err != nil {
+			return nil, fmt.Errorf("failed to scan id range bound: %w", err)
+		}
+		bounds = append(bounds, bound)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read id range bounds: %w", err)
+	}
+	if len(bounds) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]string, len(bounds))
+	after := ""
+	for i, bound := range bounds {
+		if i == len(bounds)-1 {
+			bound = ""
+		}
+		keys[i] = pgvectorRangeKey(after, bound)
+		after = bound
+	}
+	return keys, nil
+}
+
+// WithPartition returns an adapter sharing this connection pool that reads
+// the id range named by key
+func (a *PgvectorAdapter) WithPartition(key string) (Database, error) {
+	after, until, err := parsePgvectorRangeKey(key)
+	if err != nil {
+		return nil, err
+	}
+	for _, bound := range []string{after, until} {
+		if bound == "" {
+			continue
+		}
+		if _, err := a.idArg(bound); err != nil {
+			return nil, fmt.Errorf("invalid pgvector partition %q: %w", key, err)
+		}
+	}
+
+	scoped := *a
+	scoped.after, scoped.until = after, until
+	return &scoped, nil
+}
+
+// pgvectorRangeKey formats an id range as "after:until" with both bounds
+// query-escaped
+func pgvectorRangeKey(after, until string) string {
+	return url.QueryEscape(after) + ":" + url.QueryEscape(until)
+}
+
+// parsePgvectorRangeKey parses a key formatted by pgvectorRangeKey
+func parsePgvectorRangeKey(key string) (string, string, error) {
+	afterPart, untilPart, ok := strings.Cut(key, ":")
+	if !ok {
+		return "", "", fmt.Errorf("invalid pgvector partition %q: expected after:until", key)
+	}
+	after, err := url.QueryUnescape(afterPart)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid pgvector partition %q: %w", key, err)
+	}
+	until, err := url.QueryUnescape(untilPart)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid pgvector partition %q: %w", key, err)
+	}
+	return after, until, nil
+}
+
+// selectColumns returns the column list read by GetBatch
+func (a *PgvectorAdapter) selectColumns() []string {
+	columns := []string{
+		quoteIdent(a.idColumn) + "::text",
+		quoteIdent(a.vectorColumn) + "::text",
+	}
+
+	switch {
+	case a.metadataColumn != "":
+		columns = append(columns, quoteIdent(a.metadataColumn)+"::text")
+	default:
+		for _, column := range a.metadataColumns {
+			columns = append(columns, quoteIdent(column))
+		}
+	}
+
+	return columns
+}
+
+// selectQuery builds the keyset pagination query, bounded above by the
+// partition's upper bound if it has one
+func (a *PgvectorAdapter) selectQuery(hasCursor bool) string {
+	query := fmt.Sprintf("SELECT %s FROM %s", strings.Join(a.selectColumns(), ", "), quoteQualified(a.table))
+	id := quoteIdent(a.idColumn)
+
+	var conditions []string
+	if hasCursor {
+		conditions = append(conditions, fmt.Sprintf("%s > $%d", id, len(conditions)+1))
+	}
+	if a.until != "" {
+		conditions = append(conditions, fmt.Sprintf("%s <= $%d", id, len(conditions)+1))
+	}
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	return query + fmt.Sprintf(" ORDER BY %s LIMIT $%d", id, len(conditions)+1)
+}
+
+// partitionQuery builds the query returning the upper id of each of $1
+// equal-sized row ranges
+func (a *PgvectorAdapter) partitionQuery() string {
+	id := quoteIdent(a.idColumn)
+	return fmt.Sprintf("SELECT max(%s)::text FROM (SELECT %s, ntile($1) OVER (ORDER BY %s) AS part FROM %s) AS parts GROUP BY part ORDER BY part",
+		id, id, id, quoteQualified(a.table))
+}
+
+// fetchQuery builds a SELECT of idCount rows by id
+func (a *PgvectorAdapter) fetchQuery(idCount int) string {
+	placeholders := make([]string, idCount)
+	for i := range placeholders {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+	}
+
+	return fmt.Sprintf("SELECT %s FROM %s WHERE %s IN (%s)",
+		strings.Join(a.selectColumns(), ", "), quoteQualified(a.table), quoteIdent(a.idColumn), strings.Join(placeholders, ", "))
+}
+
+// upsertColumnCount returns the number of columns UpsertBatch writes per row
+func (a *PgvectorAdapter) upsertColumnCount() int {
+	if a.metadataColumn != "" {
+		return 3
+	}
+	return 2 + len(a.metadataColumns)
+}
+
+// upsertQuery builds a multi-row INSERT ... ON CONFLICT statement
+func (a *PgvectorAdapter) upsertQuery(rowCount int) string {
+	columns := []string{quoteIdent(a.idColumn), quoteIdent(a.vectorColumn)}
+	casts := []string{"", "::vector"}
+
+	switch {
+	case a.metadataColumn != "":
+		columns = append(columns, quoteIdent(a.metadataColumn))
+		casts = append(casts, "::jsonb")
+	default:
+		for _, column := range a.metadataColumns {
+			columns = append(columns, quoteIdent(column))
+			casts = append(casts, "")
+		}
+	}
+
+	values := make([]string, rowCount)
+	param := 1
+	for i := range values {
+		placeholders := make([]string, len(columns))
+		for j := range columns {
+			placeholders[j] = fmt.Sprintf("$%d%s", param, casts[j])
+			param++
+		}
+		values[i] = "(" + strings.Join(placeholders, ", ") + ")"
+	}
+
+	updates := make([]string, 0, len(columns)-1)
+	for _, column := range columns[1:] {
+		updates = append(updates, fmt.Sprintf("%s = EXCLUDED.%s", column, column))
+	}
+
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES %s ON CONFLICT (%s) DO UPDATE SET %s",
+		quoteQualified(a.table),
+		strings.Join(columns, ", "),
+		strings.Join(values, ", "),
+		quoteIdent(a.idColumn),
+		strings.Join(updates, ", "))
+}
+
+// lastByID returns records with only the last record of each ID, in the
+// order the IDs first appear
+func lastByID(records []Record) []Record {
+	index := make(map[string]int, len(records))
+	unique := make([]Record, 0, len(records))
+	for _, record := range records {
+		if i, ok := index[record.ID]; ok {
+			unique[i] = record
+			continue
+		}
+		index[record.ID] = len(unique)
+		unique = append(unique, record)
+	}
+	return unique
+}
+
+// scanRecord converts a result row into a Record
+func (a *PgvectorAdapter) scanRecord(rows *sql.Rows) (Record, error) {
+	var id, vector string
+	dest := []interface{}{&id, &vector}
+
+	var metadataJSON sql.NullString
+	columnValues := make([]interface{}, len(a.metadataColumns))
+	switch {
+	case a.metadataColumn != "":
+		dest = append(dest, &metadataJSON)
+	default:
+		for i := range columnValues {
+			dest = append(dest, &columnValues[i])
+		}
+	}
+
+	if err := rows.Scan(dest...); err != nil {
+		return Record{}, fmt.Errorf("failed to scan pgvector row: %w", err)
+	}
+
+	values, err := parsePgvector(vector)
+	if err != nil {
+		return Record{}, fmt.Errorf("failed to parse vector for %s: %w", id, err)
+	}
+
+	record := Record{
+		ID:       id,
+		Vector:   values,
+		Metadata: make(map[string]interface{}),
+	}
+
+	if metadataJSON.Valid && metadataJSON.String != "" {
+		if err := json.Unmarshal([]byte(metadataJSON.String), &record.Metadata); err != nil {
+			return Record{}, fmt.Errorf("failed to decode metadata for %s: %w", id, err)
+		}
+	}
+
+	for i, column := range a.metadataColumns {
+		switch v := columnValues[i].(type) {
+		case nil:
+			continue
+		case []byte:
+			record.Metadata[column] = string(v)
+		default:
+			record.Metadata[column] = v
+		}
+	}
+
+	return record, nil
+}
+
+// idArg converts a record ID into the query argument for the id column
+func (a *PgvectorAdapter) idArg(id string) (interface{}, error) {
+	if !a.idIsInt {
+		return id, nil
+	}
+	n, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid integer id %q: %w", id, err)
+	}
+	return n, nil
+}
+
+// formatPgvector renders a vector in pgvector's text format
+func formatPgvector(vector []float32) string {
+	parts := make([]string, len(vector))
+	for i, v := range vector {
+		parts[i] = strconv.FormatFloat(float64(v), 'g', -1, 32)
+	}
+	return "[" + strings.Join(parts, ",") + "]"
+}
+
+// parsePgvector parses pgvector's text format
+func parsePgvector(s string) ([]float32, error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "[") || !strings.HasSuffix(s, "]") {
+		return nil, fmt.Errorf("malformed vector %q", s)
+	}
+
+	body := strings.TrimSpace(s[1 : len(s)-1])
+	if body == "" {
+		return []float32{}, nil
+	}
+
+	parts := strings.Split(body, ",")
+	vector := make([]float32, len(parts))
+	for i, part := range parts {
+		f, err := strconv.ParseFloat(strings.TrimSpace(part), 32)
+		if err != nil {
+			return nil, fmt.Errorf("malformed vector element %q: %w", part, err)
+		}
+		vector[i] = float32(f)
+	}
+
+	return vector, nil
+}
+
+// quoteIdent quotes a PostgreSQL identifier
+func quoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// quoteQualified quotes a possibly schema-qualified identifier
+func quoteQualified(name string) string {
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		parts[i] = quoteIdent(part)
+	}
+	return strings.Join(parts, ".")
+}
+
+// redactDSN removes the password from a PostgreSQL URL for logging
+func redactDSN(dsn string) string {
+	scheme := strings.Index(dsn, "://")
+	at := strings.LastIndex(dsn, "@")
+	if scheme < 0 || at < scheme {
+		return dsn
+	}
+
+	userInfo := dsn[scheme+3 : at]
+	if colon := strings.Index(userInfo, ":"); colon >= 0 {
+		userInfo = userInfo[:colon] + ":****"
+	}
+
+	return dsn[:scheme+3] + userInfo + dsn[at:]
+}
+
+// Ensure PgvectorAdapter implements Database interface
+var _ Database = (*PgvectorAdapter)(nil)
+
+// Ensure PgvectorAdapter implements CapabilityReporter interface
+var _ CapabilityReporter = (*PgvectorAdapter)(nil)
+
+// Ensure PgvectorAdapter implements Partitioned interface
+var _ Partitioned = (*PgvectorAdapter)(nil)
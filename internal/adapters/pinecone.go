@@ -7,29 +7,81 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 )
 
 // PineconeAdapter implements Database interface for Pinecone
+//
+// The URL is either the control-plane API (the default), which describes
+// the index to find its data-plane host, or the index host itself.
+// Reads page through /vectors/list, which returns IDs only, and fetch the
+// values and metadata with /vectors/fetch. All data-plane calls address the
+// namespace from Extra["namespace"] (the default namespace if unset).
+// Extra["partition_prefixes"], a comma-separated list of ID prefixes that
+// together cover the namespace, splits reads into partitions.
 type PineconeAdapter struct {
-	config   DBConfig
+	config     DBConfig
 	httpClient *http.Client
-	baseURL    string
+	controlURL string // Control-plane API, or "" if the URL is the index host
+	dataURL    string
 	sourceURL  string
+	namespace  string
+	metric     string
+	prefix     string // ID prefix of the partition read, if any
+	
+	// GetBatch read state of each scan in progress, keyed by the last ID
+	// the scan returned
+	mu    sync.Mutex
+	scans map[string]pineconeListState
+}
+
+// pineconeListState is where a GetBatch scan continues: the IDs listed but
+// not yet returned, and the list token that follows them
+type pineconeListState struct {
+	pending []string
+	token   string
+	done    bool
 }
 
 // pineconeRecord represents Pinecone's record format
 type pineconeRecord struct {
-	ID       string                 `json:"id"`
-	Values   []float32              `json:"values"`
-	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	ID           string                 `json:"id"`
+	Values       []float32              `json:"values"`
+	SparseValues *SparseVector          `json:"sparseValues,omitempty"`
+	Metadata     map[string]interface{} `json:"metadata,omitempty"`
 }
 
 // pineconeFetchResponse represents Pinecone fetch response
 type pineconeFetchResponse struct {
-	Vectors []pineconeRecord `json:"vectors"`
+	Vectors map[string]pineconeRecord `json:"vectors"`
 }
 
+// pineconeListResponse represents a page of Pinecone list response
+type pineconeListResponse struct {
+	Vectors []struct {
+		ID string `json:"id"`
+	} `json:"vectors"`
+	Pagination *struct {
+		Next string `json:"next"`
+	} `json:"pagination"`
+}
+
+// pineconeControlURL is the control-plane API used to describe indexes
+var pineconeControlURL = "https://api.pinecone.io"
+
+const (
+	// pineconeListLimit is the largest page /vectors/list accepts
+	pineconeListLimit = 100
+	
+	// PineconeDefaultNamespace names the unnamed default namespace
+	PineconeDefaultNamespace = "__default__"
+)
+
 // Connect establishes connection to Pinecone
 func (a *PineconeAdapter) Connect(ctx context.Context, config DBConfig) error {
 	if config.Type != "pinecone" {
@@ -38,9 +90,14 @@ func (a *PineconeAdapter) Connect(ctx context.Context, config DBConfig) error {
 	
 	a.config = config
 	a.sourceURL = config.URL
+	a.namespace = pineconeNamespace(config.Extra["namespace"])
 	
-	// Pinecone API base URL
-	a.baseURL = "https://api.pinecone.io"
+	// Against the control plane the data-plane host comes from describing
+	// the index; any other URL is the index host
+	a.controlURL, a.dataURL = "", strings.TrimSuffix(config.URL, "/")
+	if a.dataURL == "" || a.dataURL == pineconeControlURL {
+		a.controlURL, a.dataURL = pineconeControlURL, ""
+	}
 	
 	// Create HTTP client with timeout
 	timeout := time.Duration(config.Timeout) * time.Second
@@ -69,54 +126,174 @@ func (a *PineconeAdapter) Close() error {
 	return nil
 }
 
-// GetBatch retrieves a batch of records from Pinecone
+// GetBatch retrieves a batch of records from Pinecone after the given ID.
+// A call continuing a scan, or retrying one of its batches, resumes from
+// the scan's list token; any other afterID rescans the namespace's ID
+// listing to find it. Scans may run concurrently.
 func (a *PineconeAdapter) GetBatch(ctx context.Context, afterID string, limit int) ([]Record, error) {
-	// Pinecone doesn't have native pagination, so we'll use list + fetch
-	// In production, this would use Pinecone's list endpoint with pagination
+	a.mu.Lock()
+	scan, ok := a.scans[afterID]
+	a.mu.Unlock()
+	if !ok {
+		var err error
+		if scan, err = a.seekList(ctx, afterID); err != nil {
+			return nil, err
+		}
+	}
 	
-	url := fmt.Sprintf("%s/vectors/list?index=%s&limit=%d", a.baseURL, a.config.Index, limit)
-	if afterID != "" {
-		url += fmt.Sprintf("&pagination_token=%s", afterID)
+	// The scan state is shared until the batch is read, so never append
+	// to its IDs in place
+	pending := scan.pending[:len(scan.pending):len(scan.pending)]
+	for len(pending) < limit && !scan.done {
+		ids, next, err := a.listIDs(ctx, scan.token, pineconeListLimit)
+		if err != nil {
+			return nil, err
+		}
+		pending = append(pending, ids...)
+		scan.token = next
+		scan.done = next == ""
 	}
 	
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	n := limit
+	if n > len(pending) {
+		n = len(pending)
+	}
+	ids := pending[:n]
+	records, err := a.fetchRecords(ctx, ids)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
 	
-	req.Header.Set("Api-Key", a.config.APIKey)
-	req.Header.Set("Content-Type", "application/json")
+	if n > 0 {
+		a.mu.Lock()
+		if a.scans == nil {
+			a.scans = make(map[string]pineconeListState)
+		}
+		delete(a.scans, afterID)
+		a.scans[ids[n-1]] = pineconeListState{pending: pending[n:], token: scan.token, done: scan.done}
+		a.mu.Unlock()
+	}
 	
-	resp, err := a.httpClient.Do(req)
+	return records, nil
+}
+
+// GetBatchCursor retrieves one list page of up to limit records (at most
+// 100) starting at the opaque pagination token cursor
+func (a *PineconeAdapter) GetBatchCursor(ctx context.Context, cursor string, limit int) ([]Record, string, error) {
+	if limit > pineconeListLimit {
+		limit = pineconeListLimit
+	}
+	
+	ids, next, err := a.listIDs(ctx, cursor, limit)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch from Pinecone: %w", err)
+		return nil, "", err
 	}
-	defer resp.Body.Close()
 	
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("Pinecone API error (%d): %s", resp.StatusCode, string(body))
+	records, err := a.fetchRecords(ctx, ids)
+	if err != nil {
+		return nil, "", err
+	}
+	
+	return records, next, nil
+}
+
+// FetchByIDs fetches records from the configured namespace with the fetch
+// endpoint, 100 IDs per request to keep URLs short
+func (a *PineconeAdapter) FetchByIDs(ctx context.Context, ids []string) ([]Record, []string, error) {
+	return fetchByIDs(ids, pineconeListLimit, func(chunk []string) ([]Record, error) {
+		return a.fetchRecords(ctx, chunk)
+	})
+}
+
+// seekList lists IDs until afterID and returns the scan state just after it
+func (a *PineconeAdapter) seekList(ctx context.Context, afterID string) (pineconeListState, error) {
+	var scan pineconeListState
+	if afterID == "" {
+		return scan, nil
+	}
+	
+	for {
+		ids, next, err := a.listIDs(ctx, scan.token, pineconeListLimit)
+		if err != nil {
+			return scan, err
+		}
+		scan.token = next
+		scan.done = next == ""
+		
+		for i, id := range ids {
+			if id == afterID {
+				scan.pending = ids[i+1:]
+				return scan, nil
+			}
+		}
+		
+		if scan.done {
+			return scan, fmt.Errorf("record %q not found in Pinecone namespace %q", afterID, a.namespace)
+		}
+	}
+}
+
+// listIDs returns one page of IDs and the token for the next page ("" at the end)
+func (a *PineconeAdapter) listIDs(ctx context.Context, token string, limit int) ([]string, string, error) {
+	query := url.Values{}
+	query.Set("namespace", a.namespace)
+	query.Set("limit", strconv.Itoa(limit))
+	if a.prefix != "" {
+		query.Set("prefix", a.prefix)
+	}
+	if token != "" {
+		query.Set("paginationToken", token)
 	}
 	
-	var listResp struct {
-		Vectors      []pineconeRecord `json:"vectors"`
-		Pagination   struct {
-			NextToken string `json:"next"`
-		} `json:"pagination"`
+	var listResp pineconeListResponse
+	if err := a.do(ctx, "GET", a.dataURL+"/vectors/list?"+query.Encode(), nil, &listResp); err != nil {
+		return nil, "", fmt.Errorf("failed to list vectors from Pinecone: %w", err)
 	}
 	
-	if err := json.NewDecoder(resp.Body).Decode(&listResp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	ids := make([]string, len(listResp.Vectors))
+	for i, v := range listResp.Vectors {
+		ids[i] = v.ID
+	}
+	
+	next := ""
+	if listResp.Pagination != nil {
+		next = listResp.Pagination.Next
+	}
+	
+	return ids, next, nil
+}
+
+// fetchRecords fetches values and metadata for ids, keeping their order.
+// IDs deleted since they were listed are skipped.
+func (a *PineconeAdapter) fetchRecords(ctx context.Context, ids []string) ([]Record, error) {
+	records := make([]Record, 0, len(ids))
+	if len(ids) == 0 {
+		return records, nil
+	}
+	
+	query := url.Values{}
+	query.Set("namespace", a.namespace)
+	for _, id := range ids {
+		query.Add("ids", id)
+	}
+	
+	var fetchResp pineconeFetchResponse
+	if err := a.do(ctx, "GET", a.dataURL+"/vectors/fetch?"+query.Encode(), nil, &fetchResp); err != nil {
+		return nil, fmt.Errorf("failed to fetch from Pinecone: %w", err)
 	}
 	
 	// Convert to our Record format
-	records := make([]Record, len(listResp.Vectors))
-	for i, v := range listResp.Vectors {
-		records[i] = Record{
+	for _, id := range ids {
+		v, ok := fetchResp.Vectors[id]
+		if !ok {
+			continue
+		}
+		records = append(records, Record{
 			ID:       v.ID,
 			Vector:   v.Values,
+			Sparse:   v.SparseValues,
 			Metadata: v.Metadata,
-		}
+		})
 	}
 	
 	return records, nil
@@ -124,15 +301,16 @@ func (a *PineconeAdapter) GetBatch(ctx context.Context, afterID string, limit in
 
 // UpsertBatch inserts or updates records in Pinecone
 func (a *PineconeAdapter) UpsertBatch(ctx context.Context, records []Record) error {
-	url := fmt.Sprintf("%s/vectors/upsert", a.baseURL)
+	url := fmt.Sprintf("%s/vectors/upsert", a.dataURL)
 	
 	// Convert to Pinecone format
 	pineconeRecords := make([]pineconeRecord, len(records))
 	for i, r := range records {
 		pineconeRecords[i] = pineconeRecord{
-			ID:       r.ID,
-			Values:   r.Vector,
-			Metadata: r.Metadata,
+			ID:           r.ID,
+			Values:       r.Vector,
+			SparseValues: r.Sparse,
+			Metadata:     r.Metadata,
 		}
 	}
 	
@@ -140,7 +318,8 @@ func (a *PineconeAdapter) UpsertBatch(ctx context.Context, records []Record) err
 		Vectors   []pineconeRecord `json:"vectors"`
 		Namespace string           `json:"namespace,omitempty"`
 	}{
-		Vectors: pineconeRecords,
+		Vectors:   pineconeRecords,
+		Namespace: a.namespace,
 	}
 	
 	jsonData, err := json.Marshal(payload)
@@ -163,8 +342,7 @@ func (a *PineconeAdapter) UpsertBatch(ctx context.Context, records []Record) err
 	defer resp.Body.Close()
 	
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("Pinecone API error (%d): %s", resp.StatusCode, string(body))
+		return apiError("Pinecone", resp)
 	}
 	
 	return nil
@@ -172,12 +350,14 @@ func (a *PineconeAdapter) UpsertBatch(ctx context.Context, records []Record) err
 
 // DeleteBatch deletes records from Pinecone by IDs
 func (a *PineconeAdapter) DeleteBatch(ctx context.Context, ids []string) error {
-	url := fmt.Sprintf("%s/vectors/delete", a.baseURL)
+	url := fmt.Sprintf("%s/vectors/delete", a.dataURL)
 	
 	payload := struct {
-		IDs []string `json:"ids"`
+		IDs       []string `json:"ids"`
+		Namespace string   `json:"namespace,omitempty"`
 	}{
-		IDs: ids,
+		IDs:       ids,
+		Namespace: a.namespace,
 	}
 	
 	jsonData, err := json.Marshal(payload)
@@ -200,17 +380,27 @@ func (a *PineconeAdapter) DeleteBatch(ctx context.Context, ids []string) error {
 	defer resp.Body.Close()
 	
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("Pinecone API error (%d): %s", resp.StatusCode, string(body))
+		return apiError("Pinecone", resp)
 	}
 	
 	return nil
 }
 
-// ValidateConnection checks if Pinecone is accessible
+// ValidateConnection checks if Pinecone is accessible. Against the control
+// plane it describes the index and takes its host; an index host is asked
+// for its stats instead.
 func (a *PineconeAdapter) ValidateConnection(ctx context.Context) error {
+	if a.controlURL == "" {
+		var indexStats pineconeIndexStats
+		if err := a.do(ctx, "POST", a.dataURL+"/describe_index_stats", struct{}{}, &indexStats); err != nil {
+			return fmt.Errorf("failed to connect to Pinecone index host: %w", err)
+		}
+		a.metric = indexStats.Metric
+		return nil
+	}
+	
 	// Simple health check - try to describe index
-	url := fmt.Sprintf("%s/indexes/%s", a.baseURL, a.config.Index)
+	url := fmt.Sprintf("%s/indexes/%s", a.controlURL, a.config.Index)
 	
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
@@ -229,48 +419,185 @@ func (a *PineconeAdapter) ValidateConnection(ctx context.Context) error {
 		return fmt.Errorf("Pinecone connection failed (status %d)", resp.StatusCode)
 	}
 	
+	var indexInfo struct {
+		Host   string `json:"host"`
+		Metric string `json:"metric"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&indexInfo); err != nil {
+		return fmt.Errorf("failed to decode index description: %w", err)
+	}
+	a.metric = indexInfo.Metric
+	
+	// Data-plane calls go to the index host, over the control plane's
+	// scheme
+	if indexInfo.Host == "" {
+		return fmt.Errorf("Pinecone index %q has no host", a.config.Index)
+	}
+	scheme, _, _ := strings.Cut(a.controlURL, "://")
+	a.dataURL = scheme + "://" + indexInfo.Host
+	
 	return nil
 }
 
-// GetStats returns Pinecone statistics
+// pineconeIndexStats represents Pinecone describe_index_stats response
+type pineconeIndexStats struct {
+	Namespaces map[string]struct {
+		VectorCount int64 `json:"vectorCount"`
+	} `json:"namespaces"`
+	Dimension        int    `json:"dimension"`
+	TotalVectorCount int64  `json:"totalVectorCount"`
+	Metric           string `json:"metric"`
+}
+
+// GetStats returns Pinecone statistics for the configured namespace
 func (a *PineconeAdapter) GetStats(ctx context.Context) (*DBStats, error) {
-	// Describe index to get stats
-	url := fmt.Sprintf("%s/indexes/%s", a.baseURL, a.config.Index)
+	var indexStats pineconeIndexStats
+	if err := a.do(ctx, "POST", a.dataURL+"/describe_index_stats", struct{}{}, &indexStats); err != nil {
+		return nil, fmt.Errorf("failed to get stats from Pinecone: %w", err)
+	}
 	
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create stats request: %w", err)
+	return &DBStats{
+		TotalRecords: indexStats.Namespaces[a.namespace].VectorCount,
+		Dimensions:   indexStats.Dimension,
+		IndexType:    "pinecone-serverless",
+		MemoryUsage:  0, // Not available via API
+		Distance:     pineconeDistanceNames[a.metric],
+		Details: map[string]interface{}{
+			"namespace":          a.namespace,
+			"namespaces":         len(indexStats.Namespaces),
+			"total_vector_count": indexStats.TotalVectorCount,
+		},
+	}, nil
+}
+
+// GetSchema infers the metadata schema of the configured namespace by
+// sampling its first records; Extra["schema_sample"] sets the sample size
+func (a *PineconeAdapter) GetSchema(ctx context.Context) (*Schema, error) {
+	return SampleSchema(ctx, a, schemaSampleSize(a.config))
+}
+
+// Search queries the configured namespace for the k nearest vectors.
+// Filter conditions become $eq clauses of a Pinecone metadata filter.
+func (a *PineconeAdapter) Search(ctx context.Context, vector []float32, k int, filter Filter) ([]SearchResult, error) {
+	if err := filter.validate(); err != nil {
+		return nil, err
 	}
 	
-	req.Header.Set("Api-Key", a.config.APIKey)
+	request := map[string]interface{}{
+		"vector":          vector,
+		"topK":            k,
+		"namespace":       a.namespace,
+		"includeValues":   false,
+		"includeMetadata": false,
+	}
+	if len(filter) > 0 {
+		conditions := make(map[string]interface{}, len(filter))
+		for field, value := range filter {
+			conditions[field] = map[string]interface{}{"$eq": value}
+		}
+		request["filter"] = conditions
+	}
 	
-	resp, err := a.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get stats from Pinecone: %w", err)
+	var queryResp struct {
+		Matches []struct {
+			ID    string  `json:"id"`
+			Score float32 `json:"score"`
+		} `json:"matches"`
+	}
+	if err := a.do(ctx, "POST", a.dataURL+"/query", request, &queryResp); err != nil {
+		return nil, fmt.Errorf("failed to query Pinecone: %w", err)
 	}
-	defer resp.Body.Close()
 	
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Pinecone API error (%d)", resp.StatusCode)
+	results := make([]SearchResult, len(queryResp.Matches))
+	for i, m := range queryResp.Matches {
+		results[i] = SearchResult{ID: m.ID, Score: m.Score}
+	}
+	return results, nil
+}
+
+// ListNamespaces returns the index's non-empty namespaces in name order,
+// with the default namespace as PineconeDefaultNamespace
+func (a *PineconeAdapter) ListNamespaces(ctx context.Context) ([]string, error) {
+	var indexStats pineconeIndexStats
+	if err := a.do(ctx, "POST", a.dataURL+"/describe_index_stats", struct{}{}, &indexStats); err != nil {
+		return nil, fmt.Errorf("failed to get stats from Pinecone: %w", err)
 	}
 	
-	var indexInfo struct {
-		Database struct {
-			TotalVectorCount int64 `json:"totalVectorCount"`
-		} `json:"database"`
-		Dimension int `json:"dimension"`
+	namespaces := make([]string, 0, len(indexStats.Namespaces))
+	for name, ns := range indexStats.Namespaces {
+		if ns.VectorCount == 0 {
+			continue
+		}
+		if name == "" {
+			name = PineconeDefaultNamespace
+		}
+		namespaces = append(namespaces, name)
 	}
+	sort.Strings(namespaces)
 	
-	if err := json.NewDecoder(resp.Body).Decode(&indexInfo); err != nil {
-		return nil, fmt.Errorf("failed to decode stats: %w", err)
+	return namespaces, nil
+}
+
+// WithNamespace returns an adapter sharing this connection that reads and
+// writes namespace
+func (a *PineconeAdapter) WithNamespace(namespace string) Database {
+	extra := make(map[string]string, len(a.config.Extra)+1)
+	for k, v := range a.config.Extra {
+		extra[k] = v
+	}
+	extra["namespace"] = namespace
+	
+	scoped := &PineconeAdapter{
+		config:     a.config,
+		httpClient: a.httpClient,
+		controlURL: a.controlURL,
+		dataURL:    a.dataURL,
+		sourceURL:  a.sourceURL,
+		namespace:  pineconeNamespace(namespace),
+		metric:     a.metric,
+		prefix:     a.prefix,
+	}
+	scoped.config.Extra = extra
+	return scoped
+}
+
+// Partitions returns the ID prefixes configured in
+// Extra["partition_prefixes"]; Pinecone cannot split a namespace by itself
+func (a *PineconeAdapter) Partitions(ctx context.Context, n int) ([]string, error) {
+	var prefixes []string
+	for _, prefix := range strings.Split(a.config.Extra["partition_prefixes"], ",") {
+		if prefix = strings.TrimSpace(prefix); prefix != "" {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	return prefixes, nil
+}
+
+// WithPartition returns an adapter sharing this connection that lists only
+// IDs starting with prefix
+func (a *PineconeAdapter) WithPartition(prefix string) (Database, error) {
+	if prefix == "" {
+		return nil, fmt.Errorf("Pinecone partition prefix must not be empty")
 	}
 	
-	return &DBStats{
-		TotalRecords: indexInfo.Database.TotalVectorCount,
-		Dimensions:   indexInfo.Dimension,
-		IndexType:    "pinecone-serverless",
-		MemoryUsage:  0, // Not available via API
-	}, nil
+	scoped := a.WithNamespace(a.namespace).(*PineconeAdapter)
+	scoped.prefix = prefix
+	return scoped, nil
+}
+
+// pineconeDistanceNames maps Pinecone metrics to Distance constants
+var pineconeDistanceNames = map[string]string{
+	"cosine":     DistanceCosine,
+	"dotproduct": DistanceDot,
+	"euclidean":  DistanceEuclidean,
+}
+
+// pineconeNamespace maps PineconeDefaultNamespace to the API's empty name
+func pineconeNamespace(name string) string {
+	if name == PineconeDefaultNamespace {
+		return ""
+	}
+	return name
 }
 
 // GetSourceURL returns the Pinecone source URL
@@ -278,5 +605,71 @@ func (a *PineconeAdapter) GetSourceURL() string {
 	return a.sourceURL
 }
 
+// pineconeCapabilities: metadata is flat and limited to 40 KB per record,
+// and upserts and fetches take at most 1000 records
+var pineconeCapabilities = Capabilities{
+	MaxMetadataBytes: 40960,
+	MaxBatchSize:     1000,
+	IDFormats:        []IDFormat{IDString},
+	SparseVector:     true,
+}
+
+// Capabilities returns what Pinecone can store
+func (a *PineconeAdapter) Capabilities() Capabilities {
+	return pineconeCapabilities
+}
+
+// do sends a JSON request to endpoint and decodes the response into out
+func (a *PineconeAdapter) do(ctx context.Context, method, endpoint string, body interface{}, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		jsonData, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reader = bytes.NewBuffer(jsonData)
+	}
+	
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	
+	req.Header.Set("Api-Key", a.config.APIKey)
+	req.Header.Set("Content-Type", "application/json")
+	
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	
+	if resp.StatusCode != http.StatusOK {
+		return apiError("Pinecone", resp)
+	}
+	
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	
+	return nil
+}
+
 // Ensure PineconeAdapter implements Database interface
 var _ Database = (*PineconeAdapter)(nil)
+
+// Ensure PineconeAdapter implements CursorReader and Namespaced interfaces
+var _ CursorReader = (*PineconeAdapter)(nil)
+var _ Namespaced = (*PineconeAdapter)(nil)
+
+// Ensure PineconeAdapter implements SchemaReader interface
+var _ SchemaReader = (*PineconeAdapter)(nil)
+
+// Ensure PineconeAdapter implements CapabilityReporter interface
+var _ CapabilityReporter = (*PineconeAdapter)(nil)
+
+// Ensure PineconeAdapter implements Searcher interface
+var _ Searcher = (*PineconeAdapter)(nil)
+
+// Ensure PineconeAdapter implements Partitioned interface
+var _ Partitioned = (*PineconeAdapter)(nil)
@@ -0,0 +1,363 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/AlphaTechini/vector-db-migration/internal/adapters/fakes"
+)
+
+func connectPinecone(t *testing.T, url, namespace string) *PineconeAdapter {
+	adapter := &PineconeAdapter{}
+	err := adapter.Connect(context.Background(), DBConfig{
+		Type:   "pinecone",
+		URL:    url,
+		APIKey: "secret",
+		Index:  "docs",
+		Extra:  map[string]string{"namespace": namespace},
+	})
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	t.Cleanup(func() { adapter.Close() })
+	return adapter
+}
+
+// usePineconeControlPlane makes url the control-plane API for the test
+func usePineconeControlPlane(t *testing.T, url string) {
+	saved := pineconeControlURL
+	pineconeControlURL = url
+	t.Cleanup(func() { pineconeControlURL = saved })
+}
+
+// seedPinecone stores n vectors named vec-000.. in a namespace
+func seedPinecone(fake *fakes.Pinecone, namespace string, n int) {
+	for i := 0; i < n; i++ {
+		fake.Seed(namespace, fakes.PineconeVector{
+			ID:       fmt.Sprintf("vec-%03d", i),
+			Values:   []float32{float32(i), 1, 0},
+			Metadata: map[string]interface{}{"n": i},
+		})
+	}
+}
+
+// TestPineconeAdapterWrites tests connect, upsert and delete against the fake
+func TestPineconeAdapterWrites(t *testing.T) {
+	fake := fakes.NewPinecone(t, "docs", 3)
+	fake.RequireAPIKey("secret")
+	adapter := connectPinecone(t, fake.URL, "")
+	ctx := context.Background()
+
+	records := []Record{
+		{ID: "a", Vector: []float32{1, 0, 0}, Metadata: map[string]interface{}{"lang": "en"}},
+		{ID: "b", Vector: []float32{0, 1, 0}},
+	}
+	if err := adapter.UpsertBatch(ctx, records); err != nil {
+		t.Fatalf("UpsertBatch failed: %v", err)
+	}
+
+	stored := fake.Vectors("")
+	if len(stored) != 2 || stored[0].Metadata["lang"] != "en" {
+		t.Fatalf("Unexpected stored vectors: %+v", stored)
+	}
+
+	if err := adapter.UpsertBatch(ctx, []Record{{ID: "c", Vector: []float32{1, 0}}}); err == nil {
+		t.Error("Expected error for wrong dimension, got nil")
+	}
+
+	if err := adapter.DeleteBatch(ctx, []string{"a"}); err != nil {
+		t.Fatalf("DeleteBatch failed: %v", err)
+	}
+	if stored := fake.Vectors(""); len(stored) != 1 || stored[0].ID != "b" {
+		t.Errorf("Expected only 'b' to remain, got %+v", stored)
+	}
+
+	usePineconeControlPlane(t, fake.URL)
+	missing := &PineconeAdapter{}
+	if err := missing.Connect(ctx, DBConfig{Type: "pinecone", URL: fake.URL, APIKey: "secret", Index: "other"}); err == nil {
+		t.Error("Expected error for unknown index, got nil")
+	}
+
+	t.Log("✓ PineconeAdapter writes through the data plane")
+}
+
+// TestPineconeAdapterHosts tests connecting through the control plane and
+// to an index host directly
+func TestPineconeAdapterHosts(t *testing.T) {
+	fake := fakes.NewPinecone(t, "docs", 3)
+	seedPinecone(fake, "", 3)
+	ctx := context.Background()
+
+	// An index host is validated on the data plane
+	direct := connectPinecone(t, fake.URL, "")
+	for _, exchange := range fake.Exchanges() {
+		if strings.HasPrefix(exchange.Path, "/indexes/") {
+			t.Errorf("Expected no control-plane call against an index host, got %s %s", exchange.Method, exchange.Path)
+		}
+	}
+	if stats, err := direct.GetStats(ctx); err != nil || stats.Distance != DistanceCosine {
+		t.Errorf("Expected the metric from the index host, got %+v (%v)", stats, err)
+	}
+
+	// The control plane describes the index to find its host
+	usePineconeControlPlane(t, fake.URL)
+	described := connectPinecone(t, "", "")
+	if described.dataURL != fake.URL {
+		t.Errorf("Expected data-plane calls to go to %s, got %s", fake.URL, described.dataURL)
+	}
+	if records := readAll(t, described, 2); len(records) != 3 {
+		t.Errorf("Expected 3 records through the described host, got %d", len(records))
+	}
+
+	t.Log("✓ PineconeAdapter tells control-plane URLs from index hosts")
+}
+
+// TestPineconeAdapterNamespace tests that every call addresses the namespace
+func TestPineconeAdapterNamespace(t *testing.T) {
+	fake := fakes.NewPinecone(t, "docs", 3)
+	seedPinecone(fake, "", 4)
+	seedPinecone(fake, "tenant-a", 2)
+	adapter := connectPinecone(t, fake.URL, "tenant-a")
+	ctx := context.Background()
+
+	stats, err := adapter.GetStats(ctx)
+	if err != nil {
+		t.Fatalf("GetStats failed: %v", err)
+	}
+	if stats.TotalRecords != 2 || stats.Dimensions != 3 || stats.Details["namespaces"] != 2 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+
+	if err := adapter.UpsertBatch(ctx, []Record{{ID: "new", Vector: []float32{1, 1, 1}}}); err != nil {
+		t.Fatalf("UpsertBatch failed: %v", err)
+	}
+	if err := adapter.DeleteBatch(ctx, []string{"vec-000"}); err != nil {
+		t.Fatalf("DeleteBatch failed: %v", err)
+	}
+
+	if got := fake.Vectors("tenant-a"); len(got) != 2 || got[0].ID != "new" || got[1].ID != "vec-001" {
+		t.Errorf("Unexpected tenant-a vectors: %+v", got)
+	}
+	if got := fake.Vectors(""); len(got) != 4 {
+		t.Errorf("Default namespace should be untouched, got %d vectors", len(got))
+	}
+
+	namespaces, err := adapter.ListNamespaces(ctx)
+	if err != nil {
+		t.Fatalf("ListNamespaces failed: %v", err)
+	}
+	if len(namespaces) != 2 || namespaces[0] != PineconeDefaultNamespace || namespaces[1] != "tenant-a" {
+		t.Errorf("Unexpected namespaces: %v", namespaces)
+	}
+
+	scoped := adapter.WithNamespace(PineconeDefaultNamespace)
+	if records := readAll(t, scoped, 3); len(records) != 4 {
+		t.Errorf("Expected 4 records in the default namespace, got %d", len(records))
+	}
+	if records := readAll(t, adapter, 3); len(records) != 2 {
+		t.Errorf("WithNamespace should not rescope the original adapter, got %d records", len(records))
+	}
+
+	t.Log("✓ PineconeAdapter scopes reads and writes to the namespace")
+}
+
+// TestPineconeAdapterPagination tests list + fetch paging by ID and by cursor
+func TestPineconeAdapterPagination(t *testing.T) {
+	fake := fakes.NewPinecone(t, "docs", 3)
+	seedPinecone(fake, "", 250)
+	adapter := connectPinecone(t, fake.URL, "")
+	ctx := context.Background()
+
+	// Cursor paging returns vectors and metadata and stops with an empty cursor
+	var cursorIDs []string
+	cursor := ""
+	for {
+		records, next, err := adapter.GetBatchCursor(ctx, cursor, 150)
+		if err != nil {
+			t.Fatalf("GetBatchCursor failed: %v", err)
+		}
+		if len(records) > 100 {
+			t.Fatalf("Expected pages capped at 100, got %d", len(records))
+		}
+		for _, r := range records {
+			if len(r.Vector) != 3 || r.Metadata["n"] == nil {
+				t.Fatalf("Record %s is missing values or metadata: %+v", r.ID, r)
+			}
+			cursorIDs = append(cursorIDs, r.ID)
+		}
+		if next == "" {
+			break
+		}
+		if next == records[len(records)-1].ID {
+			t.Fatal("Expected an opaque cursor, got a record ID")
+		}
+		cursor = next
+	}
+	if len(cursorIDs) != 250 || cursorIDs[249] != "vec-249" {
+		t.Errorf("Expected 250 records in order, got %d", len(cursorIDs))
+	}
+
+	// ID paging spans list pages and can restart from an arbitrary ID
+	records := readAll(t, adapter, 70)
+	if len(records) != 250 {
+		t.Errorf("Expected 250 records via GetBatch, got %d", len(records))
+	}
+
+	batch, err := adapter.GetBatch(ctx, "vec-199", 3)
+	if err != nil {
+		t.Fatalf("GetBatch after arbitrary ID failed: %v", err)
+	}
+	if len(batch) != 3 || batch[0].ID != "vec-200" {
+		t.Errorf("Expected vec-200.., got %+v", batch)
+	}
+
+	if _, err := adapter.GetBatch(ctx, "missing", 3); err == nil {
+		t.Error("Expected error for an unknown afterID, got nil")
+	}
+
+	// Interleaved scans, one of them resumed, each continue from their own
+	// list token
+	lists := func() int {
+		n := 0
+		for _, exchange := range fake.Exchanges() {
+			if exchange.Path == "/vectors/list" {
+				n++
+			}
+		}
+		return n
+	}
+	before := lists()
+	first, second := "", "vec-024"
+	for i := 0; i < 4; i++ {
+		a, err := adapter.GetBatch(ctx, first, 50)
+		if err != nil || len(a) != 50 || a[0].ID != fmt.Sprintf("vec-%03d", i*50) {
+			t.Fatalf("First scan failed at batch %d: %d records (%v)", i, len(a), err)
+		}
+		b, err := adapter.GetBatch(ctx, second, 50)
+		if err != nil || len(b) != 50 || b[0].ID != fmt.Sprintf("vec-%03d", i*50+25) {
+			t.Fatalf("Second scan failed at batch %d: %d records (%v)", i, len(b), err)
+		}
+		first, second = a[49].ID, b[49].ID
+	}
+	// Two pages for the first scan, three for the second, none listed twice
+	if n := lists() - before; n != 5 {
+		t.Errorf("Expected 5 list calls, got %d", n)
+	}
+
+	t.Log("✓ PineconeAdapter pages with list tokens and fetches values")
+}
+
+// TestPineconeAdapterSparse tests that hybrid records keep their sparse values
+func TestPineconeAdapterSparse(t *testing.T) {
+	fake := fakes.NewPinecone(t, "docs", 3)
+	adapter := connectPinecone(t, fake.URL, "hybrid")
+	ctx := context.Background()
+
+	sparse := &SparseVector{Indices: []uint32{10, 45, 16}, Values: []float32{0.5, 0.5, 0.2}}
+	records := []Record{
+		{ID: "a", Vector: []float32{1, 0, 0}, Sparse: sparse},
+		{ID: "b", Vector: []float32{0, 1, 0}},
+	}
+	if err := adapter.UpsertBatch(ctx, records); err != nil {
+		t.Fatalf("UpsertBatch failed: %v", err)
+	}
+	if stored := fake.Vectors("hybrid"); stored[0].SparseValues == nil || len(stored[0].SparseValues.Indices) != 3 {
+		t.Fatalf("Expected sparse values to be stored, got %+v", stored[0])
+	}
+
+	got, err := adapter.GetBatch(ctx, "", 10)
+	if err != nil {
+		t.Fatalf("GetBatch failed: %v", err)
+	}
+	if len(got) != 2 || !reflect.DeepEqual(got[0].Sparse, sparse) || got[1].Sparse != nil {
+		t.Errorf("Sparse values did not round-trip: %+v", got)
+	}
+
+	t.Log("✓ PineconeAdapter round-trips sparse values")
+}
+
+// TestPineconeAdapterSearch tests nearest-neighbour queries with a filter
+func TestPineconeAdapterSearch(t *testing.T) {
+	fake := fakes.NewPinecone(t, "docs", 3)
+	seedPinecone(fake, "", 5)
+	fake.Seed("", fakes.PineconeVector{ID: "near", Values: []float32{1, 0, 0}, Metadata: map[string]interface{}{"n": 9}})
+	adapter := connectPinecone(t, fake.URL, "")
+	ctx := context.Background()
+
+	results, err := adapter.Search(ctx, []float32{1, 0, 0}, 3, nil)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 3 || results[0].ID != "near" || results[1].ID != "vec-004" {
+		t.Fatalf("Unexpected results: %+v", results)
+	}
+	if results[0].Score < results[1].Score {
+		t.Errorf("Expected results closest first, got %+v", results)
+	}
+
+	filtered, err := adapter.Search(ctx, []float32{1, 0, 0}, 3, Filter{"n": 2})
+	if err != nil {
+		t.Fatalf("Filtered search failed: %v", err)
+	}
+	if len(filtered) != 1 || filtered[0].ID != "vec-002" {
+		t.Errorf("Expected only vec-002, got %+v", filtered)
+	}
+
+	if _, err := adapter.Search(ctx, []float32{1, 0, 0}, 3, Filter{"n": []int{1}}); err == nil {
+		t.Error("Expected error for a list filter value, got nil")
+	}
+
+	t.Log("✓ PineconeAdapter queries nearest neighbours")
+}
+
+// TestPineconeAdapterPartitions tests reading by configured ID prefixes
+func TestPineconeAdapterPartitions(t *testing.T) {
+	fake := fakes.NewPinecone(t, "docs", 3)
+	seedPinecone(fake, "tenant-a", 120)
+
+	adapter := &PineconeAdapter{}
+	err := adapter.Connect(context.Background(), DBConfig{
+		Type:  "pinecone",
+		URL:   fake.URL,
+		Index: "docs",
+		Extra: map[string]string{"namespace": "tenant-a", "partition_prefixes": "vec-0, vec-1"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	ctx := context.Background()
+
+	keys, err := adapter.Partitions(ctx, 4)
+	if err != nil {
+		t.Fatalf("Partitions failed: %v", err)
+	}
+	if !reflect.DeepEqual(keys, []string{"vec-0", "vec-1"}) {
+		t.Fatalf("Partitions = %v, want [vec-0 vec-1]", keys)
+	}
+
+	counts := map[string]int{}
+	for _, key := range keys {
+		partition, err := adapter.WithPartition(key)
+		if err != nil {
+			t.Fatalf("WithPartition(%q) failed: %v", key, err)
+		}
+		for _, r := range readAll(t, partition, 30) {
+			if r.ID[:5] != key {
+				t.Errorf("Partition %q read %s", key, r.ID)
+			}
+			counts[key]++
+		}
+	}
+	if counts["vec-0"] != 100 || counts["vec-1"] != 20 {
+		t.Errorf("Expected 100 and 20 records, got %v", counts)
+	}
+
+	if keys, _ := connectPinecone(t, fake.URL, "tenant-a").Partitions(ctx, 4); len(keys) != 0 {
+		t.Errorf("Expected no partitions without prefixes, got %v", keys)
+	}
+
+	t.Log("✓ PineconeAdapter partitions namespaces by ID prefix")
+}
@@ -6,41 +6,140 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 
 // QdrantAdapter implements Database interface for Qdrant
+//
+// Point IDs are unsigned integers or UUIDs; Record.ID holds the decimal
+// integer or the UUID string. Unnamed, named dense and named sparse
+// vectors map to Record.Vector, NamedVectors and SparseVectors.
 type QdrantAdapter struct {
 	config     DBConfig
 	httpClient *http.Client
 	baseURL    string
 	sourceURL  string
+	shardKey   string // shard key of the partition read, if any
 }
 
-// qdrantPoint represents Qdrant's point format
+// qdrantPoint represents Qdrant's point format. Vector is a bare dense
+// vector or a map of named dense and sparse vectors.
 type qdrantPoint struct {
-	ID      string                 `json:"id"`
-	Vector  []float32              `json:"vector"`
+	ID      qdrantID               `json:"id"`
+	Vector  json.RawMessage        `json:"vector,omitempty"`
 	Payload map[string]interface{} `json:"payload,omitempty"`
 }
 
+// qdrantID is a point ID, sent as a JSON number when it is an unsigned
+// integer and as a string otherwise
+type qdrantID string
+
+// MarshalJSON encodes integer IDs as numbers
+func (id qdrantID) MarshalJSON() ([]byte, error) {
+	if _, err := strconv.ParseUint(string(id), 10, 64); err == nil {
+		return []byte(id), nil
+	}
+	return json.Marshal(string(id))
+}
+
+// UnmarshalJSON accepts both number and string IDs
+func (id *qdrantID) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		*id = qdrantID(s)
+		return nil
+	}
+	
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("invalid Qdrant point ID %s: %w", data, err)
+	}
+	*id = qdrantID(n.String())
+	return nil
+}
+
+var qdrantUUIDPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-?[0-9a-fA-F]{4}-?[0-9a-fA-F]{4}-?[0-9a-fA-F]{4}-?[0-9a-fA-F]{12}$`)
+
+// validateQdrantID rejects IDs Qdrant would refuse before sending them
+func validateQdrantID(id string) error {
+	if qdrantCapabilities.AcceptsID(id) {
+		return nil
+	}
+	return fmt.Errorf("invalid Qdrant point ID %q: must be an unsigned integer or a UUID", id)
+}
+
+// qdrantScrollResponse represents a page of Qdrant scroll response
+type qdrantScrollResponse struct {
+	Result struct {
+		Points         []qdrantPoint `json:"points"`
+		NextPageOffset *qdrantID     `json:"next_page_offset"`
+	} `json:"result"`
+	Status string `json:"status"`
+}
+
+// qdrantCollectionInfo represents Qdrant collection info response
+type qdrantCollectionInfo struct {
+	Result struct {
+		Status       string `json:"status"`
+		VectorsCount int64  `json:"vectors_count"`
+		PointsCount  int64  `json:"points_count"`
+		Config       struct {
+			Params struct {
+				Vectors       json.RawMessage            `json:"vectors"`
+				SparseVectors map[string]json.RawMessage `json:"sparse_vectors"`
+			} `json:"params"`
+			HNSWConfig struct {
+				M           int `json:"m"`
+				EfConstruct int `json:"ef_construct"`
+			} `json:"hnsw_config"`
+		} `json:"config"`
+	} `json:"result"`
+	Status string `json:"status"`
+}
+
 // qdrantUpsertRequest represents Qdrant upsert request
 type qdrantUpsertRequest struct {
 	Collection string         `json:"collection"`
 	Points     []qdrantPoint  `json:"points"`
 }
 
-// qdrantSearchRequest represents Qdrant search request
+// qdrantSearchRequest represents Qdrant search request. Vector is the
+// bare query vector, or {"name": ..., "vector": [...]} for a named vector.
 type qdrantSearchRequest struct {
-	Vector []float32 `json:"vector"`
-	Limit  int       `json:"limit"`
-	Offset string    `json:"offset,omitempty"`
+	Vector      interface{}            `json:"vector"`
+	Limit       int                    `json:"limit"`
+	Filter      map[string]interface{} `json:"filter,omitempty"`
+	WithPayload bool                   `json:"with_payload"`
 }
 
 // qdrantSearchResponse represents Qdrant search response
 type qdrantSearchResponse struct {
-	Result []qdrantPoint `json:"result"`
+	Result []struct {
+		ID    qdrantID `json:"id"`
+		Score float32  `json:"score"`
+	} `json:"result"`
+}
+
+// qdrantClusterInfo represents Qdrant collection cluster info response.
+// Shard keys, like point IDs, are unsigned integers or strings.
+type qdrantClusterInfo struct {
+	Result struct {
+		LocalShards []struct {
+			ShardKey *qdrantID `json:"shard_key"`
+		} `json:"local_shards"`
+		RemoteShards []struct {
+			ShardKey *qdrantID `json:"shard_key"`
+		} `json:"remote_shards"`
+	} `json:"result"`
 }
 
 // Connect establishes connection to Qdrant
@@ -80,68 +179,232 @@ func (a *QdrantAdapter) Close() error {
 	return nil
 }
 
-// GetBatch retrieves a batch of records from Qdrant
+// GetBatch retrieves a batch of records from Qdrant after the given ID.
+// Scroll offsets are inclusive, so the page starting at afterID is read
+// one record longer and afterID itself dropped.
 func (a *QdrantAdapter) GetBatch(ctx context.Context, afterID string, limit int) ([]Record, error) {
-	url := fmt.Sprintf("%s/collections/%s/points/scroll", a.baseURL, a.config.Index)
+	if afterID == "" {
+		records, _, err := a.scroll(ctx, "", limit)
+		return records, err
+	}
+	
+	records, _, err := a.scroll(ctx, afterID, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	
+	if len(records) > 0 && records[0].ID == afterID {
+		records = records[1:]
+	}
+	if len(records) > limit {
+		records = records[:limit]
+	}
+	
+	return records, nil
+}
+
+// GetBatchCursor retrieves up to limit records starting at cursor, the
+// next_page_offset of the previous page
+func (a *QdrantAdapter) GetBatchCursor(ctx context.Context, cursor string, limit int) ([]Record, string, error) {
+	return a.scroll(ctx, cursor, limit)
+}
+
+// qdrantFetchLimit is the number of points retrieved per request
+const qdrantFetchLimit = 1000
+
+// FetchByIDs retrieves points by ID. IDs that are neither unsigned
+// integers nor UUIDs cannot name a point and are reported missing.
+func (a *QdrantAdapter) FetchByIDs(ctx context.Context, ids []string) ([]Record, []string, error) {
+	return fetchByIDs(ids, qdrantFetchLimit, func(chunk []string) ([]Record, error) {
+		return a.retrieve(ctx, chunk)
+	})
+}
+
+// retrieve reads the points with the given IDs. Qdrant answers with UUIDs
+// in canonical form, so returned IDs are mapped back to the requested ones.
+func (a *QdrantAdapter) retrieve(ctx context.Context, ids []string) ([]Record, error) {
+	url := fmt.Sprintf("%s/collections/%s/points", a.baseURL, a.config.Index)
+	
+	requested := make(map[string]string, len(ids))
+	points := make([]qdrantID, 0, len(ids))
+	for _, id := range ids {
+		if validateQdrantID(id) != nil {
+			continue
+		}
+		requested[canonicalQdrantID(id)] = id
+		points = append(points, qdrantID(id))
+	}
+	if len(points) == 0 {
+		return nil, nil
+	}
 	
 	request := struct {
-		Limit  int    `json:"limit"`
-		Offset string `json:"offset,omitempty"`
-		WithPayload bool `json:"with_payload"`
-		WithVector bool `json:"with_vector"`
+		IDs         []qdrantID `json:"ids"`
+		WithPayload bool       `json:"with_payload"`
+		WithVector  bool       `json:"with_vector"`
 	}{
-		Limit:       limit,
-		Offset:      afterID,
+		IDs:         points,
 		WithPayload: true,
 		WithVector:  true,
 	}
 	
-	jsonData, err := json.Marshal(request)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	var retrieveResp struct {
+		Result []qdrantPoint `json:"result"`
 	}
-	
-	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+	if err := a.do(ctx, "POST", url, request, &retrieveResp); err != nil {
+		return nil, fmt.Errorf("failed to retrieve points from Qdrant: %w", err)
 	}
 	
-	req.Header.Set("Content-Type", "application/json")
-	
-	resp, err := a.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to scroll Qdrant: %w", err)
+	records := make([]Record, len(retrieveResp.Result))
+	for i, p := range retrieveResp.Result {
+		records[i] = Record{
+			ID:       requested[canonicalQdrantID(string(p.ID))],
+			Metadata: p.Payload,
+		}
+		if err := decodeQdrantVectors(p.Vector, &records[i]); err != nil {
+			return nil, fmt.Errorf("failed to decode vectors of point %s: %w", p.ID, err)
+		}
 	}
-	defer resp.Body.Close()
 	
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("Qdrant API error (%d): %s", resp.StatusCode, string(body))
+	return records, nil
+}
+
+// canonicalQdrantID returns the form Qdrant reports an ID in: the integer
+// as is, UUIDs lowercase and hyphenated
+func canonicalQdrantID(id string) string {
+	if _, err := strconv.ParseUint(id, 10, 64); err == nil {
+		return id
 	}
+	hex := strings.ToLower(strings.ReplaceAll(id, "-", ""))
+	if len(hex) != 32 {
+		return id
+	}
+	return hex[0:8] + "-" + hex[8:12] + "-" + hex[12:16] + "-" + hex[16:20] + "-" + hex[20:]
+}
+
+// scroll returns up to limit records starting at offset (the first point
+// if empty) and the ID the next page starts at ("" at the end)
+func (a *QdrantAdapter) scroll(ctx context.Context, offset string, limit int) ([]Record, string, error) {
+	url := fmt.Sprintf("%s/collections/%s/points/scroll", a.baseURL, a.config.Index)
 	
-	var scrollResp struct {
-		Result struct {
-			Points []qdrantPoint `json:"points"`
-			NextPageOffset string `json:"next_page_offset"`
-		} `json:"result"`
-		Status string `json:"status"`
+	request := struct {
+		Limit       int       `json:"limit"`
+		Offset      *qdrantID `json:"offset,omitempty"`
+		ShardKey    *qdrantID `json:"shard_key,omitempty"`
+		WithPayload bool      `json:"with_payload"`
+		WithVector  bool      `json:"with_vector"`
+	}{
+		Limit:       limit,
+		WithPayload: true,
+		WithVector:  true,
+	}
+	if offset != "" {
+		id := qdrantID(offset)
+		request.Offset = &id
+	}
+	if a.shardKey != "" {
+		key := qdrantID(a.shardKey)
+		request.ShardKey = &key
 	}
 	
-	if err := json.NewDecoder(resp.Body).Decode(&scrollResp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	var scrollResp qdrantScrollResponse
+	if err := a.do(ctx, "POST", url, request, &scrollResp); err != nil {
+		return nil, "", fmt.Errorf("failed to scroll Qdrant: %w", err)
 	}
 	
 	// Convert to our Record format
 	records := make([]Record, len(scrollResp.Result.Points))
 	for i, p := range scrollResp.Result.Points {
 		records[i] = Record{
-			ID:       p.ID,
-			Vector:   p.Vector,
+			ID:       string(p.ID),
 			Metadata: p.Payload,
 		}
+		if err := decodeQdrantVectors(p.Vector, &records[i]); err != nil {
+			return nil, "", fmt.Errorf("failed to decode vectors of point %s: %w", p.ID, err)
+		}
 	}
 	
-	return records, nil
+	next := ""
+	if scrollResp.Result.NextPageOffset != nil {
+		next = string(*scrollResp.Result.NextPageOffset)
+	}
+	
+	return records, next, nil
+}
+
+// decodeQdrantVectors fills a record's vectors from a point's vector field.
+// The unnamed vector comes back bare, or under "" when the collection also
+// has sparse vectors.
+func decodeQdrantVectors(raw json.RawMessage, record *Record) error {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	if raw[0] == '[' {
+		return json.Unmarshal(raw, &record.Vector)
+	}
+	
+	var named map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &named); err != nil {
+		return err
+	}
+	
+	for name, value := range named {
+		value = bytes.TrimSpace(value)
+		if len(value) > 0 && value[0] == '{' {
+			var sparse SparseVector
+			if err := json.Unmarshal(value, &sparse); err != nil {
+				return fmt.Errorf("sparse vector %q: %w", name, err)
+			}
+			if record.SparseVectors == nil {
+				record.SparseVectors = make(map[string]SparseVector)
+			}
+			record.SparseVectors[name] = sparse
+			continue
+		}
+		
+		var dense []float32
+		if err := json.Unmarshal(value, &dense); err != nil {
+			return fmt.Errorf("vector %q: %w", name, err)
+		}
+		if name == "" {
+			record.Vector = dense
+			continue
+		}
+		if record.NamedVectors == nil {
+			record.NamedVectors = make(map[string][]float32)
+		}
+		record.NamedVectors[name] = dense
+	}
+	
+	return nil
+}
+
+// encodeQdrantVectors builds a point's vector field: the bare unnamed
+// vector, or a map when the record has named or sparse vectors. Qdrant
+// sparse vectors are always named, so an unnamed one is an error.
+func encodeQdrantVectors(r Record) (json.RawMessage, error) {
+	if r.Sparse != nil {
+		return nil, fmt.Errorf("Qdrant sparse vectors must be named; map the unnamed sparse vector to a name")
+	}
+	if len(r.NamedVectors) == 0 && len(r.SparseVectors) == 0 {
+		if r.Vector == nil {
+			return nil, nil
+		}
+		return json.Marshal(r.Vector)
+	}
+	
+	named := make(map[string]interface{}, len(r.NamedVectors)+len(r.SparseVectors)+1)
+	if r.Vector != nil {
+		named[""] = r.Vector
+	}
+	for name, v := range r.NamedVectors {
+		named[name] = v
+	}
+	for name, v := range r.SparseVectors {
+		named[name] = v
+	}
+	return json.Marshal(named)
 }
 
 // UpsertBatch inserts or updates records in Qdrant
@@ -151,9 +414,16 @@ func (a *QdrantAdapter) UpsertBatch(ctx context.Context, records []Record) error
 	// Convert to Qdrant format
 	points := make([]qdrantPoint, len(records))
 	for i, r := range records {
+		if err := validateQdrantID(r.ID); err != nil {
+			return err
+		}
+		vector, err := encodeQdrantVectors(r)
+		if err != nil {
+			return fmt.Errorf("failed to encode vectors of %s: %w", r.ID, err)
+		}
 		points[i] = qdrantPoint{
-			ID:      r.ID,
-			Vector:  r.Vector,
+			ID:      qdrantID(r.ID),
+			Vector:  vector,
 			Payload: r.Metadata,
 		}
 	}
@@ -173,7 +443,7 @@ func (a *QdrantAdapter) UpsertBatch(ctx context.Context, records []Record) error
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 	
-	req.Header.Set("Content-Type", "application/json")
+	a.setHeaders(req)
 	
 	resp, err := a.httpClient.Do(req)
 	if err != nil {
@@ -182,8 +452,7 @@ func (a *QdrantAdapter) UpsertBatch(ctx context.Context, records []Record) error
 	defer resp.Body.Close()
 	
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("Qdrant API error (%d): %s", resp.StatusCode, string(body))
+		return apiError("Qdrant", resp)
 	}
 	
 	return nil
@@ -193,10 +462,18 @@ func (a *QdrantAdapter) UpsertBatch(ctx context.Context, records []Record) error
 func (a *QdrantAdapter) DeleteBatch(ctx context.Context, ids []string) error {
 	url := fmt.Sprintf("%s/collections/%s/points/delete", a.baseURL, a.config.Index)
 	
+	points := make([]qdrantID, len(ids))
+	for i, id := range ids {
+		if err := validateQdrantID(id); err != nil {
+			return err
+		}
+		points[i] = qdrantID(id)
+	}
+	
 	request := struct {
-		Points []string `json:"points"`
+		Points []qdrantID `json:"points"`
 	}{
-		Points: ids,
+		Points: points,
 	}
 	
 	jsonData, err := json.Marshal(request)
@@ -209,7 +486,7 @@ func (a *QdrantAdapter) DeleteBatch(ctx context.Context, ids []string) error {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 	
-	req.Header.Set("Content-Type", "application/json")
+	a.setHeaders(req)
 	
 	resp, err := a.httpClient.Do(req)
 	if err != nil {
@@ -218,8 +495,7 @@ func (a *QdrantAdapter) DeleteBatch(ctx context.Context, ids []string) error {
 	defer resp.Body.Close()
 	
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("Qdrant API error (%d): %s", resp.StatusCode, string(body))
+		return apiError("Qdrant", resp)
 	}
 	
 	return nil
@@ -235,6 +511,8 @@ func (a *QdrantAdapter) ValidateConnection(ctx context.Context) error {
 		return fmt.Errorf("failed to create validation request: %w", err)
 	}
 	
+	a.setHeaders(req)
+	
 	resp, err := a.httpClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to connect to Qdrant: %w", err)
@@ -248,53 +526,312 @@ func (a *QdrantAdapter) ValidateConnection(ctx context.Context) error {
 	return nil
 }
 
-// GetStats returns Qdrant statistics
+// GetStats returns Qdrant statistics. Dimensions is the unnamed vector's
+// size, or the only named vector's; Details lists every vector.
 func (a *QdrantAdapter) GetStats(ctx context.Context) (*DBStats, error) {
 	// Get collection info
 	url := fmt.Sprintf("%s/collections/%s", a.baseURL, a.config.Index)
 	
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create stats request: %w", err)
+	var collectionInfo qdrantCollectionInfo
+	if err := a.do(ctx, "GET", url, nil, &collectionInfo); err != nil {
+		return nil, fmt.Errorf("failed to get stats from Qdrant: %w", err)
 	}
 	
-	resp, err := a.httpClient.Do(req)
+	config := collectionInfo.Result.Config
+	vectors, err := qdrantVectorConfigs(config.Params.Vectors)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get stats from Qdrant: %w", err)
+		return nil, fmt.Errorf("failed to decode vector config: %w", err)
 	}
-	defer resp.Body.Close()
 	
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Qdrant API error (%d)", resp.StatusCode)
+	sizes := make(map[string]int, len(vectors))
+	for name, vector := range vectors {
+		sizes[name] = vector.Size
 	}
 	
-	var collectionInfo struct {
-		Result struct {
-			Status      string `json:"status"`
-			VectorsCount int64  `json:"vectors_count"`
-			PointsCount int64  `json:"points_count"`
-			Config      struct {
-				Params struct {
-					Vectors struct {
-						Size     int    `json:"size"`
-						Distance string `json:"distance"`
-					} `json:"vectors"`
-				} `json:"params"`
-			} `json:"config"`
-		} `json:"result"`
-		Status string `json:"status"`
+	primary, ok := vectors[""]
+	if !ok && len(vectors) == 1 {
+		for _, vector := range vectors {
+			primary = vector
+		}
 	}
 	
-	if err := json.NewDecoder(resp.Body).Decode(&collectionInfo); err != nil {
-		return nil, fmt.Errorf("failed to decode stats: %w", err)
+	sparse := make([]string, 0, len(config.Params.SparseVectors))
+	for name := range config.Params.SparseVectors {
+		sparse = append(sparse, name)
 	}
+	sort.Strings(sparse)
 	
-	return &DBStats{
-		TotalRecords: collectionInfo.Result.VectorsCount,
-		Dimensions:   collectionInfo.Result.Config.Params.Vectors.Size,
+	stats := &DBStats{
+		TotalRecords: collectionInfo.Result.PointsCount,
+		Dimensions:   primary.Size,
 		IndexType:    "qdrant-hnsw",
 		MemoryUsage:  0, // Not available via API
-	}, nil
+		Distance:     qdrantDistanceNames[primary.Distance],
+		Details: map[string]interface{}{
+			"vectors":        sizes,
+			"sparse_vectors": sparse,
+		},
+	}
+	if config.HNSWConfig.M > 0 || config.HNSWConfig.EfConstruct > 0 {
+		stats.HNSW = &HNSWParams{M: config.HNSWConfig.M, EfConstruction: config.HNSWConfig.EfConstruct}
+	}
+	
+	return stats, nil
+}
+
+// GetSchema infers the payload schema by sampling the collection's first
+// points; Extra["schema_sample"] sets the sample size. Qdrant's own payload
+// schema only covers indexed fields.
+func (a *QdrantAdapter) GetSchema(ctx context.Context) (*Schema, error) {
+	return SampleSchema(ctx, a, schemaSampleSize(a.config))
+}
+
+// Search finds the k points nearest to vector. The unnamed vector is
+// searched unless Extra["search_vector"] names a named one. Filter
+// conditions become must clauses: match for strings, booleans and
+// integers, a closed range for other numbers.
+func (a *QdrantAdapter) Search(ctx context.Context, vector []float32, k int, filter Filter) ([]SearchResult, error) {
+	if err := filter.validate(); err != nil {
+		return nil, err
+	}
+	
+	request := qdrantSearchRequest{Vector: vector, Limit: k}
+	if name := a.config.Extra["search_vector"]; name != "" {
+		request.Vector = map[string]interface{}{"name": name, "vector": vector}
+	}
+	if len(filter) > 0 {
+		must := make([]map[string]interface{}, 0, len(filter))
+		for _, field := range sortedKeys(filter) {
+			must = append(must, qdrantCondition(field, filter[field]))
+		}
+		request.Filter = map[string]interface{}{"must": must}
+	}
+	
+	url := fmt.Sprintf("%s/collections/%s/points/search", a.baseURL, a.config.Index)
+	
+	var searchResp qdrantSearchResponse
+	if err := a.do(ctx, "POST", url, request, &searchResp); err != nil {
+		return nil, fmt.Errorf("failed to search Qdrant: %w", err)
+	}
+	
+	results := make([]SearchResult, len(searchResp.Result))
+	for i, hit := range searchResp.Result {
+		results[i] = SearchResult{ID: string(hit.ID), Score: hit.Score}
+	}
+	return results, nil
+}
+
+// qdrantCondition builds the field condition matching value
+func qdrantCondition(field string, value interface{}) map[string]interface{} {
+	if n, ok := filterNumber(value); ok && n != math.Trunc(n) {
+		return map[string]interface{}{"key": field, "range": map[string]float64{"gte": n, "lte": n}}
+	}
+	return map[string]interface{}{"key": field, "match": map[string]interface{}{"value": value}}
+}
+
+// qdrantVectorConfig is the config of one dense vector
+type qdrantVectorConfig struct {
+	Size     int    `json:"size"`
+	Distance string `json:"distance"`
+}
+
+// qdrantDistances maps Distance constants to Qdrant distance names
+var qdrantDistances = map[string]string{
+	DistanceCosine:    "Cosine",
+	DistanceDot:       "Dot",
+	DistanceEuclidean: "Euclid",
+	DistanceManhattan: "Manhattan",
+}
+
+// qdrantDistanceNames maps Qdrant distance names to Distance constants
+var qdrantDistanceNames = map[string]string{
+	"Cosine":    DistanceCosine,
+	"Dot":       DistanceDot,
+	"Euclid":    DistanceEuclidean,
+	"Manhattan": DistanceManhattan,
+}
+
+// qdrantVectorConfigs maps vector names to their configs, with "" for the
+// unnamed vector, from a collection's vectors config
+func qdrantVectorConfigs(raw json.RawMessage) (map[string]qdrantVectorConfig, error) {
+	configs := make(map[string]qdrantVectorConfig)
+	if len(raw) == 0 || string(raw) == "null" {
+		return configs, nil
+	}
+	
+	var unnamed struct {
+		Size     *int   `json:"size"`
+		Distance string `json:"distance"`
+	}
+	if err := json.Unmarshal(raw, &unnamed); err != nil {
+		return nil, err
+	}
+	if unnamed.Size != nil {
+		configs[""] = qdrantVectorConfig{Size: *unnamed.Size, Distance: unnamed.Distance}
+		return configs, nil
+	}
+	
+	if err := json.Unmarshal(raw, &configs); err != nil {
+		return nil, err
+	}
+	return configs, nil
+}
+
+// Partitions returns the collection's shard keys. Collections sharded
+// automatically have none and cannot be split.
+func (a *QdrantAdapter) Partitions(ctx context.Context, n int) ([]string, error) {
+	url := fmt.Sprintf("%s/collections/%s/cluster", a.baseURL, a.config.Index)
+	
+	var clusterResp qdrantClusterInfo
+	if err := a.do(ctx, "GET", url, nil, &clusterResp); err != nil {
+		return nil, fmt.Errorf("failed to get Qdrant cluster info: %w", err)
+	}
+	
+	seen := make(map[string]bool)
+	var keys []string
+	add := func(key *qdrantID) {
+		if key != nil && !seen[string(*key)] {
+			seen[string(*key)] = true
+			keys = append(keys, string(*key))
+		}
+	}
+	for _, shard := range clusterResp.Result.LocalShards {
+		add(shard.ShardKey)
+	}
+	for _, shard := range clusterResp.Result.RemoteShards {
+		add(shard.ShardKey)
+	}
+	sort.Strings(keys)
+	
+	return keys, nil
+}
+
+// WithPartition returns an adapter sharing this connection that scrolls
+// only the shards with shardKey
+func (a *QdrantAdapter) WithPartition(shardKey string) (Database, error) {
+	if shardKey == "" {
+		return nil, fmt.Errorf("Qdrant shard key must not be empty")
+	}
+	
+	scoped := *a
+	scoped.shardKey = shardKey
+	return &scoped, nil
+}
+
+// EnsureCollection creates the collection from spec if it does not exist.
+// An existing collection must have every vector in spec at the same size.
+func (a *QdrantAdapter) EnsureCollection(ctx context.Context, spec CollectionSpec) error {
+	if spec.Dimensions > 0 && len(spec.NamedVectors) > 0 {
+		return fmt.Errorf("Qdrant collection %s cannot mix an unnamed vector with named vectors", a.config.Index)
+	}
+	
+	url := fmt.Sprintf("%s/collections/%s", a.baseURL, a.config.Index)
+	
+	exists, err := a.collectionExists(ctx, url)
+	if err != nil {
+		return err
+	}
+	if exists {
+		stats, err := a.GetStats(ctx)
+		if err != nil {
+			return err
+		}
+		return checkQdrantVectors(a.config.Index, stats.Details["vectors"].(map[string]int), spec)
+	}
+	
+	distance := DistanceCosine
+	if spec.Distance != "" {
+		distance = spec.Distance
+	}
+	qdrantDistance, ok := qdrantDistances[distance]
+	if !ok {
+		return fmt.Errorf("Qdrant does not support distance %q", spec.Distance)
+	}
+	
+	request := map[string]interface{}{}
+	if len(spec.NamedVectors) > 0 {
+		vectors := make(map[string]qdrantVectorConfig, len(spec.NamedVectors))
+		for name, size := range spec.NamedVectors {
+			vectors[name] = qdrantVectorConfig{Size: size, Distance: qdrantDistance}
+		}
+		request["vectors"] = vectors
+	} else {
+		request["vectors"] = qdrantVectorConfig{Size: spec.Dimensions, Distance: qdrantDistance}
+	}
+	if len(spec.SparseVectors) > 0 {
+		sparse := make(map[string]interface{}, len(spec.SparseVectors))
+		for _, name := range spec.SparseVectors {
+			sparse[name] = map[string]interface{}{}
+		}
+		request["sparse_vectors"] = sparse
+	}
+	if spec.HNSW != nil {
+		hnsw := map[string]int{}
+		if spec.HNSW.M > 0 {
+			hnsw["m"] = spec.HNSW.M
+		}
+		if spec.HNSW.EfConstruction > 0 {
+			hnsw["ef_construct"] = spec.HNSW.EfConstruction
+		}
+		request["hnsw_config"] = hnsw
+	}
+	
+	var result struct {
+		Result bool `json:"result"`
+	}
+	if err := a.do(ctx, "PUT", url, request, &result); err != nil {
+		return fmt.Errorf("failed to create Qdrant collection %s: %w", a.config.Index, err)
+	}
+	
+	return nil
+}
+
+// collectionExists reports whether the collection at url exists
+func (a *QdrantAdapter) collectionExists(ctx context.Context, url string) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+	
+	a.setHeaders(req)
+	
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, apiError("Qdrant", resp)
+	}
+}
+
+// checkQdrantVectors checks that an existing collection's vector sizes
+// accept the vectors in spec
+func checkQdrantVectors(collection string, sizes map[string]int, spec CollectionSpec) error {
+	want := spec.NamedVectors
+	if spec.Dimensions > 0 {
+		want = map[string]int{"": spec.Dimensions}
+	}
+	
+	for name, size := range want {
+		existing, ok := sizes[name]
+		if !ok && name == "" {
+			return fmt.Errorf("Qdrant collection %s has only named vectors, source records have an unnamed vector", collection)
+		}
+		if !ok {
+			return fmt.Errorf("Qdrant collection %s has no vector named %q", collection, name)
+		}
+		if existing != size {
+			return fmt.Errorf("Qdrant collection %s has dimension %d for vector %q, source records have %d", collection, existing, name, size)
+		}
+	}
+	return nil
 }
 
 // GetSourceURL returns the Qdrant source URL
@@ -302,5 +839,80 @@ func (a *QdrantAdapter) GetSourceURL() string {
 	return a.sourceURL
 }
 
+// qdrantCapabilities: payloads nest, points carry named dense and sparse
+// vectors, and IDs are unsigned integers or UUIDs
+var qdrantCapabilities = Capabilities{
+	NestedMetadata:     true,
+	IDFormats:          []IDFormat{IDUint, IDUUID},
+	NamedVectors:       true,
+	NamedSparseVectors: true,
+}
+
+// Capabilities returns what Qdrant can store
+func (a *QdrantAdapter) Capabilities() Capabilities {
+	return qdrantCapabilities
+}
+
+// setHeaders sets the JSON content type and, if configured, the API key
+func (a *QdrantAdapter) setHeaders(req *http.Request) {
+	req.Header.Set("Content-Type", "application/json")
+	if a.config.APIKey != "" {
+		req.Header.Set("api-key", a.config.APIKey)
+	}
+}
+
+// do sends a JSON request to endpoint and decodes the response into out
+func (a *QdrantAdapter) do(ctx context.Context, method, endpoint string, body interface{}, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		jsonData, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reader = bytes.NewBuffer(jsonData)
+	}
+	
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	
+	a.setHeaders(req)
+	
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	
+	if resp.StatusCode != http.StatusOK {
+		return apiError("Qdrant", resp)
+	}
+	
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	
+	return nil
+}
+
 // Ensure QdrantAdapter implements Database interface
 var _ Database = (*QdrantAdapter)(nil)
+
+// Ensure QdrantAdapter implements CursorReader interface
+var _ CursorReader = (*QdrantAdapter)(nil)
+
+// Ensure QdrantAdapter implements SchemaProvisioner interface
+var _ SchemaProvisioner = (*QdrantAdapter)(nil)
+
+// Ensure QdrantAdapter implements SchemaReader interface
+var _ SchemaReader = (*QdrantAdapter)(nil)
+
+// Ensure QdrantAdapter implements CapabilityReporter interface
+var _ CapabilityReporter = (*QdrantAdapter)(nil)
+
+// Ensure QdrantAdapter implements Searcher interface
+var _ Searcher = (*QdrantAdapter)(nil)
+
+// Ensure QdrantAdapter implements Partitioned interface
+var _ Partitioned = (*QdrantAdapter)(nil)
@@ -0,0 +1,374 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/AlphaTechini/vector-db-migration/internal/adapters/fakes"
+)
+
+// TestQdrantAdapterWrites tests connect, upsert and delete against the fake
+func TestQdrantAdapterWrites(t *testing.T) {
+	fake := fakes.NewQdrant(t)
+	fake.CreateCollection("docs", map[string]interface{}{"size": 2, "distance": "Cosine"})
+
+	adapter := connectQdrant(t, fake.URL, "docs", "")
+	ctx := context.Background()
+
+	ids := []string{
+		"550e8400-e29b-41d4-a716-446655440000",
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+	}
+	records := []Record{
+		{ID: ids[0], Vector: []float32{1, 0}, Metadata: map[string]interface{}{"lang": "en"}},
+		{ID: ids[1], Vector: []float32{0, 1}},
+	}
+	if err := adapter.UpsertBatch(ctx, records); err != nil {
+		t.Fatalf("UpsertBatch failed: %v", err)
+	}
+	if points := fake.Points("docs"); len(points) != 2 {
+		t.Fatalf("Expected 2 stored points, got %d", len(points))
+	}
+
+	if err := adapter.UpsertBatch(ctx, []Record{{ID: "doc-1", Vector: []float32{1, 1}}}); err == nil {
+		t.Error("Expected error for an ID that is neither an integer nor a UUID, got nil")
+	}
+
+	if err := adapter.DeleteBatch(ctx, ids[:1]); err != nil {
+		t.Fatalf("DeleteBatch failed: %v", err)
+	}
+	if points := fake.Points("docs"); len(points) != 1 || points[0].ID != ids[1] {
+		t.Errorf("Expected only %s to remain, got %+v", ids[1], points)
+	}
+
+	t.Log("✓ QdrantAdapter writes UUID points")
+}
+
+// connectQdrant connects an adapter to collection on a fake
+func connectQdrant(t *testing.T, url, collection, apiKey string) *QdrantAdapter {
+	t.Helper()
+
+	adapter := &QdrantAdapter{}
+	if err := adapter.Connect(context.Background(), DBConfig{Type: "qdrant", URL: url, APIKey: apiKey, Index: collection}); err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	t.Cleanup(func() { adapter.Close() })
+	return adapter
+}
+
+// TestQdrantAdapterVectors tests named, sparse and unnamed vectors with
+// integer and UUID IDs
+func TestQdrantAdapterVectors(t *testing.T) {
+	fake := fakes.NewQdrant(t)
+	fake.RequireAPIKey("secret")
+	fake.CreateCollection("multi", map[string]interface{}{
+		"title": map[string]interface{}{"size": 2, "distance": "Cosine"},
+		"body":  map[string]interface{}{"size": 3, "distance": "Dot"},
+	}, "keywords")
+	fake.CreateCollection("hybrid", map[string]interface{}{"size": 2, "distance": "Cosine"}, "keywords")
+
+	ctx := context.Background()
+	keywords := SparseVector{Indices: []uint32{3, 17}, Values: []float32{0.5, 1.25}}
+
+	multi := connectQdrant(t, fake.URL, "multi", "secret")
+	records := []Record{
+		{
+			ID:            "7",
+			NamedVectors:  map[string][]float32{"title": {1, 0}, "body": {0, 1, 0}},
+			SparseVectors: map[string]SparseVector{"keywords": keywords},
+			Metadata:      map[string]interface{}{"lang": "en"},
+		},
+		{
+			ID:           "550e8400-e29b-41d4-a716-446655440000",
+			NamedVectors: map[string][]float32{"title": {0, 1}, "body": {1, 1, 1}},
+		},
+	}
+	if err := multi.UpsertBatch(ctx, records); err != nil {
+		t.Fatalf("UpsertBatch failed: %v", err)
+	}
+	if id, ok := fake.Points("multi")[0].ID.(json.Number); !ok || id != "7" {
+		t.Errorf("Expected integer ID 7 to be stored as a number, got %#v", id)
+	}
+
+	got, err := multi.GetBatch(ctx, "", 10)
+	if err != nil {
+		t.Fatalf("GetBatch failed: %v", err)
+	}
+	if !reflect.DeepEqual(got, records) {
+		t.Errorf("Named vectors did not round-trip:\ngot  %+v\nwant %+v", got, records)
+	}
+
+	stats, err := multi.GetStats(ctx)
+	if err != nil {
+		t.Fatalf("GetStats failed: %v", err)
+	}
+	if stats.TotalRecords != 2 || stats.Dimensions != 0 {
+		t.Errorf("Expected 2 records and no single dimension, got %d and %d", stats.TotalRecords, stats.Dimensions)
+	}
+	if sizes := stats.Details["vectors"].(map[string]int); sizes["title"] != 2 || sizes["body"] != 3 {
+		t.Errorf("Unexpected vector sizes: %v", sizes)
+	}
+
+	// An unnamed vector next to sparse vectors travels under ""
+	hybrid := connectQdrant(t, fake.URL, "hybrid", "secret")
+	record := Record{ID: "1", Vector: []float32{0.5, 0.5}, SparseVectors: map[string]SparseVector{"keywords": keywords}}
+	if err := hybrid.UpsertBatch(ctx, []Record{record}); err != nil {
+		t.Fatalf("UpsertBatch with unnamed and sparse vectors failed: %v", err)
+	}
+	got, err = hybrid.GetBatch(ctx, "", 10)
+	if err != nil {
+		t.Fatalf("GetBatch failed: %v", err)
+	}
+	if len(got) != 1 || !reflect.DeepEqual(got[0].Vector, record.Vector) || !reflect.DeepEqual(got[0].SparseVectors, record.SparseVectors) {
+		t.Errorf("Hybrid record did not round-trip: %+v", got)
+	}
+	if stats, err := hybrid.GetStats(ctx); err != nil || stats.Dimensions != 2 {
+		t.Errorf("Expected dimension 2, got %+v (%v)", stats, err)
+	}
+
+	unnamed := Record{ID: "2", Vector: []float32{1, 0}, Sparse: &keywords}
+	if err := hybrid.UpsertBatch(ctx, []Record{unnamed}); err == nil {
+		t.Error("Expected error for an unnamed sparse vector, got nil")
+	}
+
+	t.Log("✓ QdrantAdapter round-trips named and sparse vectors")
+}
+
+// TestQdrantAdapterPagination tests that scroll pages neither repeat nor
+// skip points, by ID and by cursor
+func TestQdrantAdapterPagination(t *testing.T) {
+	fake := fakes.NewQdrant(t)
+	fake.CreateCollection("docs", map[string]interface{}{"size": 2, "distance": "Cosine"})
+	for i := 1; i <= 5; i++ {
+		fake.Seed("docs", fakes.QdrantPoint{ID: i * 10, Vector: []float32{float32(i), 0}})
+	}
+	fake.Seed("docs", fakes.QdrantPoint{ID: "6ba7b810-9dad-11d1-80b4-00c04fd430c8", Vector: []float32{0, 1}})
+
+	adapter := connectQdrant(t, fake.URL, "docs", "")
+	ctx := context.Background()
+	want := []string{"10", "20", "30", "40", "50", "6ba7b810-9dad-11d1-80b4-00c04fd430c8"}
+
+	var ids []string
+	afterID := ""
+	for pages := 0; pages < 10; pages++ {
+		batch, err := adapter.GetBatch(ctx, afterID, 2)
+		if err != nil {
+			t.Fatalf("GetBatch(%q) failed: %v", afterID, err)
+		}
+		if len(batch) == 0 {
+			break
+		}
+		for _, r := range batch {
+			ids = append(ids, r.ID)
+		}
+		afterID = batch[len(batch)-1].ID
+	}
+	if !reflect.DeepEqual(ids, want) {
+		t.Errorf("GetBatch pages = %v, want %v", ids, want)
+	}
+
+	ids = nil
+	cursor := ""
+	for pages := 0; pages < 10; pages++ {
+		batch, next, err := adapter.GetBatchCursor(ctx, cursor, 4)
+		if err != nil {
+			t.Fatalf("GetBatchCursor(%q) failed: %v", cursor, err)
+		}
+		for _, r := range batch {
+			ids = append(ids, r.ID)
+		}
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+	if !reflect.DeepEqual(ids, want) {
+		t.Errorf("GetBatchCursor pages = %v, want %v", ids, want)
+	}
+
+	t.Log("✓ QdrantAdapter pages without duplicates")
+}
+
+// TestQdrantAdapterEnsureCollection tests creating a collection from a spec
+// and checking an existing one
+func TestQdrantAdapterEnsureCollection(t *testing.T) {
+	fake := fakes.NewQdrant(t)
+	fake.CreateCollection("small", map[string]interface{}{"size": 2, "distance": "Cosine"})
+	ctx := context.Background()
+
+	adapter := connectQdrant(t, fake.URL, "multi", "")
+	spec := CollectionSpec{
+		Distance:      DistanceDot,
+		NamedVectors:  map[string]int{"title": 2, "body": 3},
+		SparseVectors: []string{"keywords"},
+		HNSW:          &HNSWParams{M: 32, EfConstruction: 200},
+	}
+	if err := adapter.EnsureCollection(ctx, spec); err != nil {
+		t.Fatalf("EnsureCollection failed: %v", err)
+	}
+
+	stats, err := adapter.GetStats(ctx)
+	if err != nil {
+		t.Fatalf("GetStats failed: %v", err)
+	}
+	if sizes := stats.Details["vectors"].(map[string]int); sizes["title"] != 2 || sizes["body"] != 3 {
+		t.Errorf("Unexpected vector sizes: %v", sizes)
+	}
+	if sparse := stats.Details["sparse_vectors"].([]string); !reflect.DeepEqual(sparse, []string{"keywords"}) {
+		t.Errorf("Unexpected sparse vectors: %v", sparse)
+	}
+	if stats.HNSW == nil || *stats.HNSW != *spec.HNSW {
+		t.Errorf("Expected HNSW %+v, got %+v", spec.HNSW, stats.HNSW)
+	}
+
+	// Existing collections are checked, not recreated
+	if err := adapter.EnsureCollection(ctx, spec); err != nil {
+		t.Errorf("EnsureCollection on an existing collection failed: %v", err)
+	}
+
+	small := connectQdrant(t, fake.URL, "small", "")
+	if err := small.EnsureCollection(ctx, CollectionSpec{Dimensions: 2}); err != nil {
+		t.Errorf("EnsureCollection with a matching dimension failed: %v", err)
+	}
+	if err := small.EnsureCollection(ctx, CollectionSpec{Dimensions: 3}); err == nil {
+		t.Error("Expected error for a dimension mismatch, got nil")
+	}
+	if stats, err := small.GetStats(ctx); err != nil || stats.Distance != DistanceCosine {
+		t.Errorf("Expected cosine distance, got %+v (%v)", stats, err)
+	}
+
+	t.Log("✓ QdrantAdapter provisions collections")
+}
+
+// TestQdrantAdapterFetchByIDs tests point lookups by UUID in any form
+func TestQdrantAdapterFetchByIDs(t *testing.T) {
+	fake := fakes.NewQdrant(t)
+	fake.CreateCollection("docs", map[string]interface{}{"size": 2, "distance": "Cosine"})
+
+	adapter := connectQdrant(t, fake.URL, "docs", "")
+	ctx := context.Background()
+
+	err := adapter.UpsertBatch(ctx, []Record{
+		{ID: "550e8400-e29b-41d4-a716-446655440000", Vector: []float32{1, 0}, Metadata: map[string]interface{}{"lang": "en"}},
+		{ID: "7", Vector: []float32{0, 1}},
+	})
+	if err != nil {
+		t.Fatalf("UpsertBatch failed: %v", err)
+	}
+
+	// Qdrant answers in canonical form; records keep the requested IDs
+	ids := []string{"550E8400E29B41D4A716446655440000", "7", "doc-1", "8"}
+	records, missing, err := adapter.FetchByIDs(ctx, ids)
+	if err != nil {
+		t.Fatalf("FetchByIDs failed: %v", err)
+	}
+	if len(records) != 2 || records[0].ID != ids[0] || records[1].ID != "7" {
+		t.Fatalf("Expected %s and 7, got %+v", ids[0], records)
+	}
+	if records[0].Metadata["lang"] != "en" || !reflect.DeepEqual(records[1].Vector, []float32{0, 1}) {
+		t.Errorf("Unexpected records: %+v", records)
+	}
+	if !reflect.DeepEqual(missing, []string{"doc-1", "8"}) {
+		t.Errorf("Expected doc-1 and 8 missing, got %v", missing)
+	}
+
+	t.Log("✓ QdrantAdapter fetches points by ID")
+}
+
+// TestQdrantAdapterSearch tests search on unnamed and named vectors with
+// match and range filters
+func TestQdrantAdapterSearch(t *testing.T) {
+	fake := fakes.NewQdrant(t)
+	fake.CreateCollection("docs", map[string]interface{}{"size": 2, "distance": "Cosine"})
+	fake.CreateCollection("named", map[string]interface{}{"title": map[string]interface{}{"size": 2, "distance": "Cosine"}})
+	for i := 1; i <= 4; i++ {
+		payload := map[string]interface{}{"lang": "en", "rank": float64(i) / 2}
+		if i == 4 {
+			payload["lang"] = "de"
+		}
+		fake.Seed("docs", fakes.QdrantPoint{ID: i, Vector: []float32{float32(i), 1}, Payload: payload})
+		fake.Seed("named", fakes.QdrantPoint{ID: i, Vector: map[string]interface{}{"title": []float32{1, float32(i)}}})
+	}
+	ctx := context.Background()
+
+	adapter := connectQdrant(t, fake.URL, "docs", "")
+	results, err := adapter.Search(ctx, []float32{1, 0}, 2, nil)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 2 || results[0].ID != "4" || results[1].ID != "3" {
+		t.Fatalf("Unexpected results: %+v", results)
+	}
+
+	filtered, err := adapter.Search(ctx, []float32{1, 0}, 5, Filter{"lang": "en", "rank": 1.5})
+	if err != nil {
+		t.Fatalf("Filtered search failed: %v", err)
+	}
+	if len(filtered) != 1 || filtered[0].ID != "3" {
+		t.Errorf("Expected only point 3, got %+v", filtered)
+	}
+
+	named := &QdrantAdapter{}
+	if err := named.Connect(ctx, DBConfig{Type: "qdrant", URL: fake.URL, Index: "named", Extra: map[string]string{"search_vector": "title"}}); err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	results, err = named.Search(ctx, []float32{1, 0}, 1, nil)
+	if err != nil {
+		t.Fatalf("Named vector search failed: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != "1" {
+		t.Errorf("Expected point 1, got %+v", results)
+	}
+
+	t.Log("✓ QdrantAdapter searches unnamed and named vectors")
+}
+
+// TestQdrantAdapterPartitions tests splitting a collection by shard key
+func TestQdrantAdapterPartitions(t *testing.T) {
+	fake := fakes.NewQdrant(t)
+	fake.CreateCollection("docs", map[string]interface{}{"size": 2, "distance": "Cosine"})
+	fake.SeedShard("docs", "eu", fakes.QdrantPoint{ID: 1, Vector: []float32{1, 0}}, fakes.QdrantPoint{ID: 3, Vector: []float32{3, 0}})
+	fake.SeedShard("docs", "us", fakes.QdrantPoint{ID: 2, Vector: []float32{2, 0}})
+
+	adapter := connectQdrant(t, fake.URL, "docs", "")
+	ctx := context.Background()
+
+	keys, err := adapter.Partitions(ctx, 8)
+	if err != nil {
+		t.Fatalf("Partitions failed: %v", err)
+	}
+	if !reflect.DeepEqual(keys, []string{"eu", "us"}) {
+		t.Fatalf("Partitions = %v, want [eu us]", keys)
+	}
+
+	want := map[string][]string{"eu": {"1", "3"}, "us": {"2"}}
+	for _, key := range keys {
+		partition, err := adapter.WithPartition(key)
+		if err != nil {
+			t.Fatalf("WithPartition(%q) failed: %v", key, err)
+		}
+		batch, _, err := partition.(CursorReader).GetBatchCursor(ctx, "", 10)
+		if err != nil {
+			t.Fatalf("GetBatchCursor on %q failed: %v", key, err)
+		}
+		var ids []string
+		for _, r := range batch {
+			ids = append(ids, r.ID)
+		}
+		if !reflect.DeepEqual(ids, want[key]) {
+			t.Errorf("Partition %q read %v, want %v", key, ids, want[key])
+		}
+	}
+
+	// Collections without shard keys cannot be split
+	fake.CreateCollection("plain", map[string]interface{}{"size": 2, "distance": "Cosine"})
+	fake.Seed("plain", fakes.QdrantPoint{ID: 1, Vector: []float32{1, 0}})
+	plain := connectQdrant(t, fake.URL, "plain", "")
+	if keys, err := plain.Partitions(ctx, 4); err != nil || len(keys) != 0 {
+		t.Errorf("Expected no partitions, got %v (%v)", keys, err)
+	}
+
+	t.Log("✓ QdrantAdapter partitions collections by shard key")
+}
@@ -0,0 +1,826 @@
+package adapters
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RedisAdapter implements Database interface for Redis Stack using
+// RediSearch vector indexes over HASH or JSON documents.
+//
+// DBConfig.Index is the RediSearch index name. Key prefix, storage type and
+// vector field default to what FT.INFO reports and can be overridden with
+// Extra["key_prefix"], Extra["storage"] (hash or json) and Extra["vector_field"].
+type RedisAdapter struct {
+	config      DBConfig
+	conn        *redisConn
+	sourceURL   string
+	index       string
+	prefix      string
+	storage     string
+	vectorField string
+	vectorPath  string
+	dimension   int
+	indexType   string
+	numeric     map[string]bool
+
+	// SCAN state; a SCAN cursor cannot be derived from a record ID, so
+	// GetBatch continues the scan only from the last ID it returned.
+	scanCursor string
+	scanDone   bool
+	pending    []string
+	lastID     string
+}
+
+// redisIndexInfo is the subset of FT.INFO the adapter relies on
+type redisIndexInfo struct {
+	numDocs    int64
+	keyType    string
+	prefixes   []string
+	attributes []map[string]string
+}
+
+// Connect dials Redis, authenticates and loads the index definition
+func (a *RedisAdapter) Connect(ctx context.Context, config DBConfig) error {
+	if config.Type != "redis" {
+		return fmt.Errorf("expected type 'redis', got '%s'", config.Type)
+	}
+
+	a.config = config
+	a.sourceURL = config.URL
+	a.index = config.Index
+
+	timeout := time.Duration(config.Timeout) * time.Second
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+
+	conn, err := dialRedis(ctx, config.URL, config.APIKey, timeout)
+	if err != nil {
+		return fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+	a.conn = conn
+
+	// Validate connection
+	if err := a.ValidateConnection(ctx); err != nil {
+		conn.close()
+		return err
+	}
+
+	info, err := a.indexInfo(ctx)
+	if err != nil {
+		conn.close()
+		return err
+	}
+
+	return a.configure(info)
+}
+
+// configure resolves prefix, storage type and vector field from FT.INFO
+func (a *RedisAdapter) configure(info *redisIndexInfo) error {
+	a.prefix = a.config.Extra["key_prefix"]
+	if a.prefix == "" && len(info.prefixes) > 0 {
+		a.prefix = info.prefixes[0]
+	}
+
+	a.storage = strings.ToLower(a.config.Extra["storage"])
+	if a.storage == "" {
+		a.storage = strings.ToLower(info.keyType)
+	}
+	if a.storage != "hash" && a.storage != "json" {
+		return fmt.Errorf("unsupported Redis storage type: %s (supported: hash, json)", a.storage)
+	}
+
+	a.vectorField = a.config.Extra["vector_field"]
+	a.numeric = make(map[string]bool)
+
+	for _, attr := range info.attributes {
+		name := attr["attribute"]
+		if name == "" {
+			name = attr["identifier"]
+		}
+
+		switch strings.ToUpper(attr["type"]) {
+		case "NUMERIC":
+			a.numeric[name] = true
+		case "VECTOR":
+			if a.vectorField == "" {
+				a.vectorField = name
+			}
+			if name == a.vectorField {
+				a.vectorPath = attr["identifier"]
+				a.dimension, _ = strconv.Atoi(attr["dim"])
+				a.indexType = "redis-" + strings.ToLower(attr["algorithm"])
+				if attr["data_type"] != "" && strings.ToUpper(attr["data_type"]) != "FLOAT32" {
+					return fmt.Errorf("unsupported Redis vector data type: %s", attr["data_type"])
+				}
+			}
+		}
+	}
+
+	if a.vectorField == "" {
+		return fmt.Errorf("Redis index %s has no VECTOR field", a.index)
+	}
+	if a.vectorPath == "" {
+		a.vectorPath = a.vectorField
+	}
+	if a.indexType == "redis-" {
+		a.indexType = "redis"
+	}
+
+	return nil
+}
+
+// Close closes the Redis connection
+func (a *RedisAdapter) Close() error {
+	if a.conn != nil {
+		return a.conn.close()
+	}
+	return nil
+}
+
+// GetBatch retrieves a batch of documents by scanning keys under the index prefix
+func (a *RedisAdapter) GetBatch(ctx context.Context, afterID string, limit int) ([]Record, error) {
+	if afterID == "" {
+		a.scanCursor = "0"
+		a.scanDone = false
+		a.pending = nil
+	} else if afterID != a.lastID {
+		return nil, fmt.Errorf("Redis SCAN cannot resume after %q; restart from the beginning", afterID)
+	}
+
+	// Keys deleted between SCAN and read are skipped, so keep going until
+	// something is returned or the scan is exhausted
+	for {
+		for len(a.pending) < limit && !a.scanDone {
+			if err := a.scan(ctx, limit); err != nil {
+				return nil, err
+			}
+		}
+
+		n := limit
+		if n > len(a.pending) {
+			n = len(a.pending)
+		}
+		// Keys stay pending until read, so a retry of a failed fetch reads them
+		records, err := a.fetch(ctx, a.pending[:n])
+		if err != nil {
+			return nil, err
+		}
+		a.pending = a.pending[n:]
+
+		if len(records) > 0 {
+			a.lastID = records[len(records)-1].ID
+			return records, nil
+		}
+
+		if len(a.pending) == 0 && a.scanDone {
+			return []Record{}, nil
+		}
+	}
+}
+
+// redisFetchLimit is the number of documents read per pipeline
+const redisFetchLimit = 1000
+
+// FetchByIDs reads the documents stored under the index prefix plus each ID
+func (a *RedisAdapter) FetchByIDs(ctx context.Context, ids []string) ([]Record, []string, error) {
+	return fetchByIDs(ids, redisFetchLimit, func(chunk []string) ([]Record, error) {
+		keys := make([]string, len(chunk))
+		for i, id := range chunk {
+			keys[i] = a.prefix + id
+		}
+		return a.fetch(ctx, keys)
+	})
+}
+
+// scan runs one SCAN step and buffers the returned keys
+func (a *RedisAdapter) scan(ctx context.Context, count int) error {
+	reply, err := a.conn.do(ctx, "SCAN", a.scanCursor, "MATCH", a.prefix+"*", "COUNT", strconv.Itoa(count))
+	if err != nil {
+		return fmt.Errorf("failed to scan Redis: %w", err)
+	}
+
+	parts, ok := reply.([]interface{})
+	if !ok || len(parts) != 2 {
+		return fmt.Errorf("unexpected SCAN reply: %v", reply)
+	}
+
+	a.scanCursor = redisString(parts[0])
+	a.scanDone = a.scanCursor == "0"
+
+	keys, _ := parts[1].([]interface{})
+	for _, key := range keys {
+		a.pending = append(a.pending, redisString(key))
+	}
+
+	return nil
+}
+
+// fetch reads documents for the given keys in one pipeline
+func (a *RedisAdapter) fetch(ctx context.Context, keys []string) ([]Record, error) {
+	commands := make([][]string, len(keys))
+	for i, key := range keys {
+		if a.storage == "json" {
+			commands[i] = []string{"JSON.GET", key, "$"}
+		} else {
+			commands[i] = []string{"HGETALL", key}
+		}
+	}
+
+	replies, err := a.conn.pipeline(ctx, commands)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Redis documents: %w", err)
+	}
+
+	records := make([]Record, 0, len(keys))
+	for i, reply := range replies {
+		if err, ok := reply.(redisError); ok {
+			return nil, fmt.Errorf("failed to read %s: %w", keys[i], err)
+		}
+
+		record := Record{
+			ID:       strings.TrimPrefix(keys[i], a.prefix),
+			Metadata: make(map[string]interface{}),
+		}
+
+		if a.storage == "json" {
+			if reply == nil {
+				continue // Deleted between SCAN and read
+			}
+			if err := a.decodeJSON(redisString(reply), &record); err != nil {
+				return nil, fmt.Errorf("failed to decode %s: %w", keys[i], err)
+			}
+		} else {
+			fields, _ := reply.([]interface{})
+			if len(fields) == 0 {
+				continue // Deleted between SCAN and read
+			}
+			if err := a.decodeHash(fields, &record); err != nil {
+				return nil, fmt.Errorf("failed to decode %s: %w", keys[i], err)
+			}
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// decodeHash converts HGETALL field/value pairs into a record
+func (a *RedisAdapter) decodeHash(fields []interface{}, record *Record) error {
+	for i := 0; i+1 < len(fields); i += 2 {
+		name := redisString(fields[i])
+		value := redisString(fields[i+1])
+
+		if name == a.vectorField {
+			vector, err := decodeFloat32Blob([]byte(value))
+			if err != nil {
+				return err
+			}
+			record.Vector = vector
+			continue
+		}
+
+		if a.numeric[name] {
+			if f, err := strconv.ParseFloat(value, 64); err == nil {
+				record.Metadata[name] = f
+				continue
+			}
+		}
+
+		record.Metadata[name] = value
+	}
+
+	return nil
+}
+
+// decodeJSON converts a JSON.GET $ reply into a record
+func (a *RedisAdapter) decodeJSON(raw string, record *Record) error {
+	// JSON.GET with a JSONPath returns an array of matches
+	var docs []map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &docs); err != nil {
+		return err
+	}
+	if len(docs) == 0 {
+		return nil
+	}
+
+	field := strings.TrimPrefix(a.vectorPath, "$.")
+	for key, value := range docs[0] {
+		if key != field {
+			record.Metadata[key] = value
+			continue
+		}
+
+		values, _ := value.([]interface{})
+		record.Vector = make([]float32, len(values))
+		for i, v := range values {
+			if f, ok := v.(float64); ok {
+				record.Vector[i] = float32(f)
+			}
+		}
+	}
+
+	return nil
+}
+
+// UpsertBatch writes documents with HSET or JSON.SET in one pipeline. Each
+// document is written in its own MULTI/EXEC, so a failed write or a dropped
+// connection never leaves a hash deleted but not rewritten.
+func (a *RedisAdapter) UpsertBatch(ctx context.Context, records []Record) error {
+	commands := make([][]string, 0, len(records)*4)
+	for _, r := range records {
+		key := a.prefix + r.ID
+
+		if a.storage == "json" {
+			doc := make(map[string]interface{}, len(r.Metadata)+1)
+			for k, v := range r.Metadata {
+				doc[k] = v
+			}
+			doc[strings.TrimPrefix(a.vectorPath, "$.")] = r.Vector
+
+			jsonData, err := json.Marshal(doc)
+			if err != nil {
+				return fmt.Errorf("failed to marshal document %s: %w", r.ID, err)
+			}
+			commands = append(commands, []string{"MULTI"}, []string{"JSON.SET", key, "$", string(jsonData)}, []string{"EXEC"})
+			continue
+		}
+
+		// Replace the whole hash so removed metadata fields do not linger
+		command := []string{"HSET", key, a.vectorField, string(encodeFloat32Blob(r.Vector))}
+		for _, k := range sortedKeys(r.Metadata) {
+			command = append(command, k, redisFieldValue(r.Metadata[k]))
+		}
+		commands = append(commands, []string{"MULTI"}, []string{"DEL", key}, command, []string{"EXEC"})
+	}
+
+	replies, err := a.conn.pipeline(ctx, commands)
+	if err != nil {
+		return fmt.Errorf("failed to upsert to Redis: %w", err)
+	}
+
+	for _, reply := range replies {
+		// EXEC replies with the replies of the queued commands
+		results, ok := reply.([]interface{})
+		if !ok {
+			results = []interface{}{reply}
+		}
+		for _, result := range results {
+			if err, ok := result.(redisError); ok {
+				return fmt.Errorf("failed to upsert to Redis: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// DeleteBatch deletes documents by ID
+func (a *RedisAdapter) DeleteBatch(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	args := make([]string, len(ids))
+	for i, id := range ids {
+		args[i] = a.prefix + id
+	}
+
+	if _, err := a.conn.do(ctx, "DEL", args...); err != nil {
+		return fmt.Errorf("failed to delete from Redis: %w", err)
+	}
+
+	return nil
+}
+
+// ValidateConnection checks if Redis is accessible
+func (a *RedisAdapter) ValidateConnection(ctx context.Context) error {
+	reply, err := a.conn.do(ctx, "PING")
+	if err != nil {
+		return fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+
+	if redisString(reply) != "PONG" {
+		return fmt.Errorf("Redis connection failed (unexpected PING reply %v)", reply)
+	}
+
+	return nil
+}
+
+// GetStats returns document count, dimension and index algorithm from FT.INFO
+func (a *RedisAdapter) GetStats(ctx context.Context) (*DBStats, error) {
+	info, err := a.indexInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DBStats{
+		TotalRecords: info.numDocs,
+		Dimensions:   a.dimension,
+		IndexType:    a.indexType,
+		MemoryUsage:  0, // Not tracked per index
+	}, nil
+}
+
+// GetSourceURL returns the Redis source URL
+func (a *RedisAdapter) GetSourceURL() string {
+	return a.sourceURL
+}
+
+// redisCapabilities: HASH documents hold flat fields
+var redisCapabilities = Capabilities{
+	IDFormats: []IDFormat{IDString},
+}
+
+// Capabilities returns what the index can store; JSON documents nest
+func (a *RedisAdapter) Capabilities() Capabilities {
+	caps := redisCapabilities
+	caps.NestedMetadata = a.storage == "json"
+	return caps
+}
+
+// indexInfo runs FT.INFO and parses the parts of the reply the adapter uses
+func (a *RedisAdapter) indexInfo(ctx context.Context) (*redisIndexInfo, error) {
+	reply, err := a.conn.do(ctx, "FT.INFO", a.index)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Redis index info: %w", err)
+	}
+
+	pairs, ok := reply.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected FT.INFO reply: %v", reply)
+	}
+
+	info := &redisIndexInfo{}
+	for i := 0; i+1 < len(pairs); i += 2 {
+		value := pairs[i+1]
+
+		switch redisString(pairs[i]) {
+		case "num_docs":
+			info.numDocs, _ = strconv.ParseInt(redisString(value), 10, 64)
+
+		case "index_definition":
+			definition := redisPairs(value)
+			info.keyType = redisString(definition["key_type"])
+			if prefixes, ok := definition["prefixes"].([]interface{}); ok {
+				for _, p := range prefixes {
+					info.prefixes = append(info.prefixes, redisString(p))
+				}
+			}
+
+		case "attributes":
+			attrs, _ := value.([]interface{})
+			for _, attr := range attrs {
+				fields := make(map[string]string)
+				for k, v := range redisPairs(attr) {
+					fields[k] = redisString(v)
+				}
+				info.attributes = append(info.attributes, fields)
+			}
+		}
+	}
+
+	return info, nil
+}
+
+// encodeFloat32Blob encodes a vector as little-endian FLOAT32 bytes
+func encodeFloat32Blob(vector []float32) []byte {
+	blob := make([]byte, 4*len(vector))
+	for i, v := range vector {
+		binary.LittleEndian.PutUint32(blob[4*i:], math.Float32bits(v))
+	}
+	return blob
+}
+
+// decodeFloat32Blob decodes little-endian FLOAT32 bytes into a vector
+func decodeFloat32Blob(blob []byte) ([]float32, error) {
+	if len(blob)%4 != 0 {
+		return nil, fmt.Errorf("vector blob length %d is not a multiple of 4", len(blob))
+	}
+
+	vector := make([]float32, len(blob)/4)
+	for i := range vector {
+		vector[i] = math.Float32frombits(binary.LittleEndian.Uint32(blob[4*i:]))
+	}
+	return vector, nil
+}
+
+// redisFieldValue renders a metadata value for storage in a hash field
+func redisFieldValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool, int, int64, int32:
+		return fmt.Sprint(v)
+	default:
+		jsonData, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(jsonData)
+	}
+}
+
+// redisPairs converts a flat [key, value, ...] reply into a map
+func redisPairs(reply interface{}) map[string]interface{} {
+	items, _ := reply.([]interface{})
+	pairs := make(map[string]interface{}, len(items)/2)
+	for i := 0; i+1 < len(items); i += 2 {
+		pairs[strings.ToLower(redisString(items[i]))] = items[i+1]
+	}
+	return pairs
+}
+
+// redisString converts a scalar reply into a string
+func redisString(reply interface{}) string {
+	switch v := reply.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// redisError is an error reply (-ERR ...) from the server
+type redisError string
+
+func (e redisError) Error() string {
+	return string(e)
+}
+
+// redisConn is a minimal RESP2 client over a single connection. A failed
+// exchange may leave replies unread, so the connection is then closed and
+// dialed again by the next command.
+type redisConn struct {
+	mu       sync.Mutex
+	conn     net.Conn
+	reader   *bufio.Reader
+	writer   *bufio.Writer
+	timeout  time.Duration
+	rawURL   string
+	password string
+	broken   bool
+}
+
+// dialRedis connects to a redis:// or rediss:// URL (or bare host:port)
+func dialRedis(ctx context.Context, rawURL, password string, timeout time.Duration) (*redisConn, error) {
+	address := rawURL
+	useTLS := false
+	database := ""
+
+	if strings.Contains(rawURL, "://") {
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Redis URL: %w", err)
+		}
+		if u.Scheme != "redis" && u.Scheme != "rediss" {
+			return nil, fmt.Errorf("unsupported Redis URL scheme: %s", u.Scheme)
+		}
+		useTLS = u.Scheme == "rediss"
+		address = u.Host
+		if u.Port() == "" {
+			address = net.JoinHostPort(u.Hostname(), "6379")
+		}
+		if p, ok := u.User.Password(); ok && password == "" {
+			password = p
+		}
+		database = strings.TrimPrefix(u.Path, "/")
+	}
+
+	dialer := &net.Dialer{Timeout: timeout}
+	var conn net.Conn
+	var err error
+	if useTLS {
+		tlsDialer := &tls.Dialer{NetDialer: dialer}
+		conn, err = tlsDialer.DialContext(ctx, "tcp", address)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", address)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c := &redisConn{
+		conn:     conn,
+		reader:   bufio.NewReader(conn),
+		writer:   bufio.NewWriter(conn),
+		timeout:  timeout,
+		rawURL:   rawURL,
+		password: password,
+	}
+
+	if password != "" {
+		if _, err := c.do(ctx, "AUTH", password); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("authentication failed: %w", err)
+		}
+	}
+
+	if database != "" && database != "0" {
+		if _, err := c.do(ctx, "SELECT", database); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to select database %s: %w", database, err)
+		}
+	}
+
+	return c, nil
+}
+
+// do sends one command and returns its reply; error replies become errors
+func (c *redisConn) do(ctx context.Context, command string, args ...string) (interface{}, error) {
+	replies, err := c.pipeline(ctx, [][]string{append([]string{command}, args...)})
+	if err != nil {
+		return nil, err
+	}
+
+	if err, ok := replies[0].(redisError); ok {
+		return nil, err
+	}
+	return replies[0], nil
+}
+
+// pipeline sends several commands and reads their replies in order.
+// Error replies are returned in place as redisError values.
+func (c *redisConn) pipeline(ctx context.Context, commands [][]string) ([]interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.broken {
+		if err := c.redial(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	replies, err := c.exchange(ctx, commands)
+	if err != nil {
+		c.conn.Close()
+		c.broken = true
+		return nil, err
+	}
+	return replies, nil
+}
+
+// exchange writes commands and reads one reply per command
+func (c *redisConn) exchange(ctx context.Context, commands [][]string) ([]interface{}, error) {
+	deadline := time.Now().Add(c.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := c.conn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	for _, command := range commands {
+		if err := writeRESPCommand(c.writer, command); err != nil {
+			return nil, err
+		}
+	}
+	if err := c.writer.Flush(); err != nil {
+		return nil, err
+	}
+
+	replies := make([]interface{}, len(commands))
+	for i := range replies {
+		reply, err := readRESP(c.reader)
+		if err != nil {
+			return nil, err
+		}
+		replies[i] = reply
+	}
+
+	return replies, nil
+}
+
+// redial replaces a broken connection with a new, authenticated one
+func (c *redisConn) redial(ctx context.Context) error {
+	fresh, err := dialRedis(ctx, c.rawURL, c.password, c.timeout)
+	if err != nil {
+		return fmt.Errorf("failed to reconnect to Redis: %w", err)
+	}
+
+	c.conn, c.reader, c.writer = fresh.conn, fresh.reader, fresh.writer
+	c.broken = false
+	return nil
+}
+
+// close closes the underlying connection
+func (c *redisConn) close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.broken {
+		return nil
+	}
+	return c.conn.Close()
+}
+
+// writeRESPCommand encodes a command as a RESP array of bulk strings
+func writeRESPCommand(w *bufio.Writer, command []string) error {
+	if _, err := fmt.Fprintf(w, "*%d\r\n", len(command)); err != nil {
+		return err
+	}
+	for _, arg := range command {
+		if _, err := fmt.Fprintf(w, "$%d\r\n%s\r\n", len(arg), arg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readRESP reads a single RESP2 value. Bulk strings are returned as string,
+// integers as int64, arrays as []interface{} and nil replies as nil.
+func readRESP(r *bufio.Reader) (interface{}, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || !strings.HasSuffix(line, "\r\n") {
+		return nil, fmt.Errorf("malformed RESP line %q", line)
+	}
+
+	payload := line[1 : len(line)-2]
+	switch line[0] {
+	case '+':
+		return payload, nil
+
+	case '-':
+		return redisError(payload), nil
+
+	case ':':
+		return strconv.ParseInt(payload, 10, 64)
+
+	case '$':
+		size, err := strconv.Atoi(payload)
+		if err != nil {
+			return nil, err
+		}
+		if size < 0 {
+			return nil, nil
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		return string(buf[:size]), nil
+
+	case '*':
+		count, err := strconv.Atoi(payload)
+		if err != nil {
+			return nil, err
+		}
+		if count < 0 {
+			return nil, nil
+		}
+		items := make([]interface{}, count)
+		for i := range items {
+			item, err := readRESP(r)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = item
+		}
+		return items, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported RESP type %q", line[0])
+	}
+}
+
+// sortedKeys returns map keys in sorted order
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Ensure RedisAdapter implements Database interface
+var _ Database = (*RedisAdapter)(nil)
+
+// Ensure RedisAdapter implements CapabilityReporter interface
+var _ CapabilityReporter = (*RedisAdapter)(nil)
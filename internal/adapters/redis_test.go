@@ -0,0 +1,516 @@
+package adapters
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeRedis is a miniredis-style RESP server implementing the commands
+// RedisAdapter uses, with a single FT index over HASH or JSON documents.
+type fakeRedis struct {
+	mu       sync.Mutex
+	password string
+	keyType  string
+	hashes   map[string]map[string]string
+	docs     map[string]string
+
+	// stall delays the reply to the next command of this name by stallFor
+	stall    string
+	stallFor time.Duration
+
+	// reject fails writes to this key when they are queued, which aborts
+	// their transaction; execs counts transactions run
+	reject string
+	execs  int
+}
+
+func newFakeRedis(t *testing.T, keyType, password string) (*fakeRedis, string) {
+	fake := &fakeRedis{
+		password: password,
+		keyType:  keyType,
+		hashes:   make(map[string]map[string]string),
+		docs:     make(map[string]string),
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go fake.serve(conn)
+		}
+	}()
+
+	return fake, "redis://" + listener.Addr().String()
+}
+
+func (f *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	writer := bufio.NewWriter(conn)
+	authed := f.password == ""
+
+	// queue holds the commands of an open MULTI; aborted is set when one
+	// failed to queue
+	var queue [][]string
+	var aborted bool
+
+	for {
+		request, err := readRESP(reader)
+		if err != nil {
+			return
+		}
+		items, _ := request.([]interface{})
+		args := make([]string, len(items))
+		for i, item := range items {
+			args[i] = redisString(item)
+		}
+
+		if !authed && strings.ToUpper(args[0]) != "AUTH" {
+			writer.WriteString("-NOAUTH Authentication required.\r\n")
+		} else if strings.ToUpper(args[0]) == "AUTH" {
+			authed = args[1] == f.password
+			if authed {
+				writer.WriteString("+OK\r\n")
+			} else {
+				writer.WriteString("-WRONGPASS invalid password\r\n")
+			}
+		} else if strings.ToUpper(args[0]) == "MULTI" {
+			queue, aborted = [][]string{}, false
+			writer.WriteString("+OK\r\n")
+		} else if strings.ToUpper(args[0]) == "EXEC" {
+			f.mu.Lock()
+			if aborted {
+				writeFakeReply(writer, redisError("EXECABORT Transaction discarded because of previous errors."))
+			} else {
+				replies := make([]interface{}, len(queue))
+				for i, command := range queue {
+					replies[i] = f.exec(command)
+				}
+				f.execs++
+				writeFakeReply(writer, replies)
+			}
+			f.mu.Unlock()
+			queue = nil
+		} else if queue != nil {
+			f.mu.Lock()
+			rejected := strings.ToUpper(args[0]) != "DEL" && len(args) > 1 && args[1] == f.reject
+			f.mu.Unlock()
+			if rejected {
+				aborted = true
+				writeFakeReply(writer, redisError("ERR wrong number of arguments for '"+strings.ToLower(args[0])+"' command"))
+			} else {
+				queue = append(queue, args)
+				writer.WriteString("+QUEUED\r\n")
+			}
+		} else {
+			f.mu.Lock()
+			var delay time.Duration
+			if f.stall == strings.ToUpper(args[0]) {
+				f.stall, delay = "", f.stallFor
+			}
+			f.mu.Unlock()
+			time.Sleep(delay)
+
+			f.mu.Lock()
+			writeFakeReply(writer, f.exec(args))
+			f.mu.Unlock()
+		}
+		writer.Flush()
+	}
+}
+
+func (f *fakeRedis) exec(args []string) interface{} {
+	switch strings.ToUpper(args[0]) {
+	case "PING":
+		return "+PONG"
+
+	case "FT.INFO":
+		if args[1] != "idx:docs" {
+			return redisError("Unknown index name")
+		}
+		identifier := "embedding"
+		if f.keyType == "JSON" {
+			identifier = "$.embedding"
+		}
+		return []interface{}{
+			"index_name", "idx:docs",
+			"index_definition", []interface{}{"key_type", f.keyType, "prefixes", []interface{}{"doc:"}},
+			"attributes", []interface{}{
+				[]interface{}{"identifier", identifier, "attribute", "embedding", "type", "VECTOR",
+					"algorithm", "HNSW", "data_type", "FLOAT32", "dim", "3", "distance_metric", "COSINE"},
+				[]interface{}{"identifier", "score", "attribute", "score", "type", "NUMERIC"},
+			},
+			"num_docs", strconv.Itoa(len(f.hashes) + len(f.docs)),
+		}
+
+	case "SCAN":
+		keys := make([]string, 0)
+		for k := range f.hashes {
+			keys = append(keys, k)
+		}
+		for k := range f.docs {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		cursor, _ := strconv.Atoi(args[1])
+		count, _ := strconv.Atoi(args[5])
+		prefix := strings.TrimSuffix(args[3], "*")
+
+		// Return fewer keys than COUNT to exercise buffering across SCAN calls
+		page := []interface{}{}
+		next := cursor
+		for next < len(keys) && len(page) < count/2+1 {
+			if strings.HasPrefix(keys[next], prefix) {
+				page = append(page, keys[next])
+			}
+			next++
+		}
+		if next >= len(keys) {
+			next = 0
+		}
+		return []interface{}{strconv.Itoa(next), page}
+
+	case "HSET":
+		hash := f.hashes[args[1]]
+		if hash == nil {
+			hash = make(map[string]string)
+			f.hashes[args[1]] = hash
+		}
+		for i := 2; i+1 < len(args); i += 2 {
+			hash[args[i]] = args[i+1]
+		}
+		return int64((len(args) - 2) / 2)
+
+	case "HGETALL":
+		reply := []interface{}{}
+		hash := f.hashes[args[1]]
+		fields := make([]string, 0, len(hash))
+		for k := range hash {
+			fields = append(fields, k)
+		}
+		sort.Strings(fields)
+		for _, k := range fields {
+			reply = append(reply, k, hash[k])
+		}
+		return reply
+
+	case "JSON.SET":
+		f.docs[args[1]] = args[3]
+		return "+OK"
+
+	case "JSON.GET":
+		doc, ok := f.docs[args[1]]
+		if !ok {
+			return nil
+		}
+		return "[" + doc + "]"
+
+	case "DEL":
+		var deleted int64
+		for _, key := range args[1:] {
+			if _, ok := f.hashes[key]; ok {
+				delete(f.hashes, key)
+				deleted++
+			}
+			if _, ok := f.docs[key]; ok {
+				delete(f.docs, key)
+				deleted++
+			}
+		}
+		return deleted
+
+	default:
+		return redisError("ERR unknown command '" + args[0] + "'")
+	}
+}
+
+func writeFakeReply(w *bufio.Writer, reply interface{}) {
+	switch v := reply.(type) {
+	case nil:
+		w.WriteString("$-1\r\n")
+	case redisError:
+		w.WriteString("-" + string(v) + "\r\n")
+	case int64:
+		fmt.Fprintf(w, ":%d\r\n", v)
+	case string:
+		if strings.HasPrefix(v, "+") {
+			w.WriteString(v + "\r\n")
+		} else {
+			fmt.Fprintf(w, "$%d\r\n%s\r\n", len(v), v)
+		}
+	case []interface{}:
+		fmt.Fprintf(w, "*%d\r\n", len(v))
+		for _, item := range v {
+			writeFakeReply(w, item)
+		}
+	}
+}
+
+func connectRedis(t *testing.T, url, password string) *RedisAdapter {
+	adapter := &RedisAdapter{}
+	err := adapter.Connect(context.Background(), DBConfig{
+		Type:   "redis",
+		URL:    url,
+		APIKey: password,
+		Index:  "idx:docs",
+	})
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	t.Cleanup(func() { adapter.Close() })
+	return adapter
+}
+
+// TestRedisAdapterConnect tests authentication and FT.INFO discovery
+func TestRedisAdapterConnect(t *testing.T) {
+	_, url := newFakeRedis(t, "HASH", "secret")
+	adapter := connectRedis(t, url, "secret")
+
+	if adapter.prefix != "doc:" || adapter.storage != "hash" {
+		t.Errorf("Expected prefix 'doc:' and hash storage, got '%s' and '%s'", adapter.prefix, adapter.storage)
+	}
+
+	if adapter.vectorField != "embedding" || adapter.dimension != 3 {
+		t.Errorf("Expected vector field 'embedding' with dim 3, got '%s' with dim %d", adapter.vectorField, adapter.dimension)
+	}
+
+	wrongPassword := &RedisAdapter{}
+	if err := wrongPassword.Connect(context.Background(), DBConfig{Type: "redis", URL: url, APIKey: "nope", Index: "idx:docs"}); err == nil {
+		t.Error("Expected error for wrong password, got nil")
+	}
+
+	wrongType := &RedisAdapter{}
+	if err := wrongType.Connect(context.Background(), DBConfig{Type: "qdrant"}); err == nil {
+		t.Error("Expected error for invalid type, got nil")
+	}
+
+	t.Log("✓ RedisAdapter authenticates and reads FT.INFO")
+}
+
+// TestRedisAdapterHashRoundTrip tests FLOAT32 blobs, numeric fields and SCAN pagination
+func TestRedisAdapterHashRoundTrip(t *testing.T) {
+	_, url := newFakeRedis(t, "HASH", "")
+	adapter := connectRedis(t, url, "")
+	ctx := context.Background()
+
+	var records []Record
+	for i := 0; i < 23; i++ {
+		records = append(records, Record{
+			ID:       fmt.Sprintf("%03d", i),
+			Vector:   []float32{float32(i), -0.5, 0.125},
+			Metadata: map[string]interface{}{"title": fmt.Sprintf("doc %d", i), "score": float64(i) / 2},
+		})
+	}
+
+	if err := adapter.UpsertBatch(ctx, records); err != nil {
+		t.Fatalf("Failed to upsert: %v", err)
+	}
+
+	var seen []Record
+	afterID := ""
+	for {
+		batch, err := adapter.GetBatch(ctx, afterID, 5)
+		if err != nil {
+			t.Fatalf("Failed to get batch: %v", err)
+		}
+		if len(batch) == 0 {
+			break
+		}
+		seen = append(seen, batch...)
+		afterID = batch[len(batch)-1].ID
+	}
+
+	if len(seen) != 23 {
+		t.Fatalf("Expected 23 records, got %d", len(seen))
+	}
+
+	if seen[4].ID != "004" || seen[4].Vector[0] != 4 || seen[4].Vector[2] != 0.125 {
+		t.Errorf("Expected record 004 with decoded vector, got %s %v", seen[4].ID, seen[4].Vector)
+	}
+
+	if seen[4].Metadata["score"] != 2.0 {
+		t.Errorf("Expected NUMERIC field decoded as float64 2, got %v (%T)", seen[4].Metadata["score"], seen[4].Metadata["score"])
+	}
+
+	if _, err := adapter.GetBatch(ctx, "not-the-last-id", 5); err == nil {
+		t.Error("Expected error when resuming SCAN from an arbitrary ID")
+	}
+
+	stats, err := adapter.GetStats(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get stats: %v", err)
+	}
+	if stats.TotalRecords != 23 || stats.Dimensions != 3 || stats.IndexType != "redis-hnsw" {
+		t.Errorf("Expected 23 records, dim 3, redis-hnsw; got %+v", stats)
+	}
+
+	t.Log("✓ RedisAdapter round trips HASH documents")
+}
+
+// TestRedisAdapterJSONRoundTrip tests JSON documents and deletes
+func TestRedisAdapterJSONRoundTrip(t *testing.T) {
+	fake, url := newFakeRedis(t, "JSON", "")
+	adapter := connectRedis(t, url, "")
+	ctx := context.Background()
+
+	err := adapter.UpsertBatch(ctx, []Record{
+		{ID: "a", Vector: []float32{1, 2, 3}, Metadata: map[string]interface{}{"tags": []interface{}{"x", "y"}}},
+		{ID: "b", Vector: []float32{4, 5, 6}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to upsert: %v", err)
+	}
+
+	if !strings.Contains(fake.docs["doc:a"], `"embedding":[1,2,3]`) {
+		t.Errorf("Expected vector stored as JSON array, got %s", fake.docs["doc:a"])
+	}
+
+	if err := adapter.DeleteBatch(ctx, []string{"b"}); err != nil {
+		t.Fatalf("Failed to delete: %v", err)
+	}
+
+	batch, err := adapter.GetBatch(ctx, "", 10)
+	if err != nil {
+		t.Fatalf("Failed to get batch: %v", err)
+	}
+
+	if len(batch) != 1 || batch[0].ID != "a" || len(batch[0].Vector) != 3 {
+		t.Fatalf("Expected only record 'a' with a vector, got %v", batch)
+	}
+
+	if tags, ok := batch[0].Metadata["tags"].([]interface{}); !ok || len(tags) != 2 {
+		t.Errorf("Expected tags array in metadata, got %v", batch[0].Metadata["tags"])
+	}
+
+	t.Log("✓ RedisAdapter round trips JSON documents")
+}
+
+// TestRedisAdapterRetryAfterTimeout tests that a GetBatch that timed out
+// can be retried: the connection is dialed again rather than left holding
+// the late replies, and no keys are skipped
+func TestRedisAdapterRetryAfterTimeout(t *testing.T) {
+	fake, url := newFakeRedis(t, "HASH", "")
+	adapter := connectRedis(t, url, "")
+	ctx := context.Background()
+
+	var records []Record
+	for i := 0; i < 6; i++ {
+		records = append(records, Record{ID: fmt.Sprintf("%03d", i), Vector: []float32{float32(i), 0, 1}})
+	}
+	if err := adapter.UpsertBatch(ctx, records); err != nil {
+		t.Fatalf("Failed to upsert: %v", err)
+	}
+
+	first, err := adapter.GetBatch(ctx, "", 3)
+	if err != nil {
+		t.Fatalf("Failed to get batch: %v", err)
+	}
+	afterID := first[len(first)-1].ID
+
+	fake.mu.Lock()
+	fake.stall, fake.stallFor = "HGETALL", 200*time.Millisecond
+	fake.mu.Unlock()
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if _, err := adapter.GetBatch(timeoutCtx, afterID, 3); err == nil {
+		t.Fatal("Expected the stalled fetch to time out")
+	}
+
+	seen := append([]Record{}, first...)
+	for {
+		batch, err := adapter.GetBatch(ctx, afterID, 3)
+		if err != nil {
+			t.Fatalf("Failed to retry batch: %v", err)
+		}
+		if len(batch) == 0 {
+			break
+		}
+		seen = append(seen, batch...)
+		afterID = batch[len(batch)-1].ID
+	}
+
+	assertSameRecords(t, seen, records)
+
+	t.Log("✓ RedisAdapter redials and rereads keys after a failed fetch")
+}
+
+// TestRedisAdapterUpsertTransaction tests that each document is replaced in
+// its own transaction, so a failed write leaves the old document in place
+func TestRedisAdapterUpsertTransaction(t *testing.T) {
+	fake, url := newFakeRedis(t, "HASH", "")
+	adapter := connectRedis(t, url, "")
+	ctx := context.Background()
+
+	err := adapter.UpsertBatch(ctx, []Record{
+		{ID: "a", Vector: []float32{1, 2, 3}, Metadata: map[string]interface{}{"title": "old", "stale": "x"}},
+		{ID: "b", Vector: []float32{4, 5, 6}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to upsert: %v", err)
+	}
+	if fake.execs != 2 {
+		t.Errorf("Expected one transaction per document, got %d", fake.execs)
+	}
+
+	// Replacing a document drops fields it no longer has
+	if err := adapter.UpsertBatch(ctx, []Record{{ID: "a", Vector: []float32{1, 2, 3}, Metadata: map[string]interface{}{"title": "new"}}}); err != nil {
+		t.Fatalf("Failed to upsert: %v", err)
+	}
+	if hash := fake.hashes["doc:a"]; hash["title"] != "new" || hash["stale"] != "" {
+		t.Errorf("Expected doc:a replaced, got %v", hash)
+	}
+
+	// A write that fails does not delete the document it replaces
+	fake.mu.Lock()
+	fake.reject = "doc:b"
+	fake.mu.Unlock()
+	err = adapter.UpsertBatch(ctx, []Record{{ID: "b", Vector: []float32{7, 8, 9}}})
+	if err == nil {
+		t.Fatal("Expected the rejected write to fail")
+	}
+	if _, ok := fake.hashes["doc:b"]; !ok {
+		t.Error("Expected doc:b to survive the failed write")
+	}
+
+	t.Log("✓ RedisAdapter replaces each document in a transaction")
+}
+
+// TestFloat32Blob tests FLOAT32 blob encoding
+func TestFloat32Blob(t *testing.T) {
+	vector := []float32{0.1, -3.5, 1e-7}
+
+	decoded, err := decodeFloat32Blob(encodeFloat32Blob(vector))
+	if err != nil {
+		t.Fatalf("Failed to decode: %v", err)
+	}
+
+	for i := range vector {
+		if decoded[i] != vector[i] {
+			t.Errorf("Expected element %d to be %v, got %v", i, vector[i], decoded[i])
+		}
+	}
+
+	if _, err := decodeFloat32Blob([]byte{1, 2, 3}); err == nil {
+		t.Error("Expected error for truncated blob")
+	}
+
+	t.Log("✓ FLOAT32 blobs round trip")
+}
@@ -0,0 +1,116 @@
+package adapters
+
+import (
+	"strings"
+)
+
+// Distance metrics, normalised across backends
+const (
+	DistanceCosine    = "cosine"
+	DistanceDot       = "dot"
+	DistanceEuclidean = "euclidean"
+	DistanceManhattan = "manhattan"
+	DistanceHamming   = "hamming"
+)
+
+// HNSWParams holds HNSW index build parameters; zero values leave the
+// backend default
+type HNSWParams struct {
+	M              int `json:"m,omitempty"`
+	EfConstruction int `json:"ef_construction,omitempty"`
+}
+
+// PropertyType is the type of a metadata property
+type PropertyType string
+
+const (
+	PropertyText   PropertyType = "text"
+	PropertyInt    PropertyType = "int"
+	PropertyNumber PropertyType = "number"
+	PropertyBool   PropertyType = "bool"
+	PropertyDate   PropertyType = "date"
+	PropertyObject PropertyType = "object"
+
+	// PropertyMixed marks a sampled field whose values differ in type
+	PropertyMixed PropertyType = "mixed"
+)
+
+// PropertySpec defines a typed metadata property
+type PropertySpec struct {
+	Name  string       `json:"name"`
+	Type  PropertyType `json:"type"`
+	Array bool         `json:"array,omitempty"`
+
+	// Nested holds the properties of an object property
+	Nested []PropertySpec `json:"nested,omitempty"`
+}
+
+// CollectionSpec describes the collection, class or index a
+// SchemaProvisioner creates for the records it will receive
+type CollectionSpec struct {
+	// Dimensions of the unnamed vector (0 if records only have named vectors)
+	Dimensions int `json:"dimensions"`
+
+	// Distance is one of the Distance constants; empty means cosine
+	Distance string `json:"distance,omitempty"`
+
+	// NamedVectors maps vector names to dimensions
+	NamedVectors map[string]int `json:"named_vectors,omitempty"`
+
+	// SparseVectors lists named sparse vectors
+	SparseVectors []string `json:"sparse_vectors,omitempty"`
+
+	// HNSW index parameters, if the source reported any
+	HNSW *HNSWParams `json:"hnsw,omitempty"`
+
+	// Properties are typed metadata definitions for backends with schemas
+	Properties []PropertySpec `json:"properties,omitempty"`
+}
+
+// FieldSchema describes a metadata field as a source stores it
+type FieldSchema struct {
+	// Path holds the field's key and, for fields of nested objects, the
+	// keys of its parents, outermost first
+	Path  []string     `json:"path"`
+	Type  PropertyType `json:"type"`
+	Array bool         `json:"array,omitempty"`
+
+	// Nullable is true if the field may be missing or null
+	Nullable bool `json:"nullable"`
+
+	// Cardinality estimates the number of distinct values; 0 if unknown.
+	// Sampled schemas count the distinct values in the sample.
+	Cardinality int64 `json:"cardinality,omitempty"`
+}
+
+// Name returns the field's dot-separated path
+func (f FieldSchema) Name() string {
+	return strings.Join(f.Path, ".")
+}
+
+// Schema describes the metadata fields of a database
+type Schema struct {
+	// Fields in path order, each object field before its nested fields
+	Fields []FieldSchema `json:"fields"`
+
+	// Sampled is the number of records the schema was inferred from, or 0
+	// if it was read from a native schema
+	Sampled int `json:"sampled"`
+}
+
+// TopLevel returns the types of the top-level fields by name, the form
+// schema mappers take
+func (s *Schema) TopLevel() map[string]interface{} {
+	fields := make(map[string]interface{})
+	for _, field := range s.Fields {
+		if len(field.Path) != 1 {
+			continue
+		}
+		typ := string(field.Type)
+		if field.Array {
+			typ += "[]"
+		}
+		fields[field.Path[0]] = typ
+	}
+	return fields
+}
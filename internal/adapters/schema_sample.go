@@ -0,0 +1,250 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultSchemaSample is the number of records sampled to infer a schema;
+// Extra["schema_sample"] overrides it for adapters that sample
+const DefaultSchemaSample = 1000
+
+// schemaSampleSize returns the configured schema sample size
+func schemaSampleSize(config DBConfig) int {
+	if n, err := strconv.Atoi(config.Extra["schema_sample"]); err == nil && n > 0 {
+		return n
+	}
+	return DefaultSchemaSample
+}
+
+// SampleSchema infers the schema of db's metadata from its first n records,
+// paging by cursor if db supports it. Integer and fractional numbers widen
+// to number; fields whose values otherwise disagree are PropertyMixed.
+func SampleSchema(ctx context.Context, db Database, n int) (*Schema, error) {
+	sampler := newSchemaSampler()
+
+	cursorReader, useCursor := db.(CursorReader)
+	var afterID, cursor string
+	for sampler.records < n {
+		limit := n - sampler.records
+		if limit > 100 {
+			limit = 100
+		}
+
+		var records []Record
+		var err error
+		if useCursor {
+			records, cursor, err = cursorReader.GetBatchCursor(ctx, cursor, limit)
+		} else {
+			records, err = db.GetBatch(ctx, afterID, limit)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to sample records: %w", err)
+		}
+
+		for _, record := range records {
+			sampler.add(record.Metadata)
+		}
+		if len(records) > 0 {
+			afterID = records[len(records)-1].ID
+		}
+
+		if useCursor && cursor == "" || !useCursor && len(records) == 0 {
+			break
+		}
+	}
+
+	return sampler.schema(), nil
+}
+
+// schemaSampler accumulates field statistics over sampled records
+type schemaSampler struct {
+	records int
+	fields  map[string]*sampledField
+
+	// objects counts the objects seen at each path, "" for records, so
+	// fields missing from some of them are nullable
+	objects map[string]int
+}
+
+// sampledField holds the statistics of one field path
+type sampledField struct {
+	path     []string
+	typ      PropertyType
+	array    bool
+	present  int
+	distinct map[string]bool
+}
+
+func newSchemaSampler() *schemaSampler {
+	return &schemaSampler{
+		fields:  make(map[string]*sampledField),
+		objects: make(map[string]int),
+	}
+}
+
+// add records one record's metadata
+func (s *schemaSampler) add(metadata map[string]interface{}) {
+	s.records++
+	s.addObject(nil, metadata)
+}
+
+func (s *schemaSampler) addObject(parent []string, object map[string]interface{}) {
+	s.objects[pathKey(parent)]++
+	for key, value := range object {
+		path := append(append([]string(nil), parent...), key)
+		s.addValue(path, value)
+	}
+}
+
+func (s *schemaSampler) addValue(path []string, value interface{}) {
+	typ, array, ok := ValueType(value)
+	if !ok {
+		return
+	}
+
+	key := pathKey(path)
+	field, seen := s.fields[key]
+	if !seen {
+		field = &sampledField{path: path, typ: typ, array: array, distinct: make(map[string]bool)}
+		s.fields[key] = field
+	}
+	field.present++
+	if seen {
+		field.typ, field.array = mergeTypes(field.typ, field.array, typ, array)
+	}
+
+	values := []interface{}{value}
+	if elems, ok := value.([]interface{}); ok {
+		values = elems
+	}
+	for _, v := range values {
+		if object, ok := v.(map[string]interface{}); ok {
+			s.addObject(path, object)
+		} else if v != nil {
+			field.distinct[distinctKey(v)] = true
+		}
+	}
+}
+
+// schema returns the sampled fields in path order
+func (s *schemaSampler) schema() *Schema {
+	keys := make([]string, 0, len(s.fields))
+	for key := range s.fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	schema := &Schema{Fields: make([]FieldSchema, 0, len(keys)), Sampled: s.records}
+	for _, key := range keys {
+		field := s.fields[key]
+		schema.Fields = append(schema.Fields, FieldSchema{
+			Path:        field.path,
+			Type:        field.typ,
+			Array:       field.array,
+			Nullable:    field.present < s.objects[pathKey(field.path[:len(field.path)-1])],
+			Cardinality: int64(len(field.distinct)),
+		})
+	}
+	return schema
+}
+
+// pathKey joins a path into a map key that sorts parents before children
+func pathKey(path []string) string {
+	return strings.Join(path, "\x00")
+}
+
+// distinctKey identifies a scalar value for cardinality counts
+func distinctKey(v interface{}) string {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(encoded)
+}
+
+// ValueType returns the property type of a metadata value and whether it
+// is a list. ok is false for null values and empty lists.
+func ValueType(value interface{}) (typ PropertyType, array bool, ok bool) {
+	switch v := value.(type) {
+	case nil:
+		return "", false, false
+	case []interface{}:
+		for _, elem := range v {
+			elemType, elemArray, elemOK := ValueType(elem)
+			if !elemOK {
+				continue
+			}
+			if elemArray {
+				return PropertyMixed, true, true
+			}
+			if typ == "" {
+				typ = elemType
+			} else {
+				typ, _ = mergeTypes(typ, false, elemType, false)
+			}
+		}
+		return typ, true, typ != ""
+	case []string:
+		return PropertyText, true, len(v) > 0
+	case []float64, []float32:
+		return PropertyNumber, true, true
+	case []int, []int64:
+		return PropertyInt, true, true
+	case []bool:
+		return PropertyBool, true, true
+	case map[string]interface{}:
+		return PropertyObject, false, true
+	}
+
+	typ, ok = ScalarType(value)
+	if !ok {
+		return PropertyMixed, false, true
+	}
+	return typ, false, true
+}
+
+// ScalarType returns the property type of a scalar value. JSON numbers
+// decode as float64 and are numbers; Go integers and integral json.Numbers
+// are ints.
+func ScalarType(value interface{}) (PropertyType, bool) {
+	switch v := value.(type) {
+	case string:
+		return PropertyText, true
+	case bool:
+		return PropertyBool, true
+	case float64, float32:
+		return PropertyNumber, true
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return PropertyInt, true
+	case json.Number:
+		if strings.ContainsAny(string(v), ".eE") {
+			return PropertyNumber, true
+		}
+		return PropertyInt, true
+	}
+	return "", false
+}
+
+// mergeTypes combines two observed types of a field: ints widen to
+// numbers, any other disagreement is PropertyMixed
+func mergeTypes(a PropertyType, aArray bool, b PropertyType, bArray bool) (PropertyType, bool) {
+	switch {
+	case aArray != bArray:
+		return PropertyMixed, aArray
+	case a == b:
+		return a, aArray
+	case isNumericType(a) && isNumericType(b):
+		return PropertyNumber, aArray
+	}
+	return PropertyMixed, aArray
+}
+
+// isNumericType reports whether typ is int or number
+func isNumericType(typ PropertyType) bool {
+	return typ == PropertyInt || typ == PropertyNumber
+}
@@ -0,0 +1,73 @@
+package adapters
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+// TestSampleSchema tests schema inference from sampled records
+func TestSampleSchema(t *testing.T) {
+	db := NewMemoryAdapter()
+	ctx := context.Background()
+	err := db.UpsertBatch(ctx, []Record{
+		{ID: "1", Metadata: map[string]interface{}{
+			"title":  "a",
+			"views":  3,
+			"tags":   []interface{}{"x", "y"},
+			"author": map[string]interface{}{"name": "ann", "age": 30},
+			"flag":   true,
+		}},
+		{ID: "2", Metadata: map[string]interface{}{
+			"title":  "b",
+			"views":  2.5,
+			"author": map[string]interface{}{"name": "bob"},
+			"flag":   "yes",
+		}},
+		{ID: "3", Metadata: map[string]interface{}{
+			"title": "a",
+			"views": nil,
+		}},
+	})
+	if err != nil {
+		t.Fatalf("UpsertBatch failed: %v", err)
+	}
+
+	schema, err := SampleSchema(ctx, db, 100)
+	if err != nil {
+		t.Fatalf("SampleSchema failed: %v", err)
+	}
+	if schema.Sampled != 3 {
+		t.Errorf("Expected 3 sampled records, got %d", schema.Sampled)
+	}
+
+	want := []FieldSchema{
+		{Path: []string{"author"}, Type: PropertyObject, Nullable: true},
+		{Path: []string{"author", "age"}, Type: PropertyInt, Nullable: true, Cardinality: 1},
+		{Path: []string{"author", "name"}, Type: PropertyText, Cardinality: 2},
+		{Path: []string{"flag"}, Type: PropertyMixed, Nullable: true, Cardinality: 2},
+		{Path: []string{"tags"}, Type: PropertyText, Array: true, Nullable: true, Cardinality: 2},
+		{Path: []string{"title"}, Type: PropertyText, Cardinality: 2},
+		{Path: []string{"views"}, Type: PropertyNumber, Nullable: true, Cardinality: 2},
+	}
+	if !reflect.DeepEqual(schema.Fields, want) {
+		t.Errorf("Expected fields\n%+v\ngot\n%+v", want, schema.Fields)
+	}
+
+	wantTop := map[string]interface{}{
+		"author": "object", "flag": "mixed", "tags": "text[]", "title": "text", "views": "number",
+	}
+	if top := schema.TopLevel(); !reflect.DeepEqual(top, wantTop) {
+		t.Errorf("Expected top-level types %v, got %v", wantTop, top)
+	}
+
+	limited, err := SampleSchema(ctx, db, 1)
+	if err != nil {
+		t.Fatalf("SampleSchema failed: %v", err)
+	}
+	if limited.Sampled != 1 || len(limited.Fields) != 7 {
+		t.Errorf("Expected 7 fields from 1 record, got %d from %d", len(limited.Fields), limited.Sampled)
+	}
+
+	t.Log("✓ SampleSchema infers types, nullability and cardinality")
+}
@@ -0,0 +1,133 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// SearchResult is one hit of a similarity search
+type SearchResult struct {
+	ID string `json:"id"`
+
+	// Score is the database's own relevance score: a similarity (higher is
+	// closer) or a distance (lower is closer) depending on the backend and
+	// metric. Results are always ranked closest first, so compare ranks,
+	// not scores, across databases.
+	Score float32 `json:"score"`
+}
+
+// Filter restricts a search to records whose metadata field equals the
+// given value for every entry. Values are strings, numbers or booleans.
+type Filter map[string]interface{}
+
+// Searcher is implemented by databases that answer nearest-neighbour
+// queries on their dense vector, e.g. to compare the results of the same
+// query against the source and target of a migration
+type Searcher interface {
+	// Search returns up to k records closest to vector that match filter
+	// (nil for no filter), closest first
+	Search(ctx context.Context, vector []float32, k int, filter Filter) ([]SearchResult, error)
+}
+
+// Search ranks the stored records by cosine similarity to vector with an
+// exact scan. Records without a dense vector of the same dimension are
+// skipped.
+func (a *MemoryAdapter) Search(ctx context.Context, vector []float32, k int, filter Filter) ([]SearchResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	results := make([]SearchResult, 0, len(a.records))
+	for id, r := range a.records {
+		if len(r.Vector) != len(vector) || !filter.Matches(r.Metadata) {
+			continue
+		}
+		results = append(results, SearchResult{ID: id, Score: cosineSimilarity(vector, r.Vector)})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].ID < results[j].ID
+	})
+	if len(results) > k {
+		results = results[:k]
+	}
+	return results, nil
+}
+
+// Matches reports whether metadata satisfies every condition of the filter.
+// Numbers compare by value whatever their Go type.
+func (f Filter) Matches(metadata map[string]interface{}) bool {
+	for field, want := range f {
+		got, ok := metadata[field]
+		if !ok {
+			return false
+		}
+		if gotNumber, ok := filterNumber(got); ok {
+			if wantNumber, ok := filterNumber(want); ok && gotNumber == wantNumber {
+				continue
+			}
+			return false
+		}
+		if got != want {
+			return false
+		}
+	}
+	return true
+}
+
+// validate checks that every filter value is a string, number or boolean
+func (f Filter) validate() error {
+	for field, value := range f {
+		switch value.(type) {
+		case string, bool:
+		default:
+			if _, ok := filterNumber(value); !ok {
+				return fmt.Errorf("filter on %q: unsupported value type %T", field, value)
+			}
+		}
+	}
+	return nil
+}
+
+// filterNumber converts numeric filter and metadata values to float64
+func filterNumber(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+// cosineSimilarity returns the cosine of the angle between a and b, 0 if
+// either is the zero vector
+func cosineSimilarity(a, b []float32) float32 {
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return float32(dot / (math.Sqrt(normA) * math.Sqrt(normB)))
+}
+
+// Ensure MemoryAdapter implements Searcher interface
+var _ Searcher = (*MemoryAdapter)(nil)
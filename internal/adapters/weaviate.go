@@ -6,17 +6,33 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
+	neturl "net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 
 // WeaviateAdapter implements Database interface for Weaviate
+//
+// Reads page through a class with the GraphQL Get cursor (after: <last ID>),
+// selecting every property in the class schema; writes go through
+// /v1/batch/objects. For multi-tenant classes, Extra["tenant"] selects the
+// tenant, which is created on connect if missing.
 type WeaviateAdapter struct {
 	config     DBConfig
 	httpClient *http.Client
 	baseURL    string
 	sourceURL  string
 	className  string
+	tenant     string
+	
+	// Property selection for Get queries, loaded from the class schema at
+	// the start of each scan
+	selection string
 }
 
 // weaviateObject represents Weaviate's object format
@@ -25,27 +41,63 @@ type weaviateObject struct {
 	ID         string                 `json:"id"`
 	Vector     []float32              `json:"vector"`
 	Properties map[string]interface{} `json:"properties"`
+	Tenant     string                 `json:"tenant,omitempty"`
+}
+
+// weaviateProperty represents a property in a Weaviate class schema
+type weaviateProperty struct {
+	Name             string             `json:"name"`
+	DataType         []string           `json:"dataType"`
+	NestedProperties []weaviateProperty `json:"nestedProperties,omitempty"`
 }
 
-// weaviateGetResponse represents Weaviate get response
-type weaviateGetResponse struct {
-	Result []struct {
-		ID         string                 `json:"id"`
-		Vector     []float32              `json:"vector"`
-		Properties map[string]interface{} `json:"properties"`
-	} `json:"data"`
+// weaviateClass represents a Weaviate class schema
+type weaviateClass struct {
+	Class             string `json:"class"`
+	Vectorizer        string `json:"vectorizer,omitempty"`
+	VectorIndexType   string `json:"vectorIndexType"`
+	VectorIndexConfig struct {
+		Distance       string `json:"distance,omitempty"`
+		MaxConnections int    `json:"maxConnections,omitempty"`
+		EfConstruction int    `json:"efConstruction,omitempty"`
+	} `json:"vectorIndexConfig"`
+	Properties         []weaviateProperty `json:"properties"`
+	MultiTenancyConfig struct {
+		Enabled bool `json:"enabled"`
+	} `json:"multiTenancyConfig"`
 }
 
+// weaviateBatchResult represents one object's result in a batch response
+type weaviateBatchResult struct {
+	ID     string `json:"id"`
+	Result struct {
+		Errors *struct {
+			Error []struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		} `json:"errors"`
+	} `json:"result"`
+}
+
+// weaviateNamePattern matches valid GraphQL class and property names
+var weaviateNamePattern = regexp.MustCompile(`^[_A-Za-z][_0-9A-Za-z]*$`)
+
 // Connect establishes connection to Weaviate
 func (a *WeaviateAdapter) Connect(ctx context.Context, config DBConfig) error {
 	if config.Type != "weaviate" {
 		return fmt.Errorf("expected type 'weaviate', got '%s'", config.Type)
 	}
 	
+	// The class name is interpolated into GraphQL queries
+	if !weaviateNamePattern.MatchString(config.Index) {
+		return fmt.Errorf("invalid Weaviate class name %q", config.Index)
+	}
+	
 	a.config = config
 	a.sourceURL = config.URL
-	a.baseURL = config.URL
+	a.baseURL = strings.TrimSuffix(config.URL, "/")
 	a.className = config.Index // Weaviate uses "class" instead of "index"
+	a.tenant = config.Extra["tenant"]
 	
 	// Create HTTP client with timeout
 	timeout := time.Duration(config.Timeout) * time.Second
@@ -63,7 +115,20 @@ func (a *WeaviateAdapter) Connect(ctx context.Context, config DBConfig) error {
 	}
 	
 	// Validate connection
-	return a.ValidateConnection(ctx)
+	if err := a.ValidateConnection(ctx); err != nil {
+		return err
+	}
+	
+	if a.tenant == "" {
+		return nil
+	}
+	
+	// A missing class gets the tenant when EnsureCollection creates it
+	class, err := a.lookupClass(ctx)
+	if err != nil || class == nil {
+		return err
+	}
+	return a.ensureTenant(ctx)
 }
 
 // Close closes the HTTP client
@@ -74,136 +139,285 @@ func (a *WeaviateAdapter) Close() error {
 	return nil
 }
 
-// GetBatch retrieves a batch of objects from Weaviate
+// GetBatch retrieves a batch of objects from Weaviate after the given ID,
+// with every property of the class as metadata. Unset properties are
+// omitted rather than returned as null.
 func (a *WeaviateAdapter) GetBatch(ctx context.Context, afterID string, limit int) ([]Record, error) {
-	// Use GraphQL-style query via REST
-	query := fmt.Sprintf(`
-		{
-			Get {
-				%s(limit: %d, after: "%s") {
-					_additional {
-						id
-						vector
-					}
-				}
-			}
+	// A new scan picks up properties added since the last one
+	if afterID == "" || a.selection == "" {
+		class, err := a.getClass(ctx)
+		if err != nil {
+			return nil, err
 		}
-	`, a.className, limit, afterID)
+		if class.MultiTenancyConfig.Enabled && a.tenant == "" {
+			return nil, fmt.Errorf("Weaviate class %s is multi-tenant; set the tenant extra", a.className)
+		}
+		a.selection = weaviateSelection(class.Properties)
+	}
 	
-	request := struct {
-		Query string `json:"query"`
-	}{
-		Query: query,
+	args := []string{fmt.Sprintf("limit: %d", limit)}
+	if afterID != "" {
+		args = append(args, "after: "+graphqlString(afterID))
+	}
+	if a.tenant != "" {
+		args = append(args, "tenant: "+graphqlString(a.tenant))
 	}
 	
-	jsonData, err := json.Marshal(request)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	query := fmt.Sprintf("{ Get { %s(%s) { %s _additional { id vector } } } }",
+		a.className, strings.Join(args, ", "), a.selection)
+	
+	var data struct {
+		Get map[string][]map[string]json.RawMessage `json:"Get"`
+	}
+	if err := a.graphql(ctx, query, &data); err != nil {
+		return nil, err
 	}
 	
-	url := fmt.Sprintf("%s/v1/graphql", a.baseURL)
-	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
+	// Convert to our Record format
+	items := data.Get[a.className]
+	records := make([]Record, 0, len(items))
+	for _, item := range items {
+		var additional struct {
+			ID     string    `json:"id"`
+			Vector []float32 `json:"vector"`
+		}
+		if err := json.Unmarshal(item["_additional"], &additional); err != nil {
+			return nil, fmt.Errorf("failed to decode object metadata: %w", err)
+		}
+		
+		record := Record{
+			ID:       additional.ID,
+			Vector:   additional.Vector,
+			Metadata: make(map[string]interface{}, len(item)),
+		}
+		
+		// Copy properties to metadata
+		for key, raw := range item {
+			if key == "_additional" {
+				continue
+			}
+			var value interface{}
+			if err := json.Unmarshal(raw, &value); err != nil {
+				return nil, fmt.Errorf("failed to decode property %s of %s: %w", key, record.ID, err)
+			}
+			if value != nil {
+				record.Metadata[key] = value
+			}
+		}
+		
+		records = append(records, record)
+	}
+	
+	return records, nil
+}
+
+// FetchByIDs reads objects one by one from the objects endpoint. IDs that
+// are not UUIDs cannot name an object and are reported missing. As with
+// GetBatch, null properties and cross-references are left out.
+func (a *WeaviateAdapter) FetchByIDs(ctx context.Context, ids []string) ([]Record, []string, error) {
+	class, err := a.getClass(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, nil, err
+	}
+	if class.MultiTenancyConfig.Enabled && a.tenant == "" {
+		return nil, nil, fmt.Errorf("Weaviate class %s is multi-tenant; set the tenant extra", a.className)
 	}
 	
-	req.Header.Set("Content-Type", "application/json")
-	if a.config.APIKey != "" {
-		req.Header.Set("Authorization", "Bearer "+a.config.APIKey)
+	references := make(map[string]bool)
+	for _, p := range class.Properties {
+		if len(p.DataType) > 0 && p.DataType[0] != "" && strings.ToUpper(p.DataType[0][:1]) == p.DataType[0][:1] {
+			references[p.Name] = true
+		}
 	}
 	
-	resp, err := a.httpClient.Do(req)
+	return fetchByIDs(ids, 0, func(chunk []string) ([]Record, error) {
+		records := make([]Record, 0, len(chunk))
+		for _, id := range chunk {
+			if !weaviateCapabilities.AcceptsID(id) {
+				continue
+			}
+			object, err := a.getObject(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			if object == nil {
+				continue
+			}
+			
+			record := Record{
+				ID:       id,
+				Vector:   object.Vector,
+				Metadata: make(map[string]interface{}, len(object.Properties)),
+			}
+			for key, value := range object.Properties {
+				if value != nil && !references[key] {
+					record.Metadata[key] = value
+				}
+			}
+			records = append(records, record)
+		}
+		return records, nil
+	})
+}
+
+// Search runs a nearVector Get query for the k closest objects. Score is
+// the distance Weaviate reports, so lower is closer. Filter conditions
+// become Equal operands of a where filter.
+func (a *WeaviateAdapter) Search(ctx context.Context, vector []float32, k int, filter Filter) ([]SearchResult, error) {
+	if err := filter.validate(); err != nil {
+		return nil, err
+	}
+	
+	encoded, err := json.Marshal(vector)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query Weaviate: %w", err)
+		return nil, fmt.Errorf("failed to encode query vector: %w", err)
 	}
-	defer resp.Body.Close()
 	
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("Weaviate API error (%d): %s", resp.StatusCode, string(body))
+	args := []string{
+		fmt.Sprintf("nearVector: { vector: %s }", encoded),
+		fmt.Sprintf("limit: %d", k),
+	}
+	if len(filter) > 0 {
+		where, err := weaviateWhere(filter)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, "where: "+where)
+	}
+	if a.tenant != "" {
+		args = append(args, "tenant: "+graphqlString(a.tenant))
 	}
 	
-	var graphqlResp struct {
-		Data struct {
-			Get []map[string]interface{} `json:"Get"`
-		} `json:"data"`
-		Errors []struct {
-			Message string `json:"message"`
-		} `json:"errors,omitempty"`
+	query := fmt.Sprintf("{ Get { %s(%s) { _additional { id distance } } } }", a.className, strings.Join(args, ", "))
+	
+	var data struct {
+		Get map[string][]struct {
+			Additional struct {
+				ID       string  `json:"id"`
+				Distance float32 `json:"distance"`
+			} `json:"_additional"`
+		} `json:"Get"`
+	}
+	if err := a.graphql(ctx, query, &data); err != nil {
+		return nil, err
 	}
 	
-	if err := json.NewDecoder(resp.Body).Decode(&graphqlResp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	items := data.Get[a.className]
+	results := make([]SearchResult, len(items))
+	for i, item := range items {
+		results[i] = SearchResult{ID: item.Additional.ID, Score: item.Additional.Distance}
+	}
+	return results, nil
+}
+
+// weaviateWhere builds a GraphQL where argument requiring every filter
+// condition
+func weaviateWhere(filter Filter) (string, error) {
+	operands := make([]string, 0, len(filter))
+	for _, field := range sortedKeys(filter) {
+		if !weaviateNamePattern.MatchString(field) {
+			return "", fmt.Errorf("invalid Weaviate property name %q in filter", field)
+		}
+		
+		var value string
+		switch v := filter[field].(type) {
+		case string:
+			value = "valueText: " + graphqlString(v)
+		case bool:
+			value = fmt.Sprintf("valueBoolean: %t", v)
+		default:
+			n, _ := filterNumber(v)
+			if n == math.Trunc(n) {
+				value = fmt.Sprintf("valueInt: %d", int64(n))
+			} else {
+				value = "valueNumber: " + strconv.FormatFloat(n, 'g', -1, 64)
+			}
+		}
+		operands = append(operands, fmt.Sprintf("{ path: [%s], operator: Equal, %s }", graphqlString(field), value))
 	}
 	
-	if len(graphqlResp.Errors) > 0 {
-		return nil, fmt.Errorf("Weaviate GraphQL error: %s", graphqlResp.Errors[0].Message)
+	if len(operands) == 1 {
+		return operands[0], nil
+	}
+	return "{ operator: And, operands: [" + strings.Join(operands, ", ") + "] }", nil
+}
+
+// getObject reads one object with its vector, returning nil if it does
+// not exist
+func (a *WeaviateAdapter) getObject(ctx context.Context, id string) (*weaviateObject, error) {
+	query := neturl.Values{}
+	query.Set("include", "vector")
+	if a.tenant != "" {
+		query.Set("tenant", a.tenant)
 	}
 	
-	// Extract objects from response
-	objects := graphqlResp.Data.Get
-	if len(objects) == 0 {
-		return []Record{}, nil
+	path := "/v1/objects/" + a.className + "/" + neturl.PathEscape(id) + "?" + query.Encode()
+	req, err := http.NewRequestWithContext(ctx, "GET", a.baseURL+path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 	
-	// Get the class data
-	classData, ok := objects[0][a.className]
-	if !ok {
-		return []Record{}, nil
+	a.setHeaders(req)
+	
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Weaviate object %s: %w", id, err)
 	}
+	defer resp.Body.Close()
 	
-	items, ok := classData.([]interface{})
-	if !ok {
-		return []Record{}, nil
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to read Weaviate object %s: %w", id, apiError("Weaviate", resp))
 	}
 	
-	// Convert to our Record format
-	records := make([]Record, 0, len(items))
-	for _, item := range items {
-		itemMap, ok := item.(map[string]interface{})
-		if !ok {
+	var object weaviateObject
+	if err := json.NewDecoder(resp.Body).Decode(&object); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &object, nil
+}
+
+// weaviateSelection builds the GraphQL selection for a class's properties.
+// Object properties select their nested properties; cross-references,
+// which need a fragment per target class, are skipped.
+func weaviateSelection(properties []weaviateProperty) string {
+	fields := make([]string, 0, len(properties))
+	for _, p := range properties {
+		if !weaviateNamePattern.MatchString(p.Name) || len(p.DataType) == 0 {
 			continue
 		}
 		
-		record := Record{
-			Metadata: make(map[string]interface{}),
-		}
-		
-		// Extract ID and vector from _additional
-		if additional, ok := itemMap["_additional"].(map[string]interface{}); ok {
-			if id, ok := additional["id"].(string); ok {
-				record.ID = id
-			}
-			if vector, ok := additional["vector"].([]interface{}); ok {
-				record.Vector = make([]float32, len(vector))
-				for i, v := range vector {
-					if vf, ok := v.(float64); ok {
-						record.Vector[i] = float32(vf)
-					}
-				}
-			}
-		}
-		
-		// Copy properties to metadata
-		for key, value := range itemMap {
-			if key != "_additional" {
-				record.Metadata[key] = value
+		switch dataType := strings.TrimSuffix(p.DataType[0], "[]"); {
+		case dataType == "":
+			// Malformed schema entry
+		case dataType == "object":
+			if nested := weaviateSelection(p.NestedProperties); nested != "" {
+				fields = append(fields, p.Name+" { "+nested+" }")
 			}
-		}
-		
-		if record.ID != "" {
-			records = append(records, record)
+		case dataType == "geoCoordinates":
+			fields = append(fields, p.Name+" { latitude longitude }")
+		case dataType == "phoneNumber":
+			fields = append(fields, p.Name+" { input defaultCountry }")
+		case strings.ToUpper(dataType[:1]) == dataType[:1]:
+			// Cross-reference to another class
+		default:
+			fields = append(fields, p.Name)
 		}
 	}
-	
-	return records, nil
+	return strings.Join(fields, " ")
 }
 
-// UpsertBatch inserts or updates objects in Weaviate
+// graphqlString quotes s as a GraphQL string literal
+func graphqlString(s string) string {
+	quoted, _ := json.Marshal(s)
+	return string(quoted)
+}
+
+// UpsertBatch inserts or updates objects in Weaviate. The batch endpoint
+// answers 200 even when objects fail, so each object's result is checked.
 func (a *WeaviateAdapter) UpsertBatch(ctx context.Context, records []Record) error {
-	// Batch upsert using REST API
-	url := fmt.Sprintf("%s/v1/batch/objects", a.baseURL)
-	
 	// Convert to Weaviate format
 	objects := make([]weaviateObject, len(records))
 	for i, r := range records {
@@ -212,66 +426,79 @@ func (a *WeaviateAdapter) UpsertBatch(ctx context.Context, records []Record) err
 			ID:         r.ID,
 			Vector:     r.Vector,
 			Properties: r.Metadata,
+			Tenant:     a.tenant,
 		}
 	}
 	
 	request := struct {
-		Fields []string        `json:"fields"`
+		Fields  []string         `json:"fields"`
 		Objects []weaviateObject `json:"objects"`
 	}{
 		Fields:  []string{"ALL"},
 		Objects: objects,
 	}
 	
-	jsonData, err := json.Marshal(request)
-	if err != nil {
-		return fmt.Errorf("failed to marshal payload: %w", err)
-	}
-	
-	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
-	
-	req.Header.Set("Content-Type", "application/json")
-	if a.config.APIKey != "" {
-		req.Header.Set("Authorization", "Bearer "+a.config.APIKey)
+	var results []weaviateBatchResult
+	if err := a.do(ctx, "POST", "/v1/batch/objects", request, &results); err != nil {
+		return fmt.Errorf("failed to batch upsert to Weaviate: %w", err)
 	}
 	
-	resp, err := a.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to batch upsert to Weaviate: %w", err)
+	var failures []string
+	for i, result := range results {
+		if result.Result.Errors == nil {
+			continue
+		}
+		messages := make([]string, len(result.Result.Errors.Error))
+		for j, e := range result.Result.Errors.Error {
+			messages[j] = e.Message
+		}
+		id := result.ID
+		if id == "" && i < len(records) {
+			id = records[i].ID
+		}
+		failures = append(failures, fmt.Sprintf("%s: %s", id, strings.Join(messages, "; ")))
 	}
-	defer resp.Body.Close()
 	
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("Weaviate API error (%d): %s", resp.StatusCode, string(body))
+	if len(failures) > 0 {
+		return fmt.Errorf("Weaviate batch: %d of %d objects failed: %s", len(failures), len(records), strings.Join(failures, ", "))
 	}
 	
 	return nil
 }
 
-// DeleteBatch deletes objects from Weaviate by IDs
+// DeleteBatch deletes objects from Weaviate by IDs with a batch delete
+// matching the ID list
 func (a *WeaviateAdapter) DeleteBatch(ctx context.Context, ids []string) error {
-	// Delete each object individually (Weaviate doesn't support batch delete by ID list)
-	for _, id := range ids {
-		url := fmt.Sprintf("%s/v1/objects/%s/%s", a.baseURL, a.className, id)
-		
-		req, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
-		if err != nil {
-			return fmt.Errorf("failed to create delete request: %w", err)
-		}
-		
-		if a.config.APIKey != "" {
-			req.Header.Set("Authorization", "Bearer "+a.config.APIKey)
-		}
-		
-		resp, err := a.httpClient.Do(req)
-		if err != nil {
-			return fmt.Errorf("failed to delete from Weaviate: %w", err)
-		}
-		resp.Body.Close()
+	if len(ids) == 0 {
+		return nil
+	}
+	
+	endpoint := "/v1/batch/objects"
+	if a.tenant != "" {
+		endpoint += "?tenant=" + neturl.QueryEscape(a.tenant)
+	}
+	
+	request := map[string]interface{}{
+		"match": map[string]interface{}{
+			"class": a.className,
+			"where": map[string]interface{}{
+				"operator":       "ContainsAny",
+				"path":           []string{"id"},
+				"valueTextArray": ids,
+			},
+		},
+	}
+	
+	var response struct {
+		Results struct {
+			Failed int `json:"failed"`
+		} `json:"results"`
+	}
+	if err := a.do(ctx, "DELETE", endpoint, request, &response); err != nil {
+		return fmt.Errorf("failed to delete from Weaviate: %w", err)
+	}
+	if response.Results.Failed > 0 {
+		return fmt.Errorf("Weaviate batch delete: %d objects failed", response.Results.Failed)
 	}
 	
 	return nil
@@ -287,9 +514,7 @@ func (a *WeaviateAdapter) ValidateConnection(ctx context.Context) error {
 		return fmt.Errorf("failed to create validation request: %w", err)
 	}
 	
-	if a.config.APIKey != "" {
-		req.Header.Set("Authorization", "Bearer "+a.config.APIKey)
-	}
+	a.setHeaders(req)
 	
 	resp, err := a.httpClient.Do(req)
 	if err != nil {
@@ -304,119 +529,457 @@ func (a *WeaviateAdapter) ValidateConnection(ctx context.Context) error {
 	return nil
 }
 
-// GetStats returns Weaviate statistics
+// GetStats returns Weaviate statistics. The schema does not record the
+// vector dimension, so it is read from one object.
 func (a *WeaviateAdapter) GetStats(ctx context.Context) (*DBStats, error) {
-	// Get class schema
-	url := fmt.Sprintf("%s/v1/schema/%s", a.baseURL, a.className)
-	
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	class, err := a.getClass(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create stats request: %w", err)
+		return nil, err
 	}
 	
-	if a.config.APIKey != "" {
-		req.Header.Set("Authorization", "Bearer "+a.config.APIKey)
+	// Get object count via aggregate query
+	query := fmt.Sprintf("{ Aggregate { %s%s { meta { count } } } }", a.className, a.tenantArgs())
+	
+	var data struct {
+		Aggregate map[string][]struct {
+			Meta struct {
+				Count int64 `json:"count"`
+			} `json:"meta"`
+		} `json:"Aggregate"`
+	}
+	if err := a.graphql(ctx, query, &data); err != nil {
+		return nil, fmt.Errorf("failed to get aggregate: %w", err)
 	}
 	
-	resp, err := a.httpClient.Do(req)
+	stats := &DBStats{
+		IndexType:   class.VectorIndexType,
+		MemoryUsage: 0, // Not available via API
+		Distance:    weaviateDistanceNames[class.VectorIndexConfig.Distance],
+		Details: map[string]interface{}{
+			"properties":   len(class.Properties),
+			"multi_tenant": class.MultiTenancyConfig.Enabled,
+		},
+	}
+	if a.tenant != "" {
+		stats.Details["tenant"] = a.tenant
+	}
+	if config := class.VectorIndexConfig; config.MaxConnections > 0 || config.EfConstruction > 0 {
+		stats.HNSW = &HNSWParams{M: config.MaxConnections, EfConstruction: config.EfConstruction}
+	}
+	
+	if counts := data.Aggregate[a.className]; len(counts) > 0 {
+		stats.TotalRecords = counts[0].Meta.Count
+	}
+	
+	if stats.TotalRecords > 0 {
+		sample, err := a.GetBatch(ctx, "", 1)
+		if err != nil {
+			return nil, err
+		}
+		if len(sample) > 0 {
+			stats.Dimensions = len(sample[0].Vector)
+		}
+	}
+	
+	return stats, nil
+}
+
+// GetSourceURL returns the Weaviate source URL
+func (a *WeaviateAdapter) GetSourceURL() string {
+	return a.sourceURL
+}
+
+// weaviateCapabilities: objects have typed, possibly nested properties
+// and UUIDs
+var weaviateCapabilities = Capabilities{
+	NestedMetadata:  true,
+	IDFormats:       []IDFormat{IDUUID},
+	TypedProperties: true,
+}
+
+// Capabilities returns what Weaviate can store
+func (a *WeaviateAdapter) Capabilities() Capabilities {
+	return weaviateCapabilities
+}
+
+// ListNamespaces returns the tenants of a multi-tenant class in name order
+func (a *WeaviateAdapter) ListNamespaces(ctx context.Context) ([]string, error) {
+	tenants, err := a.listTenants(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get stats from Weaviate: %w", err)
+		return nil, err
+	}
+	sort.Strings(tenants)
+	return tenants, nil
+}
+
+// WithNamespace returns an adapter sharing this connection that reads and
+// writes tenant
+func (a *WeaviateAdapter) WithNamespace(tenant string) Database {
+	extra := make(map[string]string, len(a.config.Extra)+1)
+	for k, v := range a.config.Extra {
+		extra[k] = v
+	}
+	extra["tenant"] = tenant
+	
+	scoped := &WeaviateAdapter{
+		config:     a.config,
+		httpClient: a.httpClient,
+		baseURL:    a.baseURL,
+		sourceURL:  a.sourceURL,
+		className:  a.className,
+		tenant:     tenant,
+	}
+	scoped.config.Extra = extra
+	return scoped
+}
+
+// EnsureCollection creates the class from spec if it does not exist, as
+// a multi-tenant class when a tenant is configured, and adds the tenant.
+// Weaviate takes the vector dimension from the first object written.
+func (a *WeaviateAdapter) EnsureCollection(ctx context.Context, spec CollectionSpec) error {
+	if len(spec.NamedVectors) > 0 || len(spec.SparseVectors) > 0 {
+		return fmt.Errorf("Weaviate class %s stores a single dense vector per object", a.className)
 	}
-	defer resp.Body.Close()
 	
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Weaviate API error (%d)", resp.StatusCode)
+	existing, err := a.lookupClass(ctx)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		if a.tenant != "" && !existing.MultiTenancyConfig.Enabled {
+			return fmt.Errorf("Weaviate class %s is not multi-tenant, cannot write tenant %s", a.className, a.tenant)
+		}
+		if a.tenant == "" && existing.MultiTenancyConfig.Enabled {
+			return fmt.Errorf("Weaviate class %s is multi-tenant, set extra.tenant to write to it", a.className)
+		}
+		if a.tenant == "" {
+			return nil
+		}
+		return a.ensureTenant(ctx)
 	}
 	
-	var classSchema struct {
-		Class             string `json:"class"`
-		VectorIndexType   string `json:"vectorIndexType"`
-		VectorIndexConfig struct {
-			Distance string `json:"distance"`
-		} `json:"vectorIndexConfig"`
-		Properties []struct {
-			Name     string `json:"name"`
-			DataType []string `json:"dataType"`
-		} `json:"properties"`
+	distance := DistanceCosine
+	if spec.Distance != "" {
+		distance = spec.Distance
+	}
+	weaviateDistance, ok := weaviateDistances[distance]
+	if !ok {
+		return fmt.Errorf("Weaviate does not support distance %q", spec.Distance)
 	}
 	
-	if err := json.NewDecoder(resp.Body).Decode(&classSchema); err != nil {
-		return nil, fmt.Errorf("failed to decode schema: %w", err)
+	class := weaviateClass{
+		Class:           a.className,
+		Vectorizer:      "none",
+		VectorIndexType: "hnsw",
+		Properties:      weaviateProperties(spec.Properties),
+	}
+	class.VectorIndexConfig.Distance = weaviateDistance
+	if spec.HNSW != nil {
+		class.VectorIndexConfig.MaxConnections = spec.HNSW.M
+		class.VectorIndexConfig.EfConstruction = spec.HNSW.EfConstruction
 	}
+	class.MultiTenancyConfig.Enabled = a.tenant != ""
 	
-	// Get object count via aggregate query
-	aggQuery := fmt.Sprintf(`
-		{
-			Aggregate {
-				%s {
-					meta {
-						count
-					}
-				}
+	var created weaviateClass
+	if err := a.do(ctx, "POST", "/v1/schema", class, &created); err != nil {
+		return fmt.Errorf("failed to create Weaviate class %s: %w", a.className, err)
+	}
+	
+	if a.tenant == "" {
+		return nil
+	}
+	return a.ensureTenant(ctx)
+}
+
+// weaviateDistances maps Distance constants to Weaviate distance names
+var weaviateDistances = map[string]string{
+	DistanceCosine:    "cosine",
+	DistanceDot:       "dot",
+	DistanceEuclidean: "l2-squared",
+	DistanceManhattan: "manhattan",
+	DistanceHamming:   "hamming",
+}
+
+// weaviateDistanceNames maps Weaviate distance names to Distance constants
+var weaviateDistanceNames = map[string]string{
+	"cosine":     DistanceCosine,
+	"dot":        DistanceDot,
+	"l2-squared": DistanceEuclidean,
+	"manhattan":  DistanceManhattan,
+	"hamming":    DistanceHamming,
+}
+
+// weaviateDataTypes maps property types to Weaviate data types
+var weaviateDataTypes = map[PropertyType]string{
+	PropertyText:   "text",
+	PropertyInt:    "int",
+	PropertyNumber: "number",
+	PropertyBool:   "boolean",
+	PropertyDate:   "date",
+	PropertyObject: "object",
+}
+
+// weaviateProperties converts property specs to Weaviate properties.
+// Properties Weaviate cannot name are left for auto-schema to reject.
+func weaviateProperties(specs []PropertySpec) []weaviateProperty {
+	properties := make([]weaviateProperty, 0, len(specs))
+	for _, spec := range specs {
+		dataType, ok := weaviateDataTypes[spec.Type]
+		if !ok || !weaviateNamePattern.MatchString(spec.Name) {
+			continue
+		}
+		if spec.Array {
+			dataType += "[]"
+		}
+		
+		property := weaviateProperty{Name: spec.Name, DataType: []string{dataType}}
+		if spec.Type == PropertyObject {
+			property.NestedProperties = weaviateProperties(spec.Nested)
+			if len(property.NestedProperties) == 0 {
+				continue
 			}
 		}
-	`, a.className)
-	
-	aggRequest := struct {
-		Query string `json:"query"`
-	}{
-		Query: aggQuery,
+		properties = append(properties, property)
+	}
+	return properties
+}
+
+// GetSchema reads the class's property definitions; a missing class has
+// no fields. Every Weaviate property is nullable, and cross-references
+// are not metadata so they are left out.
+func (a *WeaviateAdapter) GetSchema(ctx context.Context) (*Schema, error) {
+	class, err := a.lookupClass(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schema from Weaviate: %w", err)
 	}
 	
-	jsonData, err := json.Marshal(aggRequest)
+	schema := &Schema{Fields: []FieldSchema{}}
+	if class != nil {
+		schema.Fields = weaviateFields(nil, class.Properties)
+	}
+	return schema, nil
+}
+
+// weaviatePropertyTypes maps Weaviate data types, without the array
+// suffix, to property types
+var weaviatePropertyTypes = map[string]PropertyType{
+	"text":           PropertyText,
+	"string":         PropertyText,
+	"uuid":           PropertyText,
+	"blob":           PropertyText,
+	"int":            PropertyInt,
+	"number":         PropertyNumber,
+	"boolean":        PropertyBool,
+	"date":           PropertyDate,
+	"object":         PropertyObject,
+	"geoCoordinates": PropertyObject,
+	"phoneNumber":    PropertyObject,
+}
+
+// weaviateFields converts class properties under parent to fields, each
+// object before its nested properties
+func weaviateFields(parent []string, properties []weaviateProperty) []FieldSchema {
+	sorted := append([]weaviateProperty(nil), properties...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	
+	var fields []FieldSchema
+	for _, property := range sorted {
+		if len(property.DataType) == 0 {
+			continue
+		}
+		dataType := strings.TrimSuffix(property.DataType[0], "[]")
+		typ, ok := weaviatePropertyTypes[dataType]
+		if !ok {
+			continue
+		}
+		
+		path := append(append([]string(nil), parent...), property.Name)
+		fields = append(fields, FieldSchema{
+			Path:     path,
+			Type:     typ,
+			Array:    dataType != property.DataType[0],
+			Nullable: true,
+		})
+		
+		if dataType == "geoCoordinates" {
+			property.NestedProperties = []weaviateProperty{
+				{Name: "latitude", DataType: []string{"number"}},
+				{Name: "longitude", DataType: []string{"number"}},
+			}
+		}
+		fields = append(fields, weaviateFields(path, property.NestedProperties)...)
+	}
+	return fields
+}
+
+// getClass reads the class schema
+func (a *WeaviateAdapter) getClass(ctx context.Context) (*weaviateClass, error) {
+	class, err := a.lookupClass(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal aggregate request: %w", err)
+		return nil, err
+	}
+	if class == nil {
+		return nil, fmt.Errorf("Weaviate class %s does not exist", a.className)
 	}
+	return class, nil
+}
+
+// lookupClass reads the class schema, returning nil if the class does not
+// exist
+func (a *WeaviateAdapter) lookupClass(ctx context.Context) (*weaviateClass, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", a.baseURL+"/v1/schema/"+a.className, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	
+	a.setHeaders(req)
 	
-	aggURL := fmt.Sprintf("%s/v1/graphql", a.baseURL)
-	aggReq, err := http.NewRequestWithContext(ctx, "POST", aggURL, bytes.NewBuffer(jsonData))
+	resp, err := a.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create aggregate request: %w", err)
+		return nil, fmt.Errorf("failed to read Weaviate class %s: %w", a.className, err)
 	}
+	defer resp.Body.Close()
 	
-	aggReq.Header.Set("Content-Type", "application/json")
-	if a.config.APIKey != "" {
-		aggReq.Header.Set("Authorization", "Bearer "+a.config.APIKey)
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to read Weaviate class %s: %w", a.className, apiError("Weaviate", resp))
 	}
 	
-	aggResp, err := a.httpClient.Do(aggReq)
+	var class weaviateClass
+	if err := json.NewDecoder(resp.Body).Decode(&class); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &class, nil
+}
+
+// tenantArgs returns the parenthesised GraphQL tenant argument, or ""
+// without a tenant
+func (a *WeaviateAdapter) tenantArgs() string {
+	if a.tenant == "" {
+		return ""
+	}
+	return "(tenant: " + graphqlString(a.tenant) + ")"
+}
+
+// listTenants returns the names of the class's tenants
+func (a *WeaviateAdapter) listTenants(ctx context.Context) ([]string, error) {
+	var tenants []struct {
+		Name string `json:"name"`
+	}
+	if err := a.do(ctx, "GET", "/v1/schema/"+a.className+"/tenants", nil, &tenants); err != nil {
+		return nil, fmt.Errorf("failed to list Weaviate tenants: %w", err)
+	}
+	
+	names := make([]string, len(tenants))
+	for i, t := range tenants {
+		names[i] = t.Name
+	}
+	return names, nil
+}
+
+// ensureTenant adds the configured tenant to the class if it is missing
+func (a *WeaviateAdapter) ensureTenant(ctx context.Context) error {
+	tenants, err := a.listTenants(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get aggregate: %w", err)
+		return err
+	}
+	for _, name := range tenants {
+		if name == a.tenant {
+			return nil
+		}
 	}
-	defer aggResp.Body.Close()
 	
-	var aggGraphQLResp struct {
-		Data struct {
-			Aggregate struct {
-				Class []struct {
-					Meta struct {
-						Count int64 `json:"count"`
-					} `json:"meta"`
-				} `json:""`
-			} `json:"Aggregate"`
-		} `json:"data"`
+	var added []map[string]string
+	if err := a.do(ctx, "POST", "/v1/schema/"+a.className+"/tenants", []map[string]string{{"name": a.tenant}}, &added); err != nil {
+		return fmt.Errorf("failed to add Weaviate tenant: %w", err)
 	}
 	
-	// Default stats if we can't get count
-	stats := &DBStats{
-		TotalRecords: 0,
-		Dimensions:   0, // Not available in schema
-		IndexType:    classSchema.VectorIndexType,
-		MemoryUsage:  0,
+	return nil
+}
+
+// graphql runs a GraphQL query and decodes its data into out. Weaviate
+// reports query errors in the body with status 200.
+func (a *WeaviateAdapter) graphql(ctx context.Context, query string, out interface{}) error {
+	var response struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors,omitempty"`
+	}
+	if err := a.do(ctx, "POST", "/v1/graphql", map[string]string{"query": query}, &response); err != nil {
+		return fmt.Errorf("failed to query Weaviate: %w", err)
 	}
 	
-	// Try to extract count
-	if len(aggGraphQLResp.Data.Aggregate.Class) > 0 {
-		stats.TotalRecords = aggGraphQLResp.Data.Aggregate.Class[0].Meta.Count
+	if len(response.Errors) > 0 {
+		return fmt.Errorf("Weaviate GraphQL error: %s", response.Errors[0].Message)
 	}
 	
-	return stats, nil
+	if err := json.Unmarshal(response.Data, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	
+	return nil
 }
 
-// GetSourceURL returns the Weaviate source URL
-func (a *WeaviateAdapter) GetSourceURL() string {
-	return a.sourceURL
+// setHeaders sets the JSON content type and, if configured, the API key
+func (a *WeaviateAdapter) setHeaders(req *http.Request) {
+	req.Header.Set("Content-Type", "application/json")
+	if a.config.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+a.config.APIKey)
+	}
+}
+
+// do sends a JSON request to path and decodes the response into out
+func (a *WeaviateAdapter) do(ctx context.Context, method, path string, body interface{}, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		jsonData, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reader = bytes.NewBuffer(jsonData)
+	}
+	
+	req, err := http.NewRequestWithContext(ctx, method, a.baseURL+path, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	
+	a.setHeaders(req)
+	
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	
+	if resp.StatusCode != http.StatusOK {
+		return apiError("Weaviate", resp)
+	}
+	
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	
+	return nil
 }
 
 // Ensure WeaviateAdapter implements Database interface
 var _ Database = (*WeaviateAdapter)(nil)
+
+// Ensure WeaviateAdapter implements Namespaced interface
+var _ Namespaced = (*WeaviateAdapter)(nil)
+
+// Ensure WeaviateAdapter implements SchemaProvisioner interface
+var _ SchemaProvisioner = (*WeaviateAdapter)(nil)
+
+// Ensure WeaviateAdapter implements SchemaReader interface
+var _ SchemaReader = (*WeaviateAdapter)(nil)
+
+// Ensure WeaviateAdapter implements CapabilityReporter interface
+var _ CapabilityReporter = (*WeaviateAdapter)(nil)
+
+// Ensure WeaviateAdapter implements Searcher interface
+var _ Searcher = (*WeaviateAdapter)(nil)
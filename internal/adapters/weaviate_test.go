@@ -0,0 +1,422 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/AlphaTechini/vector-db-migration/internal/adapters/fakes"
+)
+
+// TestWeaviateAdapterWrites tests connect, upsert and delete against the fake
+func TestWeaviateAdapterWrites(t *testing.T) {
+	fake := fakes.NewWeaviate(t)
+	fake.RequireAPIKey("secret")
+	if err := fake.CreateClass(map[string]interface{}{"class": "Article"}); err != nil {
+		t.Fatalf("CreateClass failed: %v", err)
+	}
+
+	adapter := &WeaviateAdapter{}
+	ctx := context.Background()
+	if err := adapter.Connect(ctx, DBConfig{Type: "weaviate", URL: fake.URL, APIKey: "secret", Index: "Article"}); err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer adapter.Close()
+
+	ids := []string{
+		"00000000-0000-4000-8000-000000000001",
+		"00000000-0000-4000-8000-000000000002",
+	}
+	records := []Record{
+		{ID: ids[0], Vector: []float32{1, 0}, Metadata: map[string]interface{}{"title": "first"}},
+		{ID: ids[1], Vector: []float32{0, 1}, Metadata: map[string]interface{}{"title": "second"}},
+	}
+	if err := adapter.UpsertBatch(ctx, records); err != nil {
+		t.Fatalf("UpsertBatch failed: %v", err)
+	}
+
+	objects := fake.Objects("Article", "")
+	if len(objects) != 2 || objects[0].Properties["title"] != "first" {
+		t.Fatalf("Unexpected stored objects: %+v", objects)
+	}
+
+	if err := adapter.DeleteBatch(ctx, ids[:1]); err != nil {
+		t.Fatalf("DeleteBatch failed: %v", err)
+	}
+	if objects := fake.Objects("Article", ""); len(objects) != 1 || objects[0].ID != ids[1] {
+		t.Errorf("Expected only %s to remain, got %+v", ids[1], objects)
+	}
+
+	t.Log("✓ WeaviateAdapter writes objects in batches")
+}
+
+// TestWeaviateAdapterTenant tests writes to a tenant created on connect
+func TestWeaviateAdapterTenant(t *testing.T) {
+	fake := fakes.NewWeaviate(t)
+	if err := fake.CreateClass(map[string]interface{}{
+		"class":              "Article",
+		"multiTenancyConfig": map[string]interface{}{"enabled": true},
+	}); err != nil {
+		t.Fatalf("CreateClass failed: %v", err)
+	}
+
+	adapter := &WeaviateAdapter{}
+	ctx := context.Background()
+	config := DBConfig{Type: "weaviate", URL: fake.URL, Index: "Article", Extra: map[string]string{"tenant": "acme"}}
+	if err := adapter.Connect(ctx, config); err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer adapter.Close()
+
+	// Connecting again finds the existing tenant
+	again := &WeaviateAdapter{}
+	if err := again.Connect(ctx, config); err != nil {
+		t.Fatalf("Failed to reconnect: %v", err)
+	}
+	defer again.Close()
+
+	id := "00000000-0000-4000-8000-000000000001"
+	if err := adapter.UpsertBatch(ctx, []Record{{ID: id, Vector: []float32{1, 0}}}); err != nil {
+		t.Fatalf("UpsertBatch failed: %v", err)
+	}
+	if objects := fake.Objects("Article", "acme"); len(objects) != 1 || objects[0].Tenant != "acme" {
+		t.Fatalf("Expected the object in tenant acme, got %+v", objects)
+	}
+
+	if err := adapter.DeleteBatch(ctx, []string{id}); err != nil {
+		t.Fatalf("DeleteBatch failed: %v", err)
+	}
+	if objects := fake.Objects("Article", "acme"); len(objects) != 0 {
+		t.Errorf("Expected tenant acme to be empty, got %+v", objects)
+	}
+
+	t.Log("✓ WeaviateAdapter writes to its tenant")
+}
+
+// connectWeaviate connects an adapter to class on a fake
+func connectWeaviate(t *testing.T, url, class, tenant string) *WeaviateAdapter {
+	t.Helper()
+
+	adapter := &WeaviateAdapter{}
+	config := DBConfig{Type: "weaviate", URL: url, Index: class}
+	if tenant != "" {
+		config.Extra = map[string]string{"tenant": tenant}
+	}
+	if err := adapter.Connect(context.Background(), config); err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	t.Cleanup(func() { adapter.Close() })
+	return adapter
+}
+
+// weaviateID returns a UUID numbered i
+func weaviateID(i int) string {
+	return fmt.Sprintf("00000000-0000-4000-8000-%012d", i)
+}
+
+// TestWeaviateAdapterReads tests that pages carry every property and
+// follow the after cursor
+func TestWeaviateAdapterReads(t *testing.T) {
+	fake := fakes.NewWeaviate(t)
+	err := fake.CreateClass(map[string]interface{}{
+		"class": "Article",
+		"properties": []interface{}{
+			map[string]interface{}{"name": "title", "dataType": []string{"text"}},
+			map[string]interface{}{"name": "wordCount", "dataType": []string{"int"}},
+			map[string]interface{}{"name": "author", "dataType": []string{"object"}, "nestedProperties": []interface{}{
+				map[string]interface{}{"name": "name", "dataType": []string{"text"}},
+			}},
+			map[string]interface{}{"name": "publishedIn", "dataType": []string{"Journal"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateClass failed: %v", err)
+	}
+	for i := 1; i <= 5; i++ {
+		properties := map[string]interface{}{"title": fmt.Sprintf("article %d", i), "wordCount": i * 100}
+		if i == 1 {
+			properties["author"] = map[string]interface{}{"name": "Ada"}
+		}
+		fake.Seed(fakes.WeaviateObject{Class: "Article", ID: weaviateID(i), Vector: []float32{float32(i), 0}, Properties: properties})
+	}
+
+	adapter := connectWeaviate(t, fake.URL, "Article", "")
+	ctx := context.Background()
+
+	first, err := adapter.GetBatch(ctx, "", 2)
+	if err != nil {
+		t.Fatalf("GetBatch failed: %v", err)
+	}
+	if len(first) != 2 || first[0].ID != weaviateID(1) || len(first[0].Vector) != 2 {
+		t.Fatalf("Unexpected first page: %+v", first)
+	}
+	want := map[string]interface{}{"title": "article 1", "wordCount": float64(100), "author": map[string]interface{}{"name": "Ada"}}
+	if !reflect.DeepEqual(first[0].Metadata, want) {
+		t.Errorf("Metadata = %v, want %v", first[0].Metadata, want)
+	}
+	if _, unset := first[1].Metadata["author"]; unset {
+		t.Errorf("Expected unset properties to be omitted, got %v", first[1].Metadata)
+	}
+
+	rest, err := adapter.GetBatch(ctx, first[1].ID, 10)
+	if err != nil {
+		t.Fatalf("GetBatch after %s failed: %v", first[1].ID, err)
+	}
+	if len(rest) != 3 || rest[0].ID != weaviateID(3) {
+		t.Errorf("Expected the remaining 3 objects, got %+v", rest)
+	}
+
+	stats, err := adapter.GetStats(ctx)
+	if err != nil {
+		t.Fatalf("GetStats failed: %v", err)
+	}
+	if stats.TotalRecords != 5 || stats.Dimensions != 2 {
+		t.Errorf("Expected 5 records of dimension 2, got %d and %d", stats.TotalRecords, stats.Dimensions)
+	}
+
+	if _, err := adapter.GetBatch(ctx, `x") { title } } }`, 1); err == nil {
+		t.Error("Expected error for a cursor that is not an ID, got nil")
+	}
+	bad := &WeaviateAdapter{}
+	if err := bad.Connect(ctx, DBConfig{Type: "weaviate", URL: fake.URL, Index: "Article(limit: 1)"}); err == nil {
+		t.Error("Expected error for an invalid class name, got nil")
+	}
+
+	t.Log("✓ WeaviateAdapter reads all properties page by page")
+}
+
+// TestWeaviateAdapterBatchErrors tests that per-object batch failures
+// surface as errors
+func TestWeaviateAdapterBatchErrors(t *testing.T) {
+	fake := fakes.NewWeaviate(t)
+	if err := fake.CreateClass(map[string]interface{}{"class": "Article"}); err != nil {
+		t.Fatalf("CreateClass failed: %v", err)
+	}
+	adapter := connectWeaviate(t, fake.URL, "Article", "")
+
+	records := []Record{
+		{ID: weaviateID(1), Vector: []float32{1, 0}},
+		{ID: "doc-2", Vector: []float32{0, 1}},
+	}
+	err := adapter.UpsertBatch(context.Background(), records)
+	if err == nil || !strings.Contains(err.Error(), "1 of 2 objects failed") || !strings.Contains(err.Error(), "doc-2") {
+		t.Errorf("Expected a per-object error naming doc-2, got %v", err)
+	}
+	if objects := fake.Objects("Article", ""); len(objects) != 1 {
+		t.Errorf("Expected the valid object to be stored, got %+v", objects)
+	}
+
+	t.Log("✓ WeaviateAdapter reports per-object batch errors")
+}
+
+// TestWeaviateAdapterTenants tests tenants as namespaces
+func TestWeaviateAdapterTenants(t *testing.T) {
+	fake := fakes.NewWeaviate(t)
+	if err := fake.CreateClass(map[string]interface{}{
+		"class":              "Article",
+		"multiTenancyConfig": map[string]interface{}{"enabled": true},
+	}); err != nil {
+		t.Fatalf("CreateClass failed: %v", err)
+	}
+	fake.AddTenants("Article", "beta", "acme")
+	fake.Seed(fakes.WeaviateObject{Class: "Article", Tenant: "acme", ID: weaviateID(1), Vector: []float32{1, 0}})
+
+	adapter := connectWeaviate(t, fake.URL, "Article", "")
+	ctx := context.Background()
+
+	if _, err := adapter.GetBatch(ctx, "", 10); err == nil {
+		t.Error("Expected error reading a multi-tenant class without a tenant, got nil")
+	}
+
+	tenants, err := adapter.ListNamespaces(ctx)
+	if err != nil {
+		t.Fatalf("ListNamespaces failed: %v", err)
+	}
+	if !reflect.DeepEqual(tenants, []string{"acme", "beta"}) {
+		t.Errorf("Expected tenants [acme beta], got %v", tenants)
+	}
+
+	acme := adapter.WithNamespace("acme")
+	records, err := acme.GetBatch(ctx, "", 10)
+	if err != nil || len(records) != 1 {
+		t.Fatalf("Expected 1 record in tenant acme, got %d (%v)", len(records), err)
+	}
+	if stats, err := acme.GetStats(ctx); err != nil || stats.TotalRecords != 1 {
+		t.Errorf("Expected 1 record in tenant stats, got %+v (%v)", stats, err)
+	}
+
+	t.Log("✓ WeaviateAdapter reads tenants as namespaces")
+}
+
+// TestWeaviateAdapterEnsureCollection tests creating a multi-tenant class
+// with typed properties from a spec
+func TestWeaviateAdapterEnsureCollection(t *testing.T) {
+	fake := fakes.NewWeaviate(t)
+	ctx := context.Background()
+
+	// Connecting with a tenant does not require the class to exist yet
+	adapter := connectWeaviate(t, fake.URL, "Article", "acme")
+	spec := CollectionSpec{
+		Dimensions: 2,
+		Distance:   DistanceEuclidean,
+		HNSW:       &HNSWParams{M: 32, EfConstruction: 200},
+		Properties: []PropertySpec{
+			{Name: "meta", Type: PropertyObject, Nested: []PropertySpec{{Name: "source", Type: PropertyText}}},
+			{Name: "published", Type: PropertyBool},
+			{Name: "score", Type: PropertyNumber},
+			{Name: "tags", Type: PropertyText, Array: true},
+			{Name: "views", Type: PropertyInt},
+		},
+	}
+	if err := adapter.EnsureCollection(ctx, spec); err != nil {
+		t.Fatalf("EnsureCollection failed: %v", err)
+	}
+
+	class, err := adapter.getClass(ctx)
+	if err != nil {
+		t.Fatalf("getClass failed: %v", err)
+	}
+	dataTypes := make(map[string]string)
+	for _, p := range class.Properties {
+		dataTypes[p.Name] = strings.Join(p.DataType, ",")
+	}
+	want := map[string]string{"meta": "object", "published": "boolean", "score": "number", "tags": "text[]", "views": "int"}
+	if !reflect.DeepEqual(dataTypes, want) {
+		t.Errorf("Expected data types %v, got %v", want, dataTypes)
+	}
+	if !class.MultiTenancyConfig.Enabled || class.VectorIndexConfig.Distance != "l2-squared" {
+		t.Errorf("Unexpected class config: %+v", class)
+	}
+
+	if tenants, err := adapter.ListNamespaces(ctx); err != nil || !reflect.DeepEqual(tenants, []string{"acme"}) {
+		t.Errorf("Expected tenant acme, got %v (%v)", tenants, err)
+	}
+	if err := adapter.UpsertBatch(ctx, []Record{{ID: weaviateID(1), Vector: []float32{1, 0}, Metadata: map[string]interface{}{"views": 3}}}); err != nil {
+		t.Fatalf("UpsertBatch failed: %v", err)
+	}
+
+	stats, err := adapter.GetStats(ctx)
+	if err != nil {
+		t.Fatalf("GetStats failed: %v", err)
+	}
+	if stats.Distance != DistanceEuclidean || stats.HNSW == nil || *stats.HNSW != *spec.HNSW {
+		t.Errorf("Expected euclidean distance and HNSW %+v, got %+v", spec.HNSW, stats)
+	}
+
+	// A second tenant is added to the existing class
+	beta := adapter.WithNamespace("beta").(*WeaviateAdapter)
+	if err := beta.EnsureCollection(ctx, spec); err != nil {
+		t.Fatalf("EnsureCollection for a second tenant failed: %v", err)
+	}
+	if tenants, err := adapter.ListNamespaces(ctx); err != nil || len(tenants) != 2 {
+		t.Errorf("Expected 2 tenants, got %v (%v)", tenants, err)
+	}
+
+	untenanted := connectWeaviate(t, fake.URL, "Article", "")
+	if err := untenanted.EnsureCollection(ctx, spec); err == nil {
+		t.Error("Expected error provisioning a multi-tenant class without a tenant, got nil")
+	}
+
+	t.Log("✓ WeaviateAdapter provisions classes and tenants")
+}
+
+// TestWeaviateAdapterGetSchema tests schema reads from class properties
+func TestWeaviateAdapterGetSchema(t *testing.T) {
+	fake := fakes.NewWeaviate(t)
+	err := fake.CreateClass(map[string]interface{}{
+		"class": "Article",
+		"properties": []interface{}{
+			map[string]interface{}{"name": "title", "dataType": []string{"text"}},
+			map[string]interface{}{"name": "tags", "dataType": []string{"text[]"}},
+			map[string]interface{}{"name": "location", "dataType": []string{"geoCoordinates"}},
+			map[string]interface{}{"name": "author", "dataType": []string{"object"}, "nestedProperties": []interface{}{
+				map[string]interface{}{"name": "name", "dataType": []string{"text"}},
+			}},
+			map[string]interface{}{"name": "cites", "dataType": []string{"Article"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateClass failed: %v", err)
+	}
+	ctx := context.Background()
+
+	adapter := connectWeaviate(t, fake.URL, "Article", "")
+	schema, err := adapter.GetSchema(ctx)
+	if err != nil {
+		t.Fatalf("GetSchema failed: %v", err)
+	}
+
+	var names []string
+	for _, field := range schema.Fields {
+		names = append(names, fmt.Sprintf("%s:%s:%v", field.Name(), field.Type, field.Array))
+	}
+	want := []string{
+		"author:object:false", "author.name:text:false",
+		"location:object:false", "location.latitude:number:false", "location.longitude:number:false",
+		"tags:text:true", "title:text:false",
+	}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("Expected fields %v, got %v", want, names)
+	}
+	if schema.Sampled != 0 || !schema.Fields[0].Nullable {
+		t.Errorf("Expected a native, nullable schema, got %+v", schema)
+	}
+
+	missing := connectWeaviate(t, fake.URL, "Missing", "")
+	if schema, err := missing.GetSchema(ctx); err != nil || len(schema.Fields) != 0 {
+		t.Errorf("Expected an empty schema for a missing class, got %+v (%v)", schema, err)
+	}
+
+	t.Log("✓ WeaviateAdapter reads schemas from class properties")
+}
+
+// TestWeaviateAdapterSearch tests nearVector queries with a where filter
+// in a tenant
+func TestWeaviateAdapterSearch(t *testing.T) {
+	fake := fakes.NewWeaviate(t)
+	if err := fake.CreateClass(map[string]interface{}{
+		"class":              "Article",
+		"multiTenancyConfig": map[string]interface{}{"enabled": true},
+	}); err != nil {
+		t.Fatalf("CreateClass failed: %v", err)
+	}
+	fake.AddTenants("Article", "acme")
+	for i := 1; i <= 4; i++ {
+		fake.Seed(fakes.WeaviateObject{
+			Class:      "Article",
+			ID:         weaviateID(i),
+			Tenant:     "acme",
+			Vector:     []float32{float32(i), 1},
+			Properties: map[string]interface{}{"lang": "en", "draft": i%2 == 0},
+		})
+	}
+	adapter := connectWeaviate(t, fake.URL, "Article", "acme")
+	ctx := context.Background()
+
+	results, err := adapter.Search(ctx, []float32{1, 0}, 2, nil)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 2 || results[0].ID != weaviateID(4) || results[1].ID != weaviateID(3) {
+		t.Fatalf("Unexpected results: %+v", results)
+	}
+	if results[0].Score > results[1].Score {
+		t.Errorf("Expected ascending distances, got %+v", results)
+	}
+
+	filtered, err := adapter.Search(ctx, []float32{1, 0}, 4, Filter{"lang": "en", "draft": false})
+	if err != nil {
+		t.Fatalf("Filtered search failed: %v", err)
+	}
+	if len(filtered) != 2 || filtered[0].ID != weaviateID(3) || filtered[1].ID != weaviateID(1) {
+		t.Errorf("Expected objects 3 and 1, got %+v", filtered)
+	}
+
+	if _, err := adapter.Search(ctx, []float32{1, 0}, 1, Filter{"bad-name": "x"}); err == nil {
+		t.Error("Expected error for an invalid property name, got nil")
+	}
+
+	t.Log("✓ WeaviateAdapter searches with nearVector")
+}
@@ -12,17 +12,34 @@ type BaseMapper struct {
 	sourceDB string
 	targetDB string
 	matcher  *FieldMatcher
+	
+	// What the target can store
+	target adapters.Capabilities
 }
 
-// NewBaseMapper creates a new base mapper
+// NewBaseMapper creates a new base mapper for the default capabilities of
+// targetDB. Unknown targets are assumed to store nested metadata and one
+// dense vector.
 func NewBaseMapper(sourceDB, targetDB string) *BaseMapper {
+	target, ok := adapters.CapabilitiesFor(targetDB)
+	if !ok {
+		target = adapters.Capabilities{NestedMetadata: true}
+	}
+	
 	return &BaseMapper{
 		sourceDB: sourceDB,
 		targetDB: targetDB,
 		matcher:  NewFieldMatcher(),
+		target:   target,
 	}
 }
 
+// SetTargetCapabilities replaces the target's default capabilities with
+// those of a connected target. Call it before mapping.
+func (m *BaseMapper) SetTargetCapabilities(caps adapters.Capabilities) {
+	m.target = caps
+}
+
 // CreateMapping creates a basic field mapping between schemas
 func (m *BaseMapper) CreateMapping(sourceSchema, targetSchema map[string]interface{}) (*SchemaMapping, error) {
 	if sourceSchema == nil || targetSchema == nil {
@@ -83,10 +100,14 @@ func (m *BaseMapper) findMatchingField(sourceField string, targetSchema map[stri
 func (m *BaseMapper) MapRecord(record adapters.Record, mapping *SchemaMapping) (adapters.Record, error) {
 	result := adapters.Record{
 		ID:       record.ID,
-		Vector:   record.Vector,
 		Metadata: make(map[string]interface{}),
 	}
 	
+	// Resolve named vectors against what the target can store
+	if err := m.mapVectors(record, &result, mapping.PrimaryVector); err != nil {
+		return result, err
+	}
+	
 	// Apply field mappings
 	for sourceField, targetField := range mapping.FieldMappings {
 		if value, exists := record.Metadata[sourceField]; exists {
@@ -96,6 +117,19 @@ func (m *BaseMapper) MapRecord(record adapters.Record, mapping *SchemaMapping) (
 		}
 	}
 	
+	// Carry over fields the mapping does not name, unless a mapped field
+	// already took their name
+	if mapping.KeepUnmapped {
+		for field, value := range record.Metadata {
+			if _, mapped := mapping.FieldMappings[field]; mapped {
+				continue
+			}
+			if _, taken := result.Metadata[field]; !taken {
+				result.Metadata[field] = value
+			}
+		}
+	}
+	
 	// Apply type conversions
 	for field, conversion := range mapping.TypeConversions {
 		if value, exists := result.Metadata[field]; exists && conversion.Converter != nil {
@@ -107,6 +141,18 @@ func (m *BaseMapper) MapRecord(record adapters.Record, mapping *SchemaMapping) (
 		}
 	}
 	
+	// Apply the sparse policy last so stashed vectors are not converted
+	if err := m.mapSparse(record, &result, mapping); err != nil {
+		return result, err
+	}
+	
+	if !m.target.NestedMetadata {
+		result.Metadata = flattenNested(result.Metadata)
+	}
+	if err := m.checkMetadataSize(result); err != nil {
+		return result, err
+	}
+	
 	return result, nil
 }
 
@@ -144,17 +190,11 @@ func (m *BaseMapper) ValidateMapping(mapping *SchemaMapping) error {
 	}
 	
 	// Check for valid database types
-	validDBs := map[string]bool{
-		"pinecone": true,
-		"qdrant":   true,
-		"weaviate": true,
-	}
-	
-	if !validDBs[mapping.SourceDB] {
+	if _, ok := adapters.CapabilitiesFor(mapping.SourceDB); !ok {
 		return fmt.Errorf("invalid source database type: %s", mapping.SourceDB)
 	}
 	
-	if !validDBs[mapping.TargetDB] {
+	if _, ok := adapters.CapabilitiesFor(mapping.TargetDB); !ok {
 		return fmt.Errorf("invalid target database type: %s", mapping.TargetDB)
 	}
 	
@@ -0,0 +1,68 @@
+package mapper
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/AlphaTechini/vector-db-migration/internal/adapters"
+)
+
+// flattenNested flattens nested metadata objects into dot-separated keys,
+// e.g. {"author": {"name": "x"}} becomes {"author.name": "x"}
+func flattenNested(metadata map[string]interface{}) map[string]interface{} {
+	flat := make(map[string]interface{}, len(metadata))
+	flattenInto(flat, "", metadata)
+	return flat
+}
+
+func flattenInto(flat map[string]interface{}, prefix string, values map[string]interface{}) {
+	for key, value := range values {
+		if nested, ok := value.(map[string]interface{}); ok {
+			flattenInto(flat, prefix+key+".", nested)
+			continue
+		}
+		flat[prefix+key] = value
+	}
+}
+
+// checkMetadataSize fails if record's encoded metadata exceeds the
+// target's limit
+func (m *BaseMapper) checkMetadataSize(record adapters.Record) error {
+	if m.target.MaxMetadataBytes == 0 {
+		return nil
+	}
+
+	encoded, err := json.Marshal(record.Metadata)
+	if err != nil {
+		return fmt.Errorf("failed to encode metadata of record %s: %w", record.ID, err)
+	}
+	if len(encoded) > m.target.MaxMetadataBytes {
+		return fmt.Errorf("record %s has %d bytes of metadata, %s allows %d", record.ID, len(encoded), m.targetDB, m.target.MaxMetadataBytes)
+	}
+	return nil
+}
+
+// NewMapper returns a mapper from sourceDB to targetDB that maps records
+// for the target's capabilities. Paths with conversions of their own get a
+// dedicated mapper; any other path between known types uses a BaseMapper.
+func NewMapper(sourceDB, targetDB string, target adapters.Capabilities) (SchemaMapper, error) {
+	if _, ok := adapters.CapabilitiesFor(sourceDB); !ok {
+		return nil, fmt.Errorf("unsupported source database type: %s", sourceDB)
+	}
+	if _, ok := adapters.CapabilitiesFor(targetDB); !ok {
+		return nil, fmt.Errorf("unsupported target database type: %s", targetDB)
+	}
+	if sourceDB == targetDB {
+		return nil, fmt.Errorf("source and target databases must be different")
+	}
+
+	if sourceDB == "pinecone" && targetDB == "qdrant" {
+		m := NewPineconeQdrantMapper()
+		m.SetTargetCapabilities(target)
+		return m, nil
+	}
+
+	m := NewBaseMapper(sourceDB, targetDB)
+	m.SetTargetCapabilities(target)
+	return m, nil
+}
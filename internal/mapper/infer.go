@@ -0,0 +1,145 @@
+package mapper
+
+import (
+	"sort"
+
+	"github.com/AlphaTechini/vector-db-migration/internal/adapters"
+)
+
+// InferProperties derives typed property definitions from the metadata of
+// mapped records, for targets that provision a schema. JSON numbers decode
+// as float64 and are inferred as numbers; only Go integers and integral
+// json.Numbers are ints. An int property widens to number if any record
+// has a fractional value. Properties whose values otherwise disagree in
+// type, or that are only ever null or empty lists, are left out for the
+// target to infer.
+func InferProperties(records []adapters.Record) []adapters.PropertySpec {
+	var inferred inferredProperties
+	for _, record := range records {
+		inferred.add(record.Metadata)
+	}
+	return inferred.specs()
+}
+
+// inferredProperties accumulates property types across objects
+type inferredProperties struct {
+	props      map[string]*adapters.PropertySpec
+	nested     map[string]*inferredProperties
+	conflicted map[string]bool
+}
+
+// add merges the properties of one object
+func (p *inferredProperties) add(values map[string]interface{}) {
+	if p.props == nil {
+		p.props = make(map[string]*adapters.PropertySpec)
+		p.nested = make(map[string]*inferredProperties)
+		p.conflicted = make(map[string]bool)
+	}
+
+	for name, value := range values {
+		if p.conflicted[name] {
+			continue
+		}
+
+		typ, array, objects, ok := inferType(value)
+		if !ok {
+			continue
+		}
+
+		existing, seen := p.props[name]
+		switch {
+		case !seen:
+			p.props[name] = &adapters.PropertySpec{Name: name, Type: typ, Array: array}
+		case existing.Array != array:
+			p.conflict(name)
+			continue
+		case existing.Type == typ:
+		case isNumeric(existing.Type) && isNumeric(typ):
+			existing.Type = adapters.PropertyNumber
+		default:
+			p.conflict(name)
+			continue
+		}
+
+		if typ == adapters.PropertyObject {
+			if p.nested[name] == nil {
+				p.nested[name] = &inferredProperties{}
+			}
+			for _, object := range objects {
+				p.nested[name].add(object)
+			}
+		}
+	}
+}
+
+// conflict drops a property whose values disagree in type
+func (p *inferredProperties) conflict(name string) {
+	p.conflicted[name] = true
+	delete(p.props, name)
+	delete(p.nested, name)
+}
+
+// specs returns the inferred properties in name order
+func (p *inferredProperties) specs() []adapters.PropertySpec {
+	names := make([]string, 0, len(p.props))
+	for name := range p.props {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	specs := make([]adapters.PropertySpec, 0, len(names))
+	for _, name := range names {
+		spec := *p.props[name]
+		if spec.Type == adapters.PropertyObject {
+			spec.Nested = p.nested[name].specs()
+			if len(spec.Nested) == 0 {
+				continue
+			}
+		}
+		specs = append(specs, spec)
+	}
+	return specs
+}
+
+// inferType returns the property type of value, whether it is a list and,
+// for objects, the objects themselves. ok is false for null values, empty
+// lists and lists of mixed or unsupported types.
+func inferType(value interface{}) (typ adapters.PropertyType, array bool, objects []map[string]interface{}, ok bool) {
+	switch v := value.(type) {
+	case []interface{}:
+		for _, elem := range v {
+			elemType, elemArray, elemObjects, elemOK := inferType(elem)
+			if !elemOK || elemArray {
+				return "", false, nil, false
+			}
+			switch {
+			case typ == "" || typ == elemType:
+				typ = elemType
+			case isNumeric(typ) && isNumeric(elemType):
+				typ = adapters.PropertyNumber
+			default:
+				return "", false, nil, false
+			}
+			objects = append(objects, elemObjects...)
+		}
+		return typ, true, objects, typ != ""
+	case []string:
+		return adapters.PropertyText, true, nil, len(v) > 0
+	case []float64, []float32:
+		return adapters.PropertyNumber, true, nil, true
+	case []int, []int64:
+		return adapters.PropertyInt, true, nil, true
+	case []bool:
+		return adapters.PropertyBool, true, nil, true
+	case map[string]interface{}:
+		return adapters.PropertyObject, false, []map[string]interface{}{v}, true
+	}
+
+	typ, ok = adapters.ScalarType(value)
+	return typ, false, nil, ok
+}
+
+// isNumeric reports whether typ is int or number
+func isNumeric(typ adapters.PropertyType) bool {
+	return typ == adapters.PropertyInt || typ == adapters.PropertyNumber
+}
@@ -1,6 +1,9 @@
 package mapper
 
 import (
+	"encoding/json"
+	"fmt"
+
 	"github.com/AlphaTechini/vector-db-migration/internal/adapters"
 )
 
@@ -20,6 +23,28 @@ type SchemaMapping struct {
 	
 	// TargetDB type (pinecone, qdrant, weaviate)
 	TargetDB string `json:"target_db"`
+	
+	// KeepUnmapped copies fields without a mapping to the target unchanged
+	// instead of dropping them, for sources whose schema is only sampled
+	KeepUnmapped bool `json:"keep_unmapped,omitempty"`
+	
+	// PrimaryVector names the vector written as the single dense vector on
+	// targets without named vectors, and the name a record's unnamed vector
+	// is stored under on targets with them. Empty keeps the unnamed vector.
+	PrimaryVector string `json:"primary_vector,omitempty"`
+	
+	// SparseVector names the sparse vector exchanged with targets that hold
+	// one unnamed sparse vector (Pinecone), and the name an unnamed sparse
+	// vector is stored under on targets with named ones (Qdrant)
+	SparseVector string `json:"sparse_vector,omitempty"`
+	
+	// SparsePolicy handles sparse vectors the target cannot store
+	// (default SparseError)
+	SparsePolicy SparsePolicy `json:"sparse_policy,omitempty"`
+	
+	// SparseField is the metadata field SparseMetadata stashes sparse
+	// vectors in (default "sparse_vectors")
+	SparseField string `json:"sparse_field,omitempty"`
 }
 
 // TypeConversion defines how to convert a field type
@@ -29,6 +54,45 @@ type TypeConversion struct {
 	Converter func(interface{}) (interface{}, error) `json:"-"`
 }
 
+// converters restores TypeConversion.Converter, which JSON cannot hold,
+// by the conversion's target type
+var converters = map[string]func(interface{}) (interface{}, error){
+	"auto": autoConvertNumber,
+}
+
+// EncodeMapping converts a mapping to the JSON object checkpoints store
+func EncodeMapping(mapping *SchemaMapping) (map[string]interface{}, error) {
+	data, err := json.Marshal(mapping)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode schema mapping: %w", err)
+	}
+	
+	var encoded map[string]interface{}
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return nil, fmt.Errorf("failed to encode schema mapping: %w", err)
+	}
+	return encoded, nil
+}
+
+// DecodeMapping restores a mapping saved by EncodeMapping, reattaching the
+// converters of its type conversions
+func DecodeMapping(encoded map[string]interface{}) (*SchemaMapping, error) {
+	data, err := json.Marshal(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode schema mapping: %w", err)
+	}
+	
+	var mapping SchemaMapping
+	if err := json.Unmarshal(data, &mapping); err != nil {
+		return nil, fmt.Errorf("failed to decode schema mapping: %w", err)
+	}
+	for field, conversion := range mapping.TypeConversions {
+		conversion.Converter = converters[conversion.ToType]
+		mapping.TypeConversions[field] = conversion
+	}
+	return &mapping, nil
+}
+
 // SchemaMapper interface for converting records between database schemas
 type SchemaMapper interface {
 	// CreateMapping analyzes source and target schemas and creates a mapping
@@ -1,6 +1,9 @@
 package mapper
 
 import (
+	"encoding/json"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/AlphaTechini/vector-db-migration/internal/adapters"
@@ -212,3 +215,327 @@ func TestBaseMapper_MapBatch(t *testing.T) {
 	
 	t.Log("✓ BaseMapper maps batches correctly")
 }
+
+// TestBaseMapper_NamedVectors tests how named vectors reach single-vector
+// and named-vector targets
+func TestBaseMapper_NamedVectors(t *testing.T) {
+	record := adapters.Record{
+		ID: "7",
+		NamedVectors: map[string][]float32{
+			"title": {1, 0},
+			"body":  {0, 1, 0},
+		},
+	}
+	
+	toPinecone := NewBaseMapper("qdrant", "pinecone")
+	
+	if _, err := toPinecone.MapRecord(record, &SchemaMapping{}); err == nil {
+		t.Error("Expected error for several named vectors without a primary, got nil")
+	}
+	
+	result, err := toPinecone.MapRecord(record, &SchemaMapping{PrimaryVector: "body"})
+	if err != nil {
+		t.Fatalf("Failed to map record: %v", err)
+	}
+	if len(result.Vector) != 3 || result.NamedVectors != nil {
+		t.Errorf("Expected the body vector only, got %v and %v", result.Vector, result.NamedVectors)
+	}
+	
+	if _, err := toPinecone.MapRecord(record, &SchemaMapping{PrimaryVector: "summary"}); err == nil {
+		t.Error("Expected error for a missing primary vector, got nil")
+	}
+	
+	single := adapters.Record{ID: "8", NamedVectors: map[string][]float32{"title": {1, 1}}}
+	result, err = toPinecone.MapRecord(single, &SchemaMapping{})
+	if err != nil || len(result.Vector) != 2 {
+		t.Errorf("Expected the only named vector to be used, got %v (%v)", result.Vector, err)
+	}
+	
+	toQdrant := NewBaseMapper("pinecone", "qdrant")
+	
+	result, err = toQdrant.MapRecord(record, &SchemaMapping{})
+	if err != nil || len(result.NamedVectors) != 2 {
+		t.Errorf("Expected named vectors to be kept, got %v (%v)", result.NamedVectors, err)
+	}
+	
+	unnamed := adapters.Record{ID: "9", Vector: []float32{0.5, 0.5}}
+	result, err = toQdrant.MapRecord(unnamed, &SchemaMapping{PrimaryVector: "title"})
+	if err != nil {
+		t.Fatalf("Failed to map record: %v", err)
+	}
+	if result.Vector != nil || len(result.NamedVectors["title"]) != 2 {
+		t.Errorf("Expected the vector to move under 'title', got %v and %v", result.Vector, result.NamedVectors)
+	}
+	
+	t.Log("✓ BaseMapper resolves named vectors per target")
+}
+
+// TestBaseMapper_SparsePolicy tests sparse vectors on targets with and
+// without sparse support
+func TestBaseMapper_SparsePolicy(t *testing.T) {
+	sparse := adapters.SparseVector{Indices: []uint32{1, 7}, Values: []float32{0.5, 0.25}}
+	hybrid := adapters.Record{
+		ID:       "doc-1",
+		Vector:   []float32{0.1, 0.2},
+		Sparse:   &sparse,
+		Metadata: map[string]interface{}{"title": "Doc 1"},
+	}
+	fields := map[string]string{"title": "title"}
+	
+	toQdrant := NewBaseMapper("pinecone", "qdrant")
+	result, err := toQdrant.MapRecord(hybrid, &SchemaMapping{FieldMappings: fields, SparseVector: "keywords"})
+	if err != nil {
+		t.Fatalf("Failed to map record: %v", err)
+	}
+	if result.Sparse != nil || len(result.SparseVectors["keywords"].Indices) != 2 {
+		t.Errorf("Expected the sparse vector under 'keywords', got %+v", result)
+	}
+	
+	toPinecone := NewBaseMapper("qdrant", "pinecone")
+	named := adapters.Record{ID: "7", Vector: []float32{1, 0}, SparseVectors: map[string]adapters.SparseVector{"keywords": sparse}}
+	result, err = toPinecone.MapRecord(named, &SchemaMapping{})
+	if err != nil || result.Sparse == nil {
+		t.Errorf("Expected the only named sparse vector to become sparse values, got %+v (%v)", result, err)
+	}
+	
+	toWeaviate := NewBaseMapper("pinecone", "weaviate")
+	if _, err := toWeaviate.MapRecord(hybrid, &SchemaMapping{FieldMappings: fields}); err == nil {
+		t.Error("Expected error for sparse vectors without a policy, got nil")
+	}
+	
+	result, err = toWeaviate.MapRecord(hybrid, &SchemaMapping{FieldMappings: fields, SparsePolicy: SparseDrop})
+	if err != nil {
+		t.Fatalf("Failed to map record: %v", err)
+	}
+	if result.Sparse != nil || len(result.Metadata) != 1 {
+		t.Errorf("Expected sparse vector to be dropped, got %+v", result)
+	}
+	
+	result, err = toWeaviate.MapRecord(hybrid, &SchemaMapping{FieldMappings: fields, SparsePolicy: SparseMetadata})
+	if err != nil {
+		t.Fatalf("Failed to map record: %v", err)
+	}
+	if stashed := result.Metadata["sparse_vectors"]; stashed != `{"":{"indices":[1,7],"values":[0.5,0.25]}}` {
+		t.Errorf("Unexpected stashed sparse vectors: %v", stashed)
+	}
+	
+	plain := adapters.Record{ID: "doc-2", Vector: []float32{0.3, 0.4}}
+	if _, err := toWeaviate.MapRecord(plain, &SchemaMapping{}); err != nil {
+		t.Errorf("Records without sparse vectors should map, got %v", err)
+	}
+	
+	records := []adapters.Record{hybrid, plain, hybrid}
+	if report := toWeaviate.SparseReport(records, &SchemaMapping{SparsePolicy: SparseDrop}); report.Dropped != 2 || report.Stashed != 0 {
+		t.Errorf("Expected 2 dropped records, got %+v", report)
+	}
+	if report := toWeaviate.SparseReport(records, &SchemaMapping{SparsePolicy: SparseMetadata}); report.Dropped != 0 || report.Stashed != 2 {
+		t.Errorf("Expected 2 stashed records, got %+v", report)
+	}
+	if report := toQdrant.SparseReport(records, &SchemaMapping{SparseVector: "keywords", SparsePolicy: SparseDrop}); report != (SparseReport{}) {
+		t.Errorf("Expected no records altered for a sparse-capable target, got %+v", report)
+	}
+	
+	if _, err := ParseSparsePolicy("keep"); err == nil {
+		t.Error("Expected error for an unknown policy, got nil")
+	}
+	
+	t.Log("✓ BaseMapper applies the sparse policy and reports it")
+}
+
+// TestInferProperties tests property type inference and widening
+func TestInferProperties(t *testing.T) {
+	records := []adapters.Record{
+		{ID: "1", Metadata: map[string]interface{}{
+			"title": "a",
+			"views": 3,
+			"score": 1.5,
+			"tags":  []interface{}{"x", "y"},
+			"meta":  map[string]interface{}{"source": "web", "rank": 1},
+			"mixed": "text",
+			"empty": nil,
+		}},
+		{ID: "2", Metadata: map[string]interface{}{
+			"views":     json.Number("4.5"),
+			"published": true,
+			"meta":      map[string]interface{}{"lang": "en"},
+			"mixed":     2.0,
+		}},
+	}
+	
+	got := InferProperties(records)
+	want := []adapters.PropertySpec{
+		{Name: "meta", Type: adapters.PropertyObject, Nested: []adapters.PropertySpec{
+			{Name: "lang", Type: adapters.PropertyText},
+			{Name: "rank", Type: adapters.PropertyInt},
+			{Name: "source", Type: adapters.PropertyText},
+		}},
+		{Name: "published", Type: adapters.PropertyBool},
+		{Name: "score", Type: adapters.PropertyNumber},
+		{Name: "tags", Type: adapters.PropertyText, Array: true},
+		{Name: "title", Type: adapters.PropertyText},
+		{Name: "views", Type: adapters.PropertyNumber},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("InferProperties:\ngot  %+v\nwant %+v", got, want)
+	}
+	
+	t.Log("✓ InferProperties infers and widens property types")
+}
+
+// TestBaseMapper_TargetCapabilities tests that mapping follows what the
+// target can store
+func TestBaseMapper_TargetCapabilities(t *testing.T) {
+	mapping := &SchemaMapping{
+		FieldMappings:   map[string]string{"author": "author", "body": "body"},
+		TypeConversions: map[string]TypeConversion{},
+		DefaultValues:   map[string]interface{}{},
+	}
+	record := adapters.Record{
+		ID:       "doc-1",
+		Vector:   []float32{1, 0},
+		Metadata: map[string]interface{}{"author": map[string]interface{}{"name": "ada", "org": map[string]interface{}{"id": 7}}},
+	}
+	
+	// Pinecone metadata is flat
+	pinecone, err := NewMapper("qdrant", "pinecone", adapters.Capabilities{MaxMetadataBytes: 64})
+	if err != nil {
+		t.Fatalf("NewMapper failed: %v", err)
+	}
+	result, err := pinecone.MapRecord(record, mapping)
+	if err != nil {
+		t.Fatalf("Failed to map record: %v", err)
+	}
+	want := map[string]interface{}{"author.name": "ada", "author.org.id": 7}
+	if !reflect.DeepEqual(result.Metadata, want) {
+		t.Errorf("Expected flattened metadata %v, got %v", want, result.Metadata)
+	}
+	
+	record.Metadata["body"] = strings.Repeat("x", 64)
+	if _, err := pinecone.MapRecord(record, mapping); err == nil {
+		t.Error("Expected error for metadata over the target limit, got nil")
+	}
+	
+	// Connected capabilities override the type defaults
+	redis, err := NewMapper("qdrant", "redis", adapters.Capabilities{NestedMetadata: true})
+	if err != nil {
+		t.Fatalf("NewMapper failed: %v", err)
+	}
+	result, err = redis.MapRecord(record, mapping)
+	if err != nil {
+		t.Fatalf("Failed to map record: %v", err)
+	}
+	if _, nested := result.Metadata["author"].(map[string]interface{}); !nested {
+		t.Errorf("Expected nested metadata to be kept, got %v", result.Metadata)
+	}
+	
+	if _, err := NewMapper("qdrant", "mongodb", adapters.Capabilities{}); err == nil {
+		t.Error("Expected error for an unknown target type, got nil")
+	}
+	if m, err := NewMapper("pinecone", "qdrant", adapters.Capabilities{}); err != nil {
+		t.Errorf("NewMapper failed: %v", err)
+	} else if _, ok := m.(*PineconeQdrantMapper); !ok {
+		t.Errorf("Expected a PineconeQdrantMapper, got %T", m)
+	}
+	
+	t.Log("✓ BaseMapper maps for the target's capabilities")
+}
+
+// TestNewMapper_AllTypes tests that every supported database type can be
+// migrated to every other, so an adapter cannot be added without a mapper
+func TestNewMapper_AllTypes(t *testing.T) {
+	types := adapters.DatabaseTypes()
+	for _, source := range types {
+		for _, target := range types {
+			if source == target {
+				continue
+			}
+			caps, _ := adapters.CapabilitiesFor(target)
+			if _, err := NewMapper(source, target, caps); err != nil {
+				t.Errorf("NewMapper(%s, %s) failed: %v", source, target, err)
+			}
+		}
+	}
+	
+	t.Log("✓ NewMapper covers every pair of supported types")
+}
+
+// TestBaseMapper_KeepUnmapped tests that unmapped fields survive when asked
+func TestBaseMapper_KeepUnmapped(t *testing.T) {
+	mapper := NewBaseMapper("qdrant", "weaviate")
+	
+	mapping, err := mapper.CreateMapping(
+		map[string]interface{}{"Title": "text", "views": "int"},
+		map[string]interface{}{"title": "text"},
+	)
+	if err != nil {
+		t.Fatalf("Failed to create mapping: %v", err)
+	}
+	
+	record := adapters.Record{
+		ID:       "1",
+		Metadata: map[string]interface{}{"Title": "doc", "views": 3, "late": true},
+	}
+	
+	result, err := mapper.MapRecord(record, mapping)
+	if err != nil {
+		t.Fatalf("Failed to map record: %v", err)
+	}
+	if result.Metadata["title"] != "doc" || len(result.Metadata) != 1 {
+		t.Errorf("Expected only the mapped title, got %v", result.Metadata)
+	}
+	
+	mapping.KeepUnmapped = true
+	result, err = mapper.MapRecord(record, mapping)
+	if err != nil {
+		t.Fatalf("Failed to map record: %v", err)
+	}
+	want := map[string]interface{}{"title": "doc", "views": 3, "late": true}
+	if !reflect.DeepEqual(result.Metadata, want) {
+		t.Errorf("Expected %v, got %v", want, result.Metadata)
+	}
+	
+	t.Log("✓ BaseMapper keeps unmapped fields when asked")
+}
+
+// TestEncodeDecodeMapping tests saving a mapping in a checkpoint and
+// restoring it with its converters
+func TestEncodeDecodeMapping(t *testing.T) {
+	m := NewPineconeQdrantMapper()
+	mapping, err := m.CreateMapping(map[string]interface{}{"count": "float", "Title": "string"}, map[string]interface{}{"title": "text"})
+	if err != nil {
+		t.Fatalf("Failed to create mapping: %v", err)
+	}
+	mapping.KeepUnmapped = true
+	mapping.SparsePolicy = SparseMetadata
+
+	encoded, err := EncodeMapping(mapping)
+	if err != nil {
+		t.Fatalf("Failed to encode mapping: %v", err)
+	}
+
+	// Checkpoints hold the mapping as JSON
+	data, _ := json.Marshal(encoded)
+	var stored map[string]interface{}
+	if err := json.Unmarshal(data, &stored); err != nil {
+		t.Fatalf("Failed to round-trip mapping: %v", err)
+	}
+
+	restored, err := DecodeMapping(stored)
+	if err != nil {
+		t.Fatalf("Failed to decode mapping: %v", err)
+	}
+	if restored.FieldMappings["Title"] != "title" || !restored.KeepUnmapped || restored.SparsePolicy != SparseMetadata {
+		t.Errorf("Unexpected restored mapping: %+v", restored)
+	}
+
+	record := adapters.Record{ID: "a", Vector: []float32{1}, Metadata: map[string]interface{}{"count": float64(3)}}
+	mapped, err := m.MapRecord(record, restored)
+	if err != nil {
+		t.Fatalf("Failed to map with restored mapping: %v", err)
+	}
+	if _, ok := mapped.Metadata["count"].(int64); !ok {
+		t.Errorf("Expected the restored converter to convert count, got %T", mapped.Metadata["count"])
+	}
+
+	t.Log("✓ Schema mappings survive checkpoints with their converters")
+}
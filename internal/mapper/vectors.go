@@ -0,0 +1,250 @@
+package mapper
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/AlphaTechini/vector-db-migration/internal/adapters"
+)
+
+// mapVectors sets result's dense vectors from record. Targets with named
+// vectors keep them all, with the unnamed vector moved under primary if
+// set. Other targets get one vector: primary if set, else the unnamed
+// vector, else the record's only named vector.
+func (m *BaseMapper) mapVectors(record adapters.Record, result *adapters.Record, primary string) error {
+	if m.target.NamedVectors {
+		result.Vector = record.Vector
+		result.NamedVectors = record.NamedVectors
+		if primary == "" || record.Vector == nil {
+			return nil
+		}
+		if _, exists := record.NamedVectors[primary]; exists {
+			return fmt.Errorf("record %s has both an unnamed vector and a vector named %q", record.ID, primary)
+		}
+
+		named := make(map[string][]float32, len(record.NamedVectors)+1)
+		for name, v := range record.NamedVectors {
+			named[name] = v
+		}
+		named[primary] = record.Vector
+		result.Vector = nil
+		result.NamedVectors = named
+		return nil
+	}
+
+	switch {
+	case primary != "":
+		v, exists := record.NamedVectors[primary]
+		if !exists {
+			return fmt.Errorf("record %s has no vector named %q", record.ID, primary)
+		}
+		result.Vector = v
+	case record.Vector != nil:
+		result.Vector = record.Vector
+	case len(record.NamedVectors) == 1:
+		for _, v := range record.NamedVectors {
+			result.Vector = v
+		}
+	case len(record.NamedVectors) > 1:
+		names := make([]string, 0, len(record.NamedVectors))
+		for name := range record.NamedVectors {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("record %s has named vectors %s but %s stores one vector per record; choose a primary vector",
+			record.ID, strings.Join(names, ", "), m.targetDB)
+	}
+
+	return nil
+}
+
+// SparsePolicy decides what happens to sparse vectors a target cannot store
+type SparsePolicy string
+
+const (
+	// SparseError fails the record
+	SparseError SparsePolicy = "error"
+
+	// SparseDrop discards the sparse vectors
+	SparseDrop SparsePolicy = "drop"
+
+	// SparseMetadata stores the sparse vectors as a JSON string in a
+	// metadata field
+	SparseMetadata SparsePolicy = "metadata"
+)
+
+// ParseSparsePolicy parses a policy name; empty selects SparseError
+func ParseSparsePolicy(name string) (SparsePolicy, error) {
+	switch policy := SparsePolicy(name); policy {
+	case "":
+		return SparseError, nil
+	case SparseError, SparseDrop, SparseMetadata:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("unknown sparse policy %q (expected drop, error or metadata)", name)
+	}
+}
+
+// defaultSparseField is the metadata field SparseMetadata writes to
+const defaultSparseField = "sparse_vectors"
+
+// sparseSupport is the kind of sparse vectors a target type stores
+type sparseSupport int
+
+const (
+	sparseNone    sparseSupport = iota
+	sparseUnnamed               // one unnamed sparse vector per record
+	sparseNamed                 // any number of named sparse vectors
+	sparseAll                   // both, e.g. file exports
+)
+
+// sparseSupportOf returns the kind of sparse vectors caps allows
+func sparseSupportOf(caps adapters.Capabilities) sparseSupport {
+	switch {
+	case caps.SparseVector && caps.NamedSparseVectors:
+		return sparseAll
+	case caps.NamedSparseVectors:
+		return sparseNamed
+	case caps.SparseVector:
+		return sparseUnnamed
+	}
+	return sparseNone
+}
+
+// SparseReport counts the records whose sparse vectors were altered by the
+// sparse policy
+type SparseReport struct {
+	Dropped int64 `json:"dropped"`
+	Stashed int64 `json:"stashed"`
+}
+
+// SparseReporter is implemented by mappers that apply a SparsePolicy
+type SparseReporter interface {
+	// SparseReport counts the records whose sparse vectors the policy
+	// alters when they are mapped with mapping. The records are not
+	// mapped, so callers can count just those that reached the target.
+	SparseReport(records []adapters.Record, mapping *SchemaMapping) SparseReport
+}
+
+// SparseReport counts the records that have sparse vectors dropped or
+// stashed in metadata when mapped with mapping
+func (m *BaseMapper) SparseReport(records []adapters.Record, mapping *SchemaMapping) SparseReport {
+	var report SparseReport
+	for _, record := range records {
+		if len(m.splitSparse(record, &adapters.Record{}, mapping)) == 0 {
+			continue
+		}
+		switch mapping.SparsePolicy {
+		case SparseDrop:
+			report.Dropped++
+		case SparseMetadata:
+			report.Stashed++
+		}
+	}
+	return report
+}
+
+// mapSparse sets result's sparse vectors from record, applying the mapping's
+// SparsePolicy to those the target cannot store. The unnamed sparse vector
+// is keyed "" in error messages and stashed metadata.
+func (m *BaseMapper) mapSparse(record adapters.Record, result *adapters.Record, mapping *SchemaMapping) error {
+	unsupported := m.splitSparse(record, result, mapping)
+	if len(unsupported) == 0 {
+		return nil
+	}
+
+	switch mapping.SparsePolicy {
+	case SparseDrop:
+		return nil
+
+	case SparseMetadata:
+		encoded, err := json.Marshal(unsupported)
+		if err != nil {
+			return fmt.Errorf("failed to encode sparse vectors of record %s: %w", record.ID, err)
+		}
+		field := mapping.SparseField
+		if field == "" {
+			field = defaultSparseField
+		}
+		result.Metadata[field] = string(encoded)
+		return nil
+
+	default:
+		names := make([]string, 0, len(unsupported))
+		for name := range unsupported {
+			if name == "" {
+				name = "(unnamed)"
+			}
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("record %s has sparse vectors %s that %s cannot store; choose a sparse policy (drop or metadata)",
+			record.ID, strings.Join(names, ", "), m.targetDB)
+	}
+}
+
+// splitSparse sets result's sparse vectors to those of record the target
+// can store and returns the rest, keyed by name
+func (m *BaseMapper) splitSparse(record adapters.Record, result *adapters.Record, mapping *SchemaMapping) map[string]adapters.SparseVector {
+	if record.Sparse == nil && len(record.SparseVectors) == 0 {
+		return nil
+	}
+
+	unsupported := make(map[string]adapters.SparseVector)
+	switch sparseSupportOf(m.target) {
+	case sparseAll:
+		result.Sparse = record.Sparse
+		result.SparseVectors = record.SparseVectors
+
+	case sparseUnnamed:
+		result.Sparse = record.Sparse
+		chosen := ""
+		if result.Sparse == nil {
+			chosen = mapping.SparseVector
+			if chosen == "" && len(record.SparseVectors) == 1 {
+				for name := range record.SparseVectors {
+					chosen = name
+				}
+			}
+			if v, exists := record.SparseVectors[chosen]; exists {
+				result.Sparse = &v
+			}
+		}
+		for name, v := range record.SparseVectors {
+			if result.Sparse == nil || name != chosen {
+				unsupported[name] = v
+			}
+		}
+
+	case sparseNamed:
+		if len(record.SparseVectors) > 0 {
+			result.SparseVectors = make(map[string]adapters.SparseVector, len(record.SparseVectors)+1)
+			for name, v := range record.SparseVectors {
+				result.SparseVectors[name] = v
+			}
+		}
+		if record.Sparse != nil {
+			name := mapping.SparseVector
+			if _, exists := record.SparseVectors[name]; name == "" || exists {
+				unsupported[""] = *record.Sparse
+			} else {
+				if result.SparseVectors == nil {
+					result.SparseVectors = make(map[string]adapters.SparseVector, 1)
+				}
+				result.SparseVectors[name] = *record.Sparse
+			}
+		}
+
+	default:
+		if record.Sparse != nil {
+			unsupported[""] = *record.Sparse
+		}
+		for name, v := range record.SparseVectors {
+			unsupported[name] = v
+		}
+	}
+
+	return unsupported
+}
@@ -40,8 +40,8 @@ func (t *ListMigrationsTool) inputSchema() map[string]interface{} {
 		"properties": map[string]interface{}{
 			"status": map[string]interface{}{
 				"type": "string",
-				"description": "Filter by migration status (not_started, in_progress, completed, failed, rolled_back)",
-				"enum": []string{"not_started", "in_progress", "completed", "failed", "rolled_back"},
+				"description": "Filter by migration status (not_started, in_progress, paused, completed, failed, rolled_back)",
+				"enum": []string{"not_started", "in_progress", "paused", "completed", "failed", "rolled_back"},
 			},
 			"limit": map[string]interface{}{
 				"type": "integer",
@@ -195,7 +195,7 @@ func (t *ListMigrationsTool) execute(ctx context.Context, params map[string]inte
 
 // validateStatus checks if a status string is valid
 func validateStatus(status string) bool {
-	validStatuses := []string{"not_started", "in_progress", "completed", "failed", "rolled_back"}
+	validStatuses := []string{"not_started", "in_progress", "paused", "completed", "failed", "rolled_back"}
 	for _, s := range validStatuses {
 		if strings.EqualFold(status, s) {
 			return true
@@ -214,7 +214,7 @@ func TestListMigrationsTool_Execute_Pagination(t *testing.T) {
 }
 
 func TestValidateStatus_ValidStatuses(t *testing.T) {
-	validStatuses := []string{"not_started", "in_progress", "completed", "failed", "rolled_back"}
+	validStatuses := []string{"not_started", "in_progress", "paused", "completed", "failed", "rolled_back"}
 
 	for _, status := range validStatuses {
 		if !validateStatus(status) {
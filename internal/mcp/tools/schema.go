@@ -3,7 +3,10 @@ package tools
 import (
 	"context"
 	"fmt"
+	"math"
+	"strings"
 
+	"github.com/AlphaTechini/vector-db-migration/internal/adapters"
 	"github.com/AlphaTechini/vector-db-migration/internal/mcp"
 )
 
@@ -51,12 +54,12 @@ func (t *SchemaRecommendationTool) inputSchema() map[string]interface{} {
 			"source_type": map[string]interface{}{
 				"type": "string",
 				"description": "Source database type",
-				"enum": []string{"pinecone", "qdrant", "weaviate", "milvus"},
+				"enum": adapters.DatabaseTypes(),
 			},
 			"target_type": map[string]interface{}{
 				"type": "string",
 				"description": "Target database type",
-				"enum": []string{"pinecone", "qdrant", "weaviate", "milvus"},
+				"enum": adapters.DatabaseTypes(),
 			},
 			"source_schema": map[string]interface{}{
 				"type": "object",
@@ -138,28 +141,14 @@ func (t *SchemaRecommendationTool) generateRecommendations(sourceType, targetTyp
 		rec.FieldMappings = append(rec.FieldMappings, fieldRec)
 	}
 
-	// Database-specific recommendations
-	switch sourceType + "_to_" + targetType {
-	case "pinecone_to_qdrant":
-		rec.Warnings = append(rec.Warnings, "Pinecone flat metadata will be flattened in Qdrant with dot notation")
-		rec.OverallConfidence = 0.9
-
-	case "pinecone_to_weaviate":
-		rec.Warnings = append(rec.Warnings, "Weaviate requires schema definition before upsert")
-		rec.Warnings = append(rec.Warnings, "Nested metadata not supported in Pinecone, but supported in Weaviate")
-		rec.OverallConfidence = 0.85
-
-	case "qdrant_to_pinecone":
-		rec.Warnings = append(rec.Warnings, "Qdrant nested payloads must be flattened for Pinecone")
-		rec.Warnings = append(rec.Warnings, "Use dot notation: author.name → author_name")
-		rec.OverallConfidence = 0.85
-
-	case "weaviate_to_pinecone":
-		rec.Warnings = append(rec.Warnings, "Weaviate typed properties will become untyped in Pinecone")
-		rec.Warnings = append(rec.Warnings, "Type information will be lost")
-		rec.OverallConfidence = 0.8
-
-	default:
+	// Recommendations from what each database can store
+	sourceCaps, sourceKnown := adapters.CapabilitiesFor(sourceType)
+	targetCaps, targetKnown := adapters.CapabilitiesFor(targetType)
+	if sourceKnown && targetKnown {
+		warnings, issues := capabilityWarnings(databaseName(sourceType), databaseName(targetType), sourceCaps, targetCaps)
+		rec.Warnings = append(rec.Warnings, warnings...)
+		rec.OverallConfidence = math.Max(0.5, math.Round((0.95-0.05*float64(issues))*100)/100)
+	} else {
 		rec.OverallConfidence = 0.75
 		rec.Warnings = append(rec.Warnings, "Generic migration path - review mappings carefully")
 	}
@@ -190,3 +179,101 @@ func (t *SchemaRecommendationTool) generateRecommendations(sourceType, targetTyp
 
 	return rec
 }
+
+// capabilityWarnings compares what the source and target can store and
+// returns warnings and the number of them that lose data or reject records
+func capabilityWarnings(source, target string, sourceCaps, targetCaps adapters.Capabilities) ([]string, int) {
+	var warnings []string
+	issues := 0
+
+	switch {
+	case sourceCaps.NestedMetadata && !targetCaps.NestedMetadata:
+		warnings = append(warnings, fmt.Sprintf("%s nested metadata will be flattened for %s with dot notation: author.name", source, target))
+		issues++
+	case !sourceCaps.NestedMetadata && targetCaps.NestedMetadata:
+		warnings = append(warnings, fmt.Sprintf("%s flat metadata is copied as-is; %s can also store nested metadata", source, target))
+	}
+
+	switch {
+	case targetCaps.TypedProperties && !sourceCaps.TypedProperties:
+		warnings = append(warnings, fmt.Sprintf("%s requires schema definition before upsert; property types are inferred from the records", target))
+	case sourceCaps.TypedProperties && !targetCaps.TypedProperties:
+		warnings = append(warnings, fmt.Sprintf("%s typed properties will become untyped in %s", source, target))
+		issues++
+	}
+
+	if !acceptsIDFormats(targetCaps, sourceCaps.IDFormats) {
+		warnings = append(warnings, fmt.Sprintf("%s accepts only %s IDs; other %s IDs will be rejected", target, joinIDFormats(targetCaps.IDFormats), source))
+		issues++
+	}
+
+	if sourceCaps.NamedVectors && !targetCaps.NamedVectors {
+		warnings = append(warnings, fmt.Sprintf("%s stores one vector per record; set a primary vector for records with several", target))
+		issues++
+	}
+
+	sourceSparse := sourceCaps.SparseVector || sourceCaps.NamedSparseVectors
+	switch {
+	case sourceSparse && !targetCaps.SparseVector && !targetCaps.NamedSparseVectors:
+		warnings = append(warnings, fmt.Sprintf("%s cannot store sparse vectors; the sparse policy decides whether they fail the migration, are dropped or are kept in metadata", target))
+		issues++
+	case sourceCaps.NamedSparseVectors && !targetCaps.NamedSparseVectors:
+		warnings = append(warnings, fmt.Sprintf("%s stores one sparse vector per record; set the sparse vector to carry over", target))
+	}
+
+	if limit := targetCaps.MaxMetadataBytes; limit > 0 && (sourceCaps.MaxMetadataBytes == 0 || sourceCaps.MaxMetadataBytes > limit) {
+		warnings = append(warnings, fmt.Sprintf("%s limits metadata to %d KB per record; larger records will fail", target, limit/1024))
+		issues++
+	}
+
+	return warnings, issues
+}
+
+// acceptsIDFormats reports whether every ID in one of formats is accepted
+// by caps
+func acceptsIDFormats(caps adapters.Capabilities, formats []adapters.IDFormat) bool {
+	accepted := make(map[adapters.IDFormat]bool, len(caps.IDFormats))
+	for _, format := range caps.IDFormats {
+		accepted[format] = true
+	}
+	if accepted[adapters.IDString] {
+		return true
+	}
+	for _, format := range formats {
+		if !accepted[format] {
+			return false
+		}
+	}
+	return true
+}
+
+// joinIDFormats lists ID formats for warnings
+func joinIDFormats(formats []adapters.IDFormat) string {
+	names := make([]string, len(formats))
+	for i, format := range formats {
+		names[i] = string(format)
+	}
+	return strings.Join(names, " or ")
+}
+
+// databaseNames are display names for database types
+var databaseNames = map[string]string{
+	"pinecone":      "Pinecone",
+	"qdrant":        "Qdrant",
+	"weaviate":      "Weaviate",
+	"milvus":        "Milvus",
+	"redis":         "Redis",
+	"chroma":        "Chroma",
+	"elasticsearch": "Elasticsearch",
+	"opensearch":    "OpenSearch",
+	"jsonl":         "JSONL",
+	"npy":           "NumPy",
+}
+
+// databaseName returns the display name of a database type
+func databaseName(dbType string) string {
+	if name, ok := databaseNames[dbType]; ok {
+		return name
+	}
+	return dbType
+}
@@ -309,3 +309,48 @@ func contains(s, substr string) bool {
 	}
 	return false
 }
+
+func TestSchemaRecommendationTool_CapabilityWarnings(t *testing.T) {
+	tool := NewSchemaRecommendationTool()
+	ctx := context.Background()
+
+	testCases := []struct {
+		source        string
+		target        string
+		expectWarning string
+	}{
+		{"qdrant", "weaviate", "uuid IDs"},
+		{"qdrant", "chroma", "one vector per record"},
+		{"pinecone", "chroma", "sparse policy"},
+		{"qdrant", "pinecone", "40 KB"},
+	}
+
+	for _, tc := range testCases {
+		result, err := tool.execute(ctx, map[string]interface{}{
+			"source_type": tc.source,
+			"target_type": tc.target,
+		})
+		if err != nil {
+			t.Fatalf("execute failed for %s→%s: %v", tc.source, tc.target, err)
+		}
+		rec := result.(*SchemaRecommendation)
+
+		found := false
+		for _, warning := range rec.Warnings {
+			if containsIgnoreCase(warning, tc.expectWarning) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("Expected warning about '%s' for %s→%s, got %v",
+				tc.expectWarning, tc.source, tc.target, rec.Warnings)
+		}
+	}
+
+	// Both store the same things, so there is nothing to warn about
+	result, _ := tool.execute(ctx, map[string]interface{}{"source_type": "elasticsearch", "target_type": "opensearch"})
+	if rec := result.(*SchemaRecommendation); len(rec.Warnings) != 0 || rec.OverallConfidence != 0.95 {
+		t.Errorf("Expected no warnings and confidence 0.95, got %v and %.2f", rec.Warnings, rec.OverallConfidence)
+	}
+}
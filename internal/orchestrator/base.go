@@ -6,6 +6,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/AlphaTechini/vector-db-migration/internal/adapters"
 	"github.com/AlphaTechini/vector-db-migration/internal/state"
 )
 
@@ -16,9 +17,23 @@ type BaseOrchestrator struct {
 	mu          sync.RWMutex
 	isRunning   bool
 	isPaused    bool
+	parent      context.Context // Context the migration was started with, which Resume restarts it in
 	ctx         context.Context
 	cancel      context.CancelFunc
+	done        chan struct{} // Closed when the run's worker has returned
 	stats       *MigrationStats
+	namespaces  map[string]state.NamespaceCheckpoint
+	partitions  map[string]state.PartitionCheckpoint
+	
+	// Keys of a partitioned scan, fixed by the first run so a resumed run
+	// reads the same partitions
+	partitionKeys []string
+	
+	// Committed position of a single-scan migration, which a resumed run
+	// continues from, and the schema mapping saved with checkpoints
+	afterID       string
+	cursor        string
+	schemaMapping map[string]interface{}
 }
 
 // NewBaseOrchestrator creates a new base orchestrator
@@ -41,9 +56,13 @@ func (o *BaseOrchestrator) Start(ctx context.Context, config MigrationConfig) er
 	}
 	
 	o.config = config
+	o.parent = ctx
 	o.ctx, o.cancel = context.WithCancel(ctx)
+	o.done = make(chan struct{})
 	o.isRunning = true
 	o.isPaused = false
+	o.namespaces, o.partitions, o.partitionKeys = nil, nil, nil
+	o.afterID, o.cursor, o.schemaMapping = "", "", nil
 	
 	// Initialize stats
 	o.stats = &MigrationStats{
@@ -52,13 +71,7 @@ func (o *BaseOrchestrator) Start(ctx context.Context, config MigrationConfig) er
 	}
 	
 	// Set initial state
-	checkpoint := &state.Checkpoint{
-		MigrationID:      o.migrationID,
-		StartedAt:        time.Now(),
-		LastCheckpointAt: time.Now(),
-	}
-	
-	if err := config.StateTracker.SaveCheckpoint(checkpoint); err != nil {
+	if err := o.saveCheckpoint("", ""); err != nil {
 		return fmt.Errorf("failed to save initial checkpoint: %w", err)
 	}
 	
@@ -70,15 +83,33 @@ func (o *BaseOrchestrator) Start(ctx context.Context, config MigrationConfig) er
 
 // runMigration executes the migration logic
 func (o *BaseOrchestrator) runMigration() {
+	o.mu.RLock()
+	ctx, exited := o.ctx, o.done
+	o.mu.RUnlock()
+	
+	// A parked run may already have been resumed, and the new run is left
+	// alone
 	defer func() {
 		o.mu.Lock()
-		o.isRunning = false
-		o.cancel()
+		if o.ctx == ctx {
+			o.isRunning = false
+			o.cancel()
+		}
 		o.mu.Unlock()
+		close(exited)
 	}()
 	
+	if o.config.NamespaceRouting != nil {
+		o.runNamespaces()
+		return
+	}
+	
 	// Get source stats to know total records
-	sourceStats, err := o.config.SourceDB.GetStats(o.ctx)
+	var sourceStats *adapters.DBStats
+	err := o.retry(o.ctx, func() (err error) {
+		sourceStats, err = o.config.SourceDB.GetStats(o.ctx)
+		return err
+	})
 	if err != nil {
 		o.fail(fmt.Sprintf("failed to get source stats: %v", err))
 		return
@@ -88,88 +119,103 @@ func (o *BaseOrchestrator) runMigration() {
 	o.stats.TotalRecords = sourceStats.TotalRecords
 	o.mu.Unlock()
 	
-	// Process batches
-	batchNum := 0
-	var afterID string
+	var done bool
+	if partitioned, ok := o.config.SourceDB.(adapters.Partitioned); ok && o.config.Partitions > 1 {
+		done, err = o.runPartitions(partitioned, sourceStats)
+	} else {
+		done, err = o.migrateSource(o.config.SourceDB, o.config.TargetDB, sourceStats, nil)
+	}
+	if err != nil {
+		o.fail(err.Error())
+		return
+	}
+	if done {
+		o.complete()
+	} else {
+		o.park()
+	}
+}
+
+// migrateSource copies every record of source into target, returning false
+// if the migration was paused or cancelled first. ns is the namespace being
+// migrated, or nil for a single-source migration. Targets that provision
+// their schema are provisioned from sourceStats before the first write.
+func (o *BaseOrchestrator) migrateSource(source, target adapters.Database, sourceStats *adapters.DBStats, ns *namespaceRun) (bool, error) {
+	// Map from the schemas as they stand before the first batch
+	mapping, err := o.mappingFor(source, target, ns)
+	if err != nil {
+		return false, err
+	}
 	
-	for {
-		// Check if paused or cancelled
-		o.mu.RLock()
-		if o.isPaused || o.ctx.Err() != nil {
-			o.mu.RUnlock()
-			return
-		}
-		o.mu.RUnlock()
-		
-		// Get next batch
-		batchSize := o.config.BatchSize
-		if batchSize == 0 {
-			batchSize = 100 // Default
-		}
-		
-		records, err := o.config.SourceDB.GetBatch(o.ctx, afterID, batchSize)
-		if err != nil {
-			o.fail(fmt.Sprintf("failed to get batch %d: %v", batchNum, err))
-			return
-		}
-		
-		if len(records) == 0 {
-			// No more records, migration complete
-			o.complete()
-			return
-		}
-		
-		// Map records to target schema
-		mappedRecords, err := o.config.SchemaMapper.MapBatch(records, nil)
-		if err != nil {
-			o.fail(fmt.Sprintf("failed to map batch %d: %v", batchNum, err))
-			return
-		}
-		
-		// Upsert to target
-		if err := o.config.TargetDB.UpsertBatch(o.ctx, mappedRecords); err != nil {
-			o.fail(fmt.Sprintf("failed to upsert batch %d: %v", batchNum, err))
-			return
+	s := &scan{source: source, ns: ns, afterID: o.afterID, cursor: o.cursor}
+	if ns != nil {
+		s.afterID, s.cursor = ns.checkpoint.LastProcessedID, ns.checkpoint.Cursor
+	}
+	return o.runPipeline(target, mapping, sourceStats, []*scan{s})
+}
+
+// batchSize returns the configured batch size, capped at the largest batch
+// the source or target accepts
+func (o *BaseOrchestrator) batchSize(source, target adapters.Database) int {
+	batchSize := o.config.BatchSize
+	if batchSize == 0 {
+		batchSize = 100 // Default
+	}
+	
+	for _, db := range []adapters.Database{source, target} {
+		reporter, ok := db.(adapters.CapabilityReporter)
+		if !ok {
+			continue
 		}
-		
-		// Update progress
-		o.mu.Lock()
-		o.stats.BatchesProcessed++
-		o.stats.MigratedRecords += int64(len(records))
-		if len(records) > 0 {
-			afterID = records[len(records)-1].ID
+		if max := reporter.Capabilities().MaxBatchSize; max > 0 && batchSize > max {
+			batchSize = max
 		}
-		
-		// Save checkpoint every N batches
-		validateEvery := o.config.ValidateEvery
-		if validateEvery == 0 {
-			validateEvery = 10
+	}
+	return batchSize
+}
+
+// saveCheckpoint persists the current progress. Callers must hold o.mu.
+func (o *BaseOrchestrator) saveCheckpoint(afterID, cursor string) error {
+	checkpoint := &state.Checkpoint{
+		MigrationID:      o.migrationID,
+		LastProcessedID:  afterID,
+		Cursor:           cursor,
+		TotalRecords:     o.stats.TotalRecords,
+		ProcessedCount:   o.stats.MigratedRecords,
+		FailedCount:      o.stats.FailedRecords,
+		BatchesProcessed: o.stats.BatchesProcessed,
+		StartedAt:        parseTime(o.stats.StartTime),
+		LastCheckpointAt: time.Now(),
+		SchemaMapping:    o.schemaMapping,
+		Source:           endpoint(o.config.SourceConfig),
+		Target:           endpoint(o.config.TargetConfig),
+		PartitionKeys:    o.partitionKeys,
+	}
+	if o.config.Partitions > 1 {
+		checkpoint.PartitionCount = o.config.Partitions
+	}
+	
+	if len(o.namespaces) > 0 {
+		checkpoint.Namespaces = make(map[string]state.NamespaceCheckpoint, len(o.namespaces))
+		for name, ns := range o.namespaces {
+			checkpoint.Namespaces[name] = ns
 		}
-		
-		if batchNum%validateEvery == 0 {
-			checkpoint := &state.Checkpoint{
-				MigrationID:      o.migrationID,
-				LastProcessedID:  afterID,
-				TotalRecords:     o.stats.TotalRecords,
-				ProcessedCount:   o.stats.MigratedRecords,
-				FailedCount:      o.stats.FailedRecords,
-				StartedAt:        parseTime(o.stats.StartTime),
-				LastCheckpointAt: time.Now(),
-			}
-			
-			if err := o.config.StateTracker.SaveCheckpoint(checkpoint); err != nil {
-				o.mu.Unlock()
-				o.fail(fmt.Sprintf("failed to save checkpoint: %v", err))
-				return
-			}
+	}
+	
+	if len(o.partitions) > 0 {
+		checkpoint.Partitions = make(map[string]state.PartitionCheckpoint, len(o.partitions))
+		for key, part := range o.partitions {
+			checkpoint.Partitions[key] = part
 		}
-		o.mu.Unlock()
-		
-		batchNum++
 	}
+	
+	return o.config.StateTracker.SaveCheckpoint(checkpoint)
 }
 
-// Pause pauses an in-progress migration
+// Pause parks an in-progress migration at the next batch boundary: reads
+// stop, batches already read are written, and the status is "pausing"
+// until the worker has saved a checkpoint at the committed position and
+// exited. The status is then "paused" and the paused state persisted.
 func (o *BaseOrchestrator) Pause(migrationID string) error {
 	if migrationID != o.migrationID {
 		return fmt.Errorf("migration ID mismatch")
@@ -178,52 +224,81 @@ func (o *BaseOrchestrator) Pause(migrationID string) error {
 	o.mu.Lock()
 	defer o.mu.Unlock()
 	
-	if !o.isRunning {
+	if !o.isRunning || o.isPaused {
 		return fmt.Errorf("migration not running")
 	}
 	
 	o.isPaused = true
-	o.stats.Status = "paused"
+	o.stats.Status = "pausing"
 	
 	return nil
 }
 
-// Resume resumes a paused migration
+// Resume restarts a paused migration from the checkpoint it was parked at
 func (o *BaseOrchestrator) Resume(migrationID string) error {
 	if migrationID != o.migrationID {
 		return fmt.Errorf("migration ID mismatch")
 	}
 	
 	o.mu.Lock()
-	defer o.mu.Unlock()
-	
 	if !o.isPaused {
+		o.mu.Unlock()
 		return fmt.Errorf("migration not paused")
 	}
+	if o.isRunning {
+		o.mu.Unlock()
+		return fmt.Errorf("migration still pausing")
+	}
+	ctx, config := o.parent, o.config
+	o.mu.Unlock()
 	
-	o.isPaused = false
-	o.stats.Status = "in_progress"
+	return o.ResumeFromCheckpoint(ctx, config)
+}
+
+// park ends a run that stopped before finishing. A paused run has written
+// every batch it read, so its checkpoint is saved at the committed
+// position and Resume writes no batch again. Once it is parked, Resume
+// may restart it. Stopped runs are left as they are.
+func (o *BaseOrchestrator) park() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
 	
-	return nil
+	if !o.isPaused {
+		return
+	}
+	
+	o.isRunning = false
+	o.cancel()
+	o.stats.Status = "paused"
+	if err := o.saveCheckpoint(o.afterID, o.cursor); err != nil {
+		o.stats.Status = fmt.Sprintf("failed: failed to save checkpoint: %v", err)
+		o.isPaused = false
+		_ = o.config.StateTracker.SetState(o.migrationID, state.StateFailed)
+		return
+	}
+	_ = o.config.StateTracker.SetState(o.migrationID, state.StatePaused)
 }
 
-// Stop stops a migration gracefully
+// Stop stops a migration gracefully. It returns once the run has exited,
+// so the migration can be resumed straight away.
 func (o *BaseOrchestrator) Stop(migrationID string) error {
 	if migrationID != o.migrationID {
 		return fmt.Errorf("migration ID mismatch")
 	}
 	
 	o.mu.Lock()
-	defer o.mu.Unlock()
-	
 	if !o.isRunning {
+		o.mu.Unlock()
 		return fmt.Errorf("migration not running")
 	}
 	
 	o.cancel()
 	o.stats.Status = "stopped"
-	o.isRunning = false
+	o.isPaused = false
+	done := o.done
+	o.mu.Unlock()
 	
+	<-done
 	return nil
 }
 
@@ -254,6 +329,24 @@ func (o *BaseOrchestrator) GetStatus(migrationID string) (*MigrationStats, error
 	
 	// Return a copy
 	statsCopy := *o.stats
+	if o.stats.Validation != nil {
+		validationCopy := *o.stats.Validation
+		statsCopy.Validation = &validationCopy
+	}
+	if o.stats.Namespaces != nil {
+		statsCopy.Namespaces = make(map[string]*NamespaceStats, len(o.stats.Namespaces))
+		for name, ns := range o.stats.Namespaces {
+			nsCopy := *ns
+			statsCopy.Namespaces[name] = &nsCopy
+		}
+	}
+	if o.stats.Partitions != nil {
+		statsCopy.Partitions = make(map[string]*PartitionStats, len(o.stats.Partitions))
+		for key, part := range o.stats.Partitions {
+			partCopy := *part
+			statsCopy.Partitions[key] = &partCopy
+		}
+	}
 	return &statsCopy, nil
 }
 
@@ -263,12 +356,22 @@ func (o *BaseOrchestrator) Validate(migrationID string) error {
 		return fmt.Errorf("migration ID mismatch")
 	}
 	
-	// TODO: Implement validation logic
-	// Sample records from source and target
+	// TODO: Sample records from source and target
 	// Compare vectors (cosine similarity)
 	// Compare metadata
 	// Report discrepancies
 	
+	// Compare search results; the migration's own context may already be
+	// done
+	search, err := o.compareSearch(context.Background())
+	if err != nil {
+		return fmt.Errorf("search comparison failed: %w", err)
+	}
+	
+	o.mu.Lock()
+	o.stats.Validation = &ValidationResult{Search: search}
+	o.mu.Unlock()
+	
 	return nil
 }
 
@@ -280,29 +383,27 @@ func (o *BaseOrchestrator) complete() {
 	o.stats.Status = "completed"
 	o.stats.EndTime = time.Now().Format(time.RFC3339)
 	o.isRunning = false
+	o.isPaused = false
 	
 	// Save final checkpoint
-	checkpoint := &state.Checkpoint{
-		MigrationID:      o.migrationID,
-		TotalRecords:     o.stats.TotalRecords,
-		ProcessedCount:   o.stats.MigratedRecords,
-		FailedCount:      o.stats.FailedRecords,
-		StartedAt:        parseTime(o.stats.StartTime),
-		LastCheckpointAt: time.Now(),
-	}
-	
-	_ = o.config.StateTracker.SaveCheckpoint(checkpoint)
+	_ = o.saveCheckpoint("", "")
 	_ = o.config.StateTracker.SetState(o.migrationID, state.StateCompleted)
 }
 
-// fail marks migration as failed
+// fail marks migration as failed. Errors of a stopped run are caused by
+// stopping it and are ignored.
 func (o *BaseOrchestrator) fail(reason string) {
 	o.mu.Lock()
 	defer o.mu.Unlock()
 	
+	if o.stats.Status == "stopped" {
+		return
+	}
+	
 	o.stats.Status = fmt.Sprintf("failed: %s", reason)
 	o.stats.EndTime = time.Now().Format(time.RFC3339)
 	o.isRunning = false
+	o.isPaused = false
 	
 	_ = o.config.StateTracker.SetState(o.migrationID, state.StateFailed)
 }
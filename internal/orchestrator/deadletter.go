@@ -0,0 +1,107 @@
+package orchestrator
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/AlphaTechini/vector-db-migration/internal/adapters"
+	"github.com/AlphaTechini/vector-db-migration/internal/state"
+)
+
+// deadLetters returns the state tracker's dead-letter store, or nil if it
+// has none, in which case any failed record fails the migration
+func (o *BaseOrchestrator) deadLetters() state.DeadLetterStore {
+	store, _ := o.config.StateTracker.(state.DeadLetterStore)
+	return store
+}
+
+// deadLetter sets record aside from batch b, failed at stage
+func (o *BaseOrchestrator) deadLetter(b *batch, stage string, record adapters.Record, err error) state.DeadLetter {
+	data, marshalErr := json.Marshal(record)
+	if marshalErr != nil {
+		data = []byte(fmt.Sprintf(`{"id":%q}`, record.ID))
+	}
+
+	letter := state.DeadLetter{
+		MigrationID: o.migrationID,
+		RecordID:    record.ID,
+		Stage:       stage,
+		Error:       err.Error(),
+		Record:      data,
+	}
+	if ns := b.scan.ns; ns != nil {
+		letter.Namespace = ns.name
+	}
+	return letter
+}
+
+// mapRecords maps a batch record by record after the batch failed to map,
+// setting aside the records that fail
+func (p *pipeline) mapRecords(b *batch) []adapters.Record {
+	mapped := make([]adapters.Record, 0, len(b.records))
+	b.sources = make([]int, 0, len(b.records))
+	for i, record := range b.records {
+		result, err := p.o.config.SchemaMapper.MapRecord(record, p.mapping)
+		if err != nil {
+			b.setAsideRecord(i, p.o.deadLetter(b, state.DeadLetterMap, record, err))
+			continue
+		}
+		mapped = append(mapped, result)
+		b.sources = append(b.sources, i)
+	}
+	return mapped
+}
+
+// isolate finds the records of a batch the target rejected by writing
+// halves of it until each rejected record is on its own, setting those
+// aside. Errors that are not permanent, and batches with no record the
+// target accepts, fail the batch as a whole: they are not the records'
+// fault.
+func (p *pipeline) isolate(b *batch, err error) error {
+	if kind, _ := adapters.ClassifyError(err); kind != adapters.ErrorPermanent || p.ctx.Err() != nil {
+		return err
+	}
+
+	// Rejected records, by index in b.mapped
+	rejected := make(map[int]state.DeadLetter)
+	written := 0
+	var bisect func(start, end int, err error) error
+	bisect = func(start, end int, err error) error {
+		if end-start == 1 {
+			rejected[start] = p.o.deadLetter(b, state.DeadLetterUpsert, b.mapped[start], err)
+			return nil
+		}
+
+		half := start + (end-start)/2
+		for _, part := range [][2]int{{start, half}, {half, end}} {
+			records := b.mapped[part[0]:part[1]]
+			err := p.o.retry(p.ctx, func() error {
+				return p.target.UpsertBatch(p.ctx, records)
+			})
+			if err == nil {
+				written += len(records)
+				continue
+			}
+			if kind, _ := adapters.ClassifyError(err); kind != adapters.ErrorPermanent || p.ctx.Err() != nil {
+				return err
+			}
+			if err := bisect(part[0], part[1], err); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := bisect(0, len(b.mapped), err); err != nil {
+		return err
+	}
+	if written == 0 && len(b.mapped) > 1 {
+		return err
+	}
+	for i := range b.mapped {
+		if letter, ok := rejected[i]; ok {
+			b.setAsideRecord(b.source(i), letter)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package orchestrator
+
+import (
+	"fmt"
+
+	"github.com/AlphaTechini/vector-db-migration/internal/adapters"
+	"github.com/AlphaTechini/vector-db-migration/internal/mapper"
+)
+
+// mappingFor returns the schema mapping to copy source into target with:
+// the one saved in the checkpoint being resumed, or a new one, which later
+// checkpoints save. ns is the namespace being migrated, or nil.
+func (o *BaseOrchestrator) mappingFor(source, target adapters.Database, ns *namespaceRun) (*mapper.SchemaMapping, error) {
+	o.mu.RLock()
+	saved := o.schemaMapping
+	if ns != nil {
+		saved = ns.checkpoint.SchemaMapping
+	}
+	o.mu.RUnlock()
+
+	if saved != nil {
+		mapping, err := mapper.DecodeMapping(saved)
+		if err != nil {
+			return nil, fmt.Errorf("failed to restore schema mapping: %v", err)
+		}
+		return mapping, nil
+	}
+
+	mapping, err := o.buildMapping(source, target)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := mapper.EncodeMapping(mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	o.mu.Lock()
+	if ns != nil {
+		ns.checkpoint.SchemaMapping = encoded
+		o.namespaces[ns.name] = ns.checkpoint
+	} else {
+		o.schemaMapping = encoded
+	}
+	o.mu.Unlock()
+	return mapping, nil
+}
+
+// buildMapping creates the schema mapping records are copied with. Fields
+// are matched between the source's schema, read natively or sampled, and
+// the target's; a target without a schema yet receives the source's
+// fields as they are. Fields the mapping cannot place are kept, since a
+// sample may miss fields that only later records carry.
+func (o *BaseOrchestrator) buildMapping(source, target adapters.Database) (*mapper.SchemaMapping, error) {
+	sourceSchema, err := o.readSchema(source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read source schema: %v", err)
+	}
+
+	// The target's schema only refines field names, so a target that
+	// cannot report one, or has none yet, takes the source's
+	targetFields := sourceSchema.TopLevel()
+	if reader, ok := target.(adapters.SchemaReader); ok {
+		if targetSchema, err := reader.GetSchema(o.ctx); err == nil && len(targetSchema.Fields) > 0 {
+			targetFields = targetSchema.TopLevel()
+		}
+	}
+
+	mapping, err := o.config.SchemaMapper.CreateMapping(sourceSchema.TopLevel(), targetFields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create schema mapping: %v", err)
+	}
+	mapping.KeepUnmapped = true
+	mapping.PrimaryVector = o.config.PrimaryVector
+	mapping.SparseVector = o.config.SparseVector
+	mapping.SparsePolicy = o.config.SparsePolicy
+	mapping.SparseField = o.config.SparseField
+
+	if err := o.config.SchemaMapper.ValidateMapping(mapping); err != nil {
+		return nil, fmt.Errorf("invalid schema mapping: %v", err)
+	}
+	return mapping, nil
+}
+
+// readSchema returns db's schema, sampling its records if it cannot
+// report one
+func (o *BaseOrchestrator) readSchema(db adapters.Database) (schema *adapters.Schema, err error) {
+	err = o.retry(o.ctx, func() error {
+		if reader, ok := db.(adapters.SchemaReader); ok {
+			schema, err = reader.GetSchema(o.ctx)
+		} else {
+			schema, err = adapters.SampleSchema(o.ctx, db, adapters.DefaultSchemaSample)
+		}
+		return err
+	})
+	return schema, err
+}
@@ -0,0 +1,264 @@
+package orchestrator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/AlphaTechini/vector-db-migration/internal/adapters"
+	"github.com/AlphaTechini/vector-db-migration/internal/state"
+)
+
+// RouteMode selects where a source namespace's records are written
+type RouteMode string
+
+const (
+	// RouteField writes into the configured target, storing the namespace
+	// name in a payload field. Record IDs must be unique across the
+	// namespaces that share the target.
+	RouteField RouteMode = "field"
+
+	// RouteCollection writes into a separate target collection or index
+	RouteCollection RouteMode = "collection"
+
+	// RouteTenant writes into a tenant of a multi-tenant target (Weaviate)
+	RouteTenant RouteMode = "tenant"
+)
+
+// namespacePlaceholder in a route target is replaced by the namespace name
+const namespacePlaceholder = "{namespace}"
+
+// NamespaceRoute is the routing rule for one source namespace
+type NamespaceRoute struct {
+	Mode RouteMode
+
+	// Target is the field, collection or tenant name and may contain
+	// {namespace}
+	Target string
+}
+
+// ParseNamespaceRoute parses a rule of the form mode[:target], e.g.
+// "field:tenant_id", "collection:docs_{namespace}" or "tenant". Without a
+// target, field routes use "namespace" and the others the namespace name.
+func ParseNamespaceRoute(rule string) (NamespaceRoute, error) {
+	mode, target, _ := strings.Cut(rule, ":")
+	route := NamespaceRoute{Mode: RouteMode(mode), Target: target}
+
+	switch route.Mode {
+	case RouteField:
+		if route.Target == "" {
+			route.Target = "namespace"
+		}
+	case RouteCollection, RouteTenant:
+		if route.Target == "" {
+			route.Target = namespacePlaceholder
+		}
+	default:
+		return NamespaceRoute{}, fmt.Errorf("unknown namespace route mode %q (expected field, collection or tenant)", mode)
+	}
+
+	return route, nil
+}
+
+// String formats the route as accepted by ParseNamespaceRoute
+func (r NamespaceRoute) String() string {
+	return string(r.Mode) + ":" + r.Target
+}
+
+// resolve substitutes the namespace name into the route target
+func (r NamespaceRoute) resolve(namespace string) NamespaceRoute {
+	r.Target = strings.ReplaceAll(r.Target, namespacePlaceholder, namespace)
+	return r
+}
+
+// apply tags records with the namespace for field routes
+func (r NamespaceRoute) apply(namespace string, records []adapters.Record) []adapters.Record {
+	if r.Mode != RouteField {
+		return records
+	}
+
+	tagged := make([]adapters.Record, len(records))
+	for i, record := range records {
+		metadata := make(map[string]interface{}, len(record.Metadata)+1)
+		for k, v := range record.Metadata {
+			metadata[k] = v
+		}
+		metadata[r.Target] = namespace
+		record.Metadata = metadata
+		tagged[i] = record
+	}
+	return tagged
+}
+
+// NamespaceRouting configures a multi-namespace migration
+type NamespaceRouting struct {
+	// Namespaces to migrate; empty migrates every namespace the source lists
+	Namespaces []string
+
+	// Routes holds per-namespace rules; Default applies to the rest
+	Routes  map[string]NamespaceRoute
+	Default NamespaceRoute
+
+	// TargetFor opens the target for a resolved collection or tenant route.
+	// The orchestrator closes the databases it returns.
+	TargetFor func(route NamespaceRoute) (adapters.Database, error)
+}
+
+// RouteFor returns the resolved rule for a namespace
+func (r *NamespaceRouting) RouteFor(namespace string) NamespaceRoute {
+	route, ok := r.Routes[namespace]
+	if !ok {
+		route = r.Default
+	}
+	return route.resolve(namespace)
+}
+
+// namespaceRun is the state of the namespace currently being migrated
+type namespaceRun struct {
+	name       string
+	route      NamespaceRoute
+	stats      *NamespaceStats
+	checkpoint state.NamespaceCheckpoint
+
+	// sourceStats are the namespace's source stats, used to provision targets
+	sourceStats *adapters.DBStats
+}
+
+// runNamespaces migrates the selected namespaces of the source one after
+// another, each according to its route. Namespaces already checkpointed in
+// o.namespaces continue from their checkpoints; completed ones are skipped.
+func (o *BaseOrchestrator) runNamespaces() {
+	routing := o.config.NamespaceRouting
+
+	source, ok := o.config.SourceDB.(adapters.Namespaced)
+	if !ok {
+		o.fail("source database does not support namespaces")
+		return
+	}
+
+	names := routing.Namespaces
+	if len(names) == 0 {
+		listed, err := source.ListNamespaces(o.ctx)
+		if err != nil {
+			o.fail(fmt.Sprintf("failed to list namespaces: %v", err))
+			return
+		}
+		names = listed
+	}
+	names = append([]string(nil), names...)
+	sort.Strings(names)
+
+	o.mu.RLock()
+	resumed := o.namespaces
+	o.mu.RUnlock()
+
+	// Count every namespace up front so overall progress is meaningful
+	runs := make([]*namespaceRun, len(names))
+	sources := make([]adapters.Database, len(names))
+	for i, name := range names {
+		sources[i] = source.WithNamespace(name)
+		var sourceStats *adapters.DBStats
+		err := o.retry(o.ctx, func() (err error) {
+			sourceStats, err = sources[i].GetStats(o.ctx)
+			return err
+		})
+		if err != nil {
+			o.fail(fmt.Sprintf("failed to get stats for namespace %q: %v", name, err))
+			return
+		}
+
+		route := routing.RouteFor(name)
+		checkpoint := resumed[name]
+		checkpoint.TotalRecords = sourceStats.TotalRecords
+		runs[i] = &namespaceRun{
+			name:  name,
+			route: route,
+			stats: &NamespaceStats{
+				TotalRecords:    sourceStats.TotalRecords,
+				MigratedRecords: checkpoint.ProcessedCount,
+				Route:           route.String(),
+				Status:          "pending",
+			},
+			checkpoint:  checkpoint,
+			sourceStats: sourceStats,
+		}
+		if checkpoint.Completed {
+			runs[i].stats.Status = "completed"
+		}
+	}
+
+	o.mu.Lock()
+	o.stats.Namespaces = make(map[string]*NamespaceStats, len(runs))
+	o.namespaces = make(map[string]state.NamespaceCheckpoint, len(runs))
+	for _, run := range runs {
+		o.stats.TotalRecords += run.stats.TotalRecords
+		o.stats.Namespaces[run.name] = run.stats
+		o.namespaces[run.name] = run.checkpoint
+	}
+	o.mu.Unlock()
+
+	for i, run := range runs {
+		if run.checkpoint.Completed {
+			continue
+		}
+
+		target, err := o.config.NamespaceRouting.Target(run.route, o.config.TargetDB)
+		if err != nil {
+			o.failNamespace(run, fmt.Sprintf("failed to open target: %v", err))
+			return
+		}
+
+		o.mu.Lock()
+		run.stats.Status = "in_progress"
+		o.mu.Unlock()
+
+		done, err := o.migrateSource(sources[i], target, run.sourceStats, run)
+		if target != o.config.TargetDB {
+			target.Close()
+		}
+		if err != nil {
+			o.failNamespace(run, err.Error())
+			return
+		}
+		if !done {
+			o.park()
+			return
+		}
+
+		o.mu.Lock()
+		run.stats.Status = "completed"
+		run.checkpoint.Cursor = ""
+		run.checkpoint.Completed = true
+		o.namespaces[run.name] = run.checkpoint
+		err = o.saveCheckpoint("", "")
+		o.mu.Unlock()
+		if err != nil {
+			o.fail(fmt.Sprintf("failed to save checkpoint: %v", err))
+			return
+		}
+	}
+
+	o.complete()
+}
+
+// Target returns the database a resolved route writes to: target for
+// field routes, or a database opened with TargetFor, which the caller
+// closes
+func (r *NamespaceRouting) Target(route NamespaceRoute, target adapters.Database) (adapters.Database, error) {
+	if route.Mode == RouteField {
+		return target, nil
+	}
+	if r.TargetFor == nil {
+		return nil, fmt.Errorf("%s routes need a target factory", route.Mode)
+	}
+	return r.TargetFor(route)
+}
+
+// failNamespace marks a namespace and the migration as failed
+func (o *BaseOrchestrator) failNamespace(run *namespaceRun, reason string) {
+	o.mu.Lock()
+	run.stats.Status = "failed"
+	o.mu.Unlock()
+
+	o.fail(fmt.Sprintf("namespace %q: %s", run.name, reason))
+}
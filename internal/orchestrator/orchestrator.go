@@ -2,6 +2,7 @@ package orchestrator
 
 import (
 	"context"
+	"time"
 
 	"github.com/AlphaTechini/vector-db-migration/internal/adapters"
 	"github.com/AlphaTechini/vector-db-migration/internal/mapper"
@@ -17,6 +18,51 @@ type MigrationConfig struct {
 	BatchSize     int
 	MaxRetries    int
 	ValidateEvery int // Validate every N batches
+	
+	// SourceConfig and TargetConfig describe SourceDB and TargetDB for the
+	// checkpoint, which records their type, URL, index and namespace (not
+	// credentials) so ResumeFromCheckpoint can check it continues the same
+	// migration. Left empty, the check is skipped.
+	SourceConfig adapters.DBConfig
+	TargetConfig adapters.DBConfig
+	
+	// RetryBackoff is the wait before the first retry of a failed read or
+	// write (default 500ms); it doubles per retry up to MaxRetryBackoff
+	// (default 30s). Only errors adapters classify as retryable or rate
+	// limited are retried, at most MaxRetries times per call.
+	RetryBackoff    time.Duration
+	MaxRetryBackoff time.Duration
+	
+	// NamespaceRouting, when set, migrates each namespace of a Namespaced
+	// source separately instead of the source as a whole
+	NamespaceRouting *NamespaceRouting
+	
+	// Partitions, when above 1, splits a Partitioned source into about
+	// that many partitions that are scanned concurrently. It does not
+	// apply to namespace migrations.
+	Partitions int
+	
+	// Readers, Mappers and Writers size the read, map and write stages of
+	// the copy pipeline (default 1 each; Readers defaults to Partitions).
+	// One scan is read sequentially, so extra readers need partitions.
+	Readers int
+	Mappers int
+	Writers int
+	
+	// PrimaryVector, SparseVector, SparsePolicy and SparseField set the
+	// vector handling of the schema mapping; see mapper.SchemaMapping
+	PrimaryVector string
+	SparseVector  string
+	SparsePolicy  mapper.SparsePolicy
+	SparseField   string
+	
+	// SearchK, SearchQueries and SearchFilter configure the search
+	// comparison Validate runs when source and target are Searchers: the
+	// neighbours compared per query (default 10), the source vectors used
+	// as queries (default 20) and an optional metadata filter
+	SearchK       int
+	SearchQueries int
+	SearchFilter  adapters.Filter
 }
 
 // MigrationStats tracks migration progress
@@ -25,9 +71,36 @@ type MigrationStats struct {
 	MigratedRecords  int64 `json:"migrated_records"`
 	FailedRecords    int64 `json:"failed_records"`
 	BatchesProcessed int64 `json:"batches_processed"`
+	Retries          int64 `json:"retries"`      // Adapter calls repeated after a retryable error
+	RateLimited      int64 `json:"rate_limited"` // Retries of those the database rate limited
 	StartTime        string `json:"start_time"`
 	EndTime          string `json:"end_time,omitempty"`
 	Status           string `json:"status"`
+	Namespaces       map[string]*NamespaceStats `json:"namespaces,omitempty"`
+	Partitions       map[string]*PartitionStats `json:"partitions,omitempty"`
+	
+	// Sparse counts written records whose sparse vectors the mapper's
+	// sparse policy dropped or stashed in metadata
+	Sparse mapper.SparseReport `json:"sparse"`
+	
+	// Validation holds the result of the last Validate run
+	Validation *ValidationResult `json:"validation,omitempty"`
+}
+
+// NamespaceStats tracks progress through one source namespace
+type NamespaceStats struct {
+	TotalRecords     int64  `json:"total_records"`
+	MigratedRecords  int64  `json:"migrated_records"`
+	BatchesProcessed int64  `json:"batches_processed"`
+	Route            string `json:"route"`
+	Status           string `json:"status"`
+}
+
+// PartitionStats tracks progress through one partition of the source
+type PartitionStats struct {
+	MigratedRecords  int64  `json:"migrated_records"`
+	BatchesProcessed int64  `json:"batches_processed"`
+	Status           string `json:"status"`
 }
 
 // MigrationOrchestrator interface for coordinating migrations
@@ -41,6 +114,10 @@ type MigrationOrchestrator interface {
 	// Resume resumes a paused migration
 	Resume(migrationID string) error
 	
+	// ResumeFromCheckpoint continues an interrupted migration from its
+	// last checkpoint
+	ResumeFromCheckpoint(ctx context.Context, config MigrationConfig) error
+	
 	// Stop stops a migration gracefully
 	Stop(migrationID string) error
 	
@@ -80,6 +157,9 @@ type ValidationResult struct {
 	// MinCosineSimilarity minimum similarity score
 	MinCosineSimilarity float64 `json:"min_cosine_similarity"`
 	
+	// Search compares nearest-neighbour results of source and target
+	Search *SearchComparison `json:"search,omitempty"`
+	
 	// Errors encountered during validation
 	Errors []ValidationError `json:"errors,omitempty"`
 }
@@ -2,9 +2,17 @@ package orchestrator
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/AlphaTechini/vector-db-migration/internal/adapters"
+	"github.com/AlphaTechini/vector-db-migration/internal/adapters/fakes"
 	"github.com/AlphaTechini/vector-db-migration/internal/mapper"
 	"github.com/AlphaTechini/vector-db-migration/internal/state"
 )
@@ -144,6 +152,10 @@ func (m *mockDatabase) GetBatch(ctx context.Context, afterID string, limit int)
 	return []adapters.Record{}, nil
 }
 
+func (m *mockDatabase) FetchByIDs(ctx context.Context, ids []string) ([]adapters.Record, []string, error) {
+	return []adapters.Record{}, ids, nil
+}
+
 func (m *mockDatabase) UpsertBatch(ctx context.Context, records []adapters.Record) error {
 	return nil
 }
@@ -215,3 +227,1481 @@ func (m *mockStateTracker) DeleteCheckpoint(migrationID string) error {
 func (m *mockStateTracker) Close() error {
 	return nil
 }
+
+func (m *mockStateTracker) ListMigrations(statusFilter string, limit, offset int) ([]string, error) {
+	return nil, nil
+}
+
+func (m *mockStateTracker) GetMigrationSummary(migrationID string) (*state.Checkpoint, error) {
+	return nil, nil
+}
+
+// recordingStateTracker keeps every saved checkpoint and the last state
+type recordingStateTracker struct {
+	mockStateTracker
+	mu          sync.Mutex
+	checkpoints []state.Checkpoint
+	state       state.MigrationState
+}
+
+func (m *recordingStateTracker) SaveCheckpoint(checkpoint *state.Checkpoint) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.checkpoints = append(m.checkpoints, *checkpoint)
+	return nil
+}
+
+func (m *recordingStateTracker) SetState(migrationID string, s state.MigrationState) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.state = s
+	return nil
+}
+
+// waitForCompletion polls until the migration leaves the in_progress state
+func waitForCompletion(t *testing.T, o *BaseOrchestrator, migrationID string) *MigrationStats {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		stats, err := o.GetStatus(migrationID)
+		if err != nil {
+			t.Fatalf("Failed to get status: %v", err)
+		}
+		if stats.Status != "in_progress" {
+			return stats
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("Migration did not finish in time")
+	return nil
+}
+
+// TestBaseOrchestrator_CursorSource tests migrating from a cursor-paged source
+func TestBaseOrchestrator_CursorSource(t *testing.T) {
+	fake := fakes.NewPinecone(t, "docs", 2)
+	for i := 0; i < 7; i++ {
+		fake.Seed("tenant-a", fakes.PineconeVector{
+			ID:       fmt.Sprintf("vec-%d", i),
+			Values:   []float32{float32(i), 1},
+			Metadata: map[string]interface{}{"n": i},
+		})
+	}
+	fake.Seed("", fakes.PineconeVector{ID: "other", Values: []float32{1, 1}})
+
+	ctx := context.Background()
+	source := &adapters.PineconeAdapter{}
+	if err := source.Connect(ctx, adapters.DBConfig{
+		Type:  "pinecone",
+		URL:   fake.URL,
+		Index: "docs",
+		Extra: map[string]string{"namespace": "tenant-a"},
+	}); err != nil {
+		t.Fatalf("Failed to connect source: %v", err)
+	}
+
+	target := adapters.NewMemoryAdapter()
+	if err := target.Connect(ctx, adapters.DBConfig{Type: "memory"}); err != nil {
+		t.Fatalf("Failed to connect target: %v", err)
+	}
+
+	tracker := &recordingStateTracker{}
+	o := NewBaseOrchestrator("cursor-test")
+	if err := o.Start(ctx, MigrationConfig{
+		SourceDB:      source,
+		TargetDB:      target,
+		SchemaMapper:  &mockMapper{},
+		StateTracker:  tracker,
+		BatchSize:     3,
+		ValidateEvery: 1,
+	}); err != nil {
+		t.Fatalf("Failed to start: %v", err)
+	}
+
+	stats := waitForCompletion(t, o, "cursor-test")
+	if stats.Status != "completed" || stats.MigratedRecords != 7 || stats.BatchesProcessed != 3 {
+		t.Fatalf("Unexpected final stats: %+v", stats)
+	}
+
+	records, err := target.GetBatch(ctx, "", 100)
+	if err != nil {
+		t.Fatalf("Failed to read target: %v", err)
+	}
+	if len(records) != 7 || records[0].ID != "vec-0" || records[0].Vector[1] != 1 {
+		t.Errorf("Unexpected migrated records: %+v", records)
+	}
+
+	// Intermediate checkpoints carry the list token, not a record ID
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+	if tracker.state != state.StateCompleted {
+		t.Errorf("Expected completed state, got %s", tracker.state)
+	}
+	if len(tracker.checkpoints) < 3 {
+		t.Fatalf("Expected initial, batch and final checkpoints, got %d", len(tracker.checkpoints))
+	}
+	first := tracker.checkpoints[1]
+	if first.Cursor == "" || first.Cursor == first.LastProcessedID || first.LastProcessedID != "vec-2" {
+		t.Errorf("Expected an opaque cursor after the first batch, got %+v", first)
+	}
+
+	t.Log("✓ BaseOrchestrator pages cursor sources and checkpoints the cursor")
+}
+
+// TestParseNamespaceRoute tests route rule parsing and defaults
+func TestParseNamespaceRoute(t *testing.T) {
+	cases := map[string]NamespaceRoute{
+		"field":                       {Mode: RouteField, Target: "namespace"},
+		"field:tenant_id":             {Mode: RouteField, Target: "tenant_id"},
+		"collection":                  {Mode: RouteCollection, Target: "{namespace}"},
+		"collection:docs_{namespace}": {Mode: RouteCollection, Target: "docs_{namespace}"},
+		"tenant:acme":                 {Mode: RouteTenant, Target: "acme"},
+	}
+	for rule, want := range cases {
+		got, err := ParseNamespaceRoute(rule)
+		if err != nil {
+			t.Errorf("ParseNamespaceRoute(%q) failed: %v", rule, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("ParseNamespaceRoute(%q) = %+v, want %+v", rule, got, want)
+		}
+	}
+
+	if _, err := ParseNamespaceRoute("payload:x"); err == nil {
+		t.Error("Expected error for unknown mode, got nil")
+	}
+
+	route, _ := ParseNamespaceRoute("collection:docs_{namespace}")
+	if resolved := route.resolve("tenant-a"); resolved.Target != "docs_tenant-a" {
+		t.Errorf("Expected docs_tenant-a, got %s", resolved.Target)
+	}
+
+	t.Log("✓ Namespace routes parse with defaults")
+}
+
+// TestNamespaceRouting_Target tests resolving the database a namespace
+// is written to
+func TestNamespaceRouting_Target(t *testing.T) {
+	target := adapters.NewMemoryAdapter()
+	docs := adapters.NewMemoryAdapter()
+	var opened []NamespaceRoute
+	routing := &NamespaceRouting{
+		Default: NamespaceRoute{Mode: RouteField, Target: "namespace"},
+		Routes:  map[string]NamespaceRoute{"docs": {Mode: RouteCollection, Target: "docs_{namespace}"}},
+		TargetFor: func(route NamespaceRoute) (adapters.Database, error) {
+			opened = append(opened, route)
+			return docs, nil
+		},
+	}
+
+	if db, err := routing.Target(routing.RouteFor("faq"), target); err != nil || db != target {
+		t.Errorf("Expected field routes to write to the target, got %v, %v", db, err)
+	}
+	db, err := routing.Target(routing.RouteFor("docs"), target)
+	if err != nil || db != docs {
+		t.Errorf("Expected collection routes to open a target, got %v, %v", db, err)
+	}
+	if len(opened) != 1 || opened[0].Target != "docs_docs" {
+		t.Errorf("Expected docs_docs to be opened, got %v", opened)
+	}
+
+	routing.TargetFor = nil
+	if _, err := routing.Target(routing.RouteFor("docs"), target); err == nil {
+		t.Error("Expected an error without a target factory")
+	}
+
+	t.Log("✓ NamespaceRouting resolves namespace targets")
+}
+
+// TestBaseOrchestrator_Namespaces tests routing each namespace separately
+func TestBaseOrchestrator_Namespaces(t *testing.T) {
+	fake := fakes.NewPinecone(t, "docs", 2)
+	seed := map[string]int{"": 3, "tenant-a": 4, "tenant-b": 2}
+	for namespace, n := range seed {
+		for i := 0; i < n; i++ {
+			fake.Seed(namespace, fakes.PineconeVector{
+				ID:     fmt.Sprintf("%s-%d", namespace, i),
+				Values: []float32{float32(i), 1},
+			})
+		}
+	}
+
+	ctx := context.Background()
+	source := &adapters.PineconeAdapter{}
+	if err := source.Connect(ctx, adapters.DBConfig{Type: "pinecone", URL: fake.URL, Index: "docs"}); err != nil {
+		t.Fatalf("Failed to connect source: %v", err)
+	}
+
+	newMemory := func() *adapters.MemoryAdapter {
+		db := adapters.NewMemoryAdapter()
+		if err := db.Connect(ctx, adapters.DBConfig{Type: "memory"}); err != nil {
+			t.Fatalf("Failed to connect target: %v", err)
+		}
+		return db
+	}
+	shared := newMemory()
+	collections := make(map[string]*adapters.MemoryAdapter)
+
+	tracker := &recordingStateTracker{}
+	o := NewBaseOrchestrator("namespace-test")
+	err := o.Start(ctx, MigrationConfig{
+		SourceDB:      source,
+		TargetDB:      shared,
+		SchemaMapper:  &mockMapper{},
+		StateTracker:  tracker,
+		BatchSize:     2,
+		ValidateEvery: 1,
+		NamespaceRouting: &NamespaceRouting{
+			Routes: map[string]NamespaceRoute{
+				"tenant-b": {Mode: RouteCollection, Target: "docs_{namespace}"},
+			},
+			Default: NamespaceRoute{Mode: RouteField, Target: "ns"},
+			TargetFor: func(route NamespaceRoute) (adapters.Database, error) {
+				db := newMemory()
+				collections[route.Target] = db
+				return db, nil
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to start: %v", err)
+	}
+
+	stats := waitForCompletion(t, o, "namespace-test")
+	if stats.Status != "completed" || stats.TotalRecords != 9 || stats.MigratedRecords != 9 {
+		t.Fatalf("Unexpected final stats: %+v", stats)
+	}
+	if len(stats.Namespaces) != 3 {
+		t.Fatalf("Expected 3 namespaces in stats, got %d", len(stats.Namespaces))
+	}
+	for name, ns := range stats.Namespaces {
+		want := int64(seed[name])
+		if name == adapters.PineconeDefaultNamespace {
+			want = int64(seed[""])
+		}
+		if ns.Status != "completed" || ns.MigratedRecords != want || ns.TotalRecords != want {
+			t.Errorf("Namespace %q: unexpected stats %+v", name, ns)
+		}
+	}
+
+	records, _ := shared.GetBatch(ctx, "", 100)
+	if len(records) != 7 {
+		t.Fatalf("Expected 7 records in the shared target, got %d", len(records))
+	}
+	for _, r := range records {
+		want := "tenant-a"
+		if r.ID[0] == '-' {
+			want = adapters.PineconeDefaultNamespace
+		}
+		if r.Metadata["ns"] != want {
+			t.Errorf("Record %s: expected ns=%s, got %v", r.ID, want, r.Metadata["ns"])
+		}
+	}
+
+	routed := collections["docs_tenant-b"]
+	if routed == nil {
+		t.Fatalf("Expected a docs_tenant-b target, got %v", collections)
+	}
+	if records, _ := routed.GetBatch(ctx, "", 100); len(records) != 2 || records[0].Metadata["ns"] != nil {
+		t.Errorf("Unexpected records in docs_tenant-b: %+v", records)
+	}
+
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+	final := tracker.checkpoints[len(tracker.checkpoints)-1]
+	if len(final.Namespaces) != 3 {
+		t.Fatalf("Expected per-namespace checkpoints, got %+v", final.Namespaces)
+	}
+	for name, ns := range final.Namespaces {
+		if !ns.Completed || ns.ProcessedCount != ns.TotalRecords {
+			t.Errorf("Namespace %q: unexpected checkpoint %+v", name, ns)
+		}
+	}
+
+	t.Log("✓ BaseOrchestrator migrates and checkpoints namespaces separately")
+}
+
+// TestBaseOrchestrator_ProvisionTarget tests that a missing target
+// collection is created from the source before the first write
+func TestBaseOrchestrator_ProvisionTarget(t *testing.T) {
+	pinecone := fakes.NewPinecone(t, "docs", 2)
+	for i := 1; i <= 4; i++ {
+		pinecone.Seed("", fakes.PineconeVector{
+			ID:       fmt.Sprintf("%d", i),
+			Values:   []float32{float32(i), 1},
+			Metadata: map[string]interface{}{"lang": "en"},
+		})
+	}
+	qdrant := fakes.NewQdrant(t)
+
+	ctx := context.Background()
+	source := &adapters.PineconeAdapter{}
+	if err := source.Connect(ctx, adapters.DBConfig{Type: "pinecone", URL: pinecone.URL, Index: "docs"}); err != nil {
+		t.Fatalf("Failed to connect source: %v", err)
+	}
+	target := &adapters.QdrantAdapter{}
+	if err := target.Connect(ctx, adapters.DBConfig{Type: "qdrant", URL: qdrant.URL, Index: "docs"}); err != nil {
+		t.Fatalf("Failed to connect target: %v", err)
+	}
+
+	o := NewBaseOrchestrator("provision-test")
+	if err := o.Start(ctx, MigrationConfig{
+		SourceDB:     source,
+		TargetDB:     target,
+		SchemaMapper: &mockMapper{},
+		StateTracker: &recordingStateTracker{},
+		BatchSize:    3,
+	}); err != nil {
+		t.Fatalf("Failed to start: %v", err)
+	}
+
+	stats := waitForCompletion(t, o, "provision-test")
+	if stats.Status != "completed" || stats.MigratedRecords != 4 {
+		t.Fatalf("Unexpected final stats: %+v", stats)
+	}
+
+	targetStats, err := target.GetStats(ctx)
+	if err != nil {
+		t.Fatalf("Failed to read target stats: %v", err)
+	}
+	if targetStats.TotalRecords != 4 || targetStats.Dimensions != 2 || targetStats.Distance != adapters.DistanceCosine {
+		t.Errorf("Unexpected provisioned collection: %+v", targetStats)
+	}
+
+	t.Log("✓ BaseOrchestrator provisions the target collection")
+}
+
+// cappedDatabase reports a maximum batch size
+type cappedDatabase struct {
+	mockDatabase
+	maxBatchSize int
+}
+
+func (m *cappedDatabase) Capabilities() adapters.Capabilities {
+	return adapters.Capabilities{MaxBatchSize: m.maxBatchSize}
+}
+
+// TestBaseOrchestrator_BatchSize tests that batches are capped at the
+// limits the source and target report
+func TestBaseOrchestrator_BatchSize(t *testing.T) {
+	cases := []struct {
+		configured int
+		source     adapters.Database
+		target     adapters.Database
+		want       int
+	}{
+		{0, &mockDatabase{}, &mockDatabase{}, 100},
+		{5000, &mockDatabase{}, &cappedDatabase{maxBatchSize: 1000}, 1000},
+		{5000, &cappedDatabase{maxBatchSize: 200}, &cappedDatabase{maxBatchSize: 1000}, 200},
+		{50, &mockDatabase{}, &cappedDatabase{maxBatchSize: 1000}, 50},
+		{500, &mockDatabase{}, &cappedDatabase{}, 500},
+	}
+	for _, tc := range cases {
+		o := NewBaseOrchestrator("batch-size-test")
+		o.config.BatchSize = tc.configured
+		if got := o.batchSize(tc.source, tc.target); got != tc.want {
+			t.Errorf("batchSize(%d) = %d, want %d", tc.configured, got, tc.want)
+		}
+	}
+
+	t.Log("✓ BaseOrchestrator caps batches at adapter limits")
+}
+
+// schemaDatabase is an in-memory database with a fixed schema
+type schemaDatabase struct {
+	*adapters.MemoryAdapter
+	schema *adapters.Schema
+}
+
+func (m *schemaDatabase) GetSchema(ctx context.Context) (*adapters.Schema, error) {
+	return m.schema, nil
+}
+
+// TestBaseOrchestrator_SchemaMapping tests that records are mapped onto
+// the target's schema, keeping fields it does not define
+func TestBaseOrchestrator_SchemaMapping(t *testing.T) {
+	ctx := context.Background()
+	source := adapters.NewMemoryAdapter()
+	if err := source.UpsertBatch(ctx, []adapters.Record{
+		{ID: "1", Vector: []float32{1, 0}, Metadata: map[string]interface{}{"Title": "a", "views": 3}},
+		{ID: "2", Vector: []float32{0, 1}, Metadata: map[string]interface{}{"Title": "b"}},
+	}); err != nil {
+		t.Fatalf("Failed to seed source: %v", err)
+	}
+	target := &schemaDatabase{
+		MemoryAdapter: adapters.NewMemoryAdapter(),
+		schema: &adapters.Schema{Fields: []adapters.FieldSchema{
+			{Path: []string{"title"}, Type: adapters.PropertyText},
+		}},
+	}
+
+	o := NewBaseOrchestrator("mapping-test")
+	if err := o.Start(ctx, MigrationConfig{
+		SourceDB:     source,
+		TargetDB:     target,
+		SchemaMapper: mapper.NewBaseMapper("qdrant", "weaviate"),
+		StateTracker: &recordingStateTracker{},
+	}); err != nil {
+		t.Fatalf("Failed to start: %v", err)
+	}
+
+	stats := waitForCompletion(t, o, "mapping-test")
+	if stats.Status != "completed" || stats.MigratedRecords != 2 {
+		t.Fatalf("Unexpected final stats: %+v", stats)
+	}
+
+	records, err := target.GetBatch(ctx, "", 10)
+	if err != nil {
+		t.Fatalf("Failed to read target: %v", err)
+	}
+	if len(records) != 2 || records[0].Metadata["title"] != "a" || records[0].Metadata["views"] != 3 {
+		t.Fatalf("Expected Title mapped to title and views kept, got %+v", records)
+	}
+	if _, exists := records[0].Metadata["Title"]; exists {
+		t.Errorf("Expected Title to be renamed, got %v", records[0].Metadata)
+	}
+
+	t.Log("✓ BaseOrchestrator maps records onto the target schema")
+}
+
+// TestRankMetrics tests recall@k and rank overlap on fixed rankings
+func TestRankMetrics(t *testing.T) {
+	ranking := func(ids ...string) []adapters.SearchResult {
+		results := make([]adapters.SearchResult, len(ids))
+		for i, id := range ids {
+			results[i] = adapters.SearchResult{ID: id}
+		}
+		return results
+	}
+
+	cases := []struct {
+		expected, actual []adapters.SearchResult
+		recall, overlap  float64
+	}{
+		{ranking("a", "b", "c"), ranking("a", "b", "c"), 1, 1},
+		{ranking("a", "b", "c"), ranking("c", "b", "a"), 1, (0.0 + 0.5 + 1) / 3},
+		{ranking("a", "b"), ranking("a", "x"), 0.5, (1 + 0.5) / 2},
+		{ranking("a", "b"), ranking("a"), 0.5, (1 + 0.5) / 2},
+		{ranking("a", "b"), nil, 0, 0},
+	}
+	for i, tc := range cases {
+		if got := recallAtK(tc.expected, tc.actual); got != tc.recall {
+			t.Errorf("case %d: recall = %v, want %v", i, got, tc.recall)
+		}
+		if got := rankOverlap(tc.expected, tc.actual, len(tc.expected)); got != tc.overlap {
+			t.Errorf("case %d: rank overlap = %v, want %v", i, got, tc.overlap)
+		}
+	}
+
+	t.Log("✓ Recall and rank overlap score rankings")
+}
+
+// TestBaseOrchestrator_ValidateSearch tests that Validate compares the
+// nearest neighbours of source and target
+func TestBaseOrchestrator_ValidateSearch(t *testing.T) {
+	ctx := context.Background()
+	source := adapters.NewMemoryAdapter()
+	target := adapters.NewMemoryAdapter()
+	var records []adapters.Record
+	for i := 0; i < 8; i++ {
+		records = append(records, adapters.Record{
+			ID:     fmt.Sprintf("r%d", i),
+			Vector: []float32{float32(i), float32(8 - i)},
+		})
+	}
+	if err := source.UpsertBatch(ctx, records); err != nil {
+		t.Fatalf("Failed to seed source: %v", err)
+	}
+
+	// The target lost one record
+	if err := target.UpsertBatch(ctx, records[1:]); err != nil {
+		t.Fatalf("Failed to seed target: %v", err)
+	}
+
+	o := NewBaseOrchestrator("validate-search")
+	o.config = MigrationConfig{
+		SourceDB:      source,
+		TargetDB:      target,
+		SearchK:       2,
+		SearchQueries: 4,
+	}
+	if err := o.Validate("validate-search"); err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+
+	stats, _ := o.GetStatus("validate-search")
+	if stats.Validation == nil || stats.Validation.Search == nil {
+		t.Fatalf("Expected a search comparison, got %+v", stats.Validation)
+	}
+	search := stats.Validation.Search
+	if search.Queries != 4 || search.K != 2 {
+		t.Errorf("Unexpected comparison size: %+v", search)
+	}
+	if search.RecallAtK >= 1 || search.MinRecall != 0.5 || search.RankOverlap >= 1 {
+		t.Errorf("Expected the missing record to lower recall and overlap, got %+v", search)
+	}
+
+	t.Log("✓ BaseOrchestrator validates search recall")
+}
+
+// TestBaseOrchestrator_Partitions tests scanning a partitioned source
+// concurrently with a checkpoint per partition
+func TestBaseOrchestrator_Partitions(t *testing.T) {
+	ctx := context.Background()
+	source := adapters.NewMemoryAdapter()
+	target := adapters.NewMemoryAdapter()
+	var records []adapters.Record
+	for i := 0; i < 50; i++ {
+		records = append(records, adapters.Record{
+			ID:     fmt.Sprintf("r%02d", i),
+			Vector: []float32{float32(i), 1},
+		})
+	}
+	if err := source.UpsertBatch(ctx, records); err != nil {
+		t.Fatalf("Failed to seed source: %v", err)
+	}
+
+	tracker := &recordingStateTracker{}
+	o := NewBaseOrchestrator("partitions")
+	if err := o.Start(ctx, MigrationConfig{
+		SourceDB:      source,
+		TargetDB:      target,
+		SchemaMapper:  &mockMapper{},
+		StateTracker:  tracker,
+		BatchSize:     4,
+		ValidateEvery: 1,
+		Partitions:    3,
+	}); err != nil {
+		t.Fatalf("Failed to start: %v", err)
+	}
+
+	stats := waitForCompletion(t, o, "partitions")
+	if stats.Status != "completed" || stats.MigratedRecords != 50 {
+		t.Fatalf("Unexpected final stats: %+v", stats)
+	}
+	if len(stats.Partitions) != 3 {
+		t.Fatalf("Expected 3 partitions, got %+v", stats.Partitions)
+	}
+	var perPartition int64
+	for key, part := range stats.Partitions {
+		if part.Status != "completed" {
+			t.Errorf("Partition %s not completed: %+v", key, part)
+		}
+		perPartition += part.MigratedRecords
+	}
+	if perPartition != 50 {
+		t.Errorf("Expected partition counts to sum to 50, got %d", perPartition)
+	}
+
+	migrated, err := target.GetBatch(ctx, "", 100)
+	if err != nil {
+		t.Fatalf("Failed to read target: %v", err)
+	}
+	if len(migrated) != 50 {
+		t.Errorf("Expected 50 migrated records, got %d", len(migrated))
+	}
+
+	// Positions are checkpointed per partition, not at the top level
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+	final := tracker.checkpoints[len(tracker.checkpoints)-1]
+	if len(final.Partitions) != 3 || final.LastProcessedID != "" {
+		t.Fatalf("Expected 3 partition checkpoints, got %+v", final)
+	}
+	for key, part := range final.Partitions {
+		if !part.Completed || part.LastProcessedID == "" {
+			t.Errorf("Partition %s checkpoint incomplete: %+v", key, part)
+		}
+	}
+	sawProgress := false
+	for _, checkpoint := range tracker.checkpoints {
+		for _, part := range checkpoint.Partitions {
+			if !part.Completed && part.LastProcessedID != "" {
+				sawProgress = true
+			}
+		}
+	}
+	if !sawProgress {
+		t.Error("Expected intermediate checkpoints with partition positions")
+	}
+
+	t.Log("✓ BaseOrchestrator scans partitions concurrently and checkpoints each")
+}
+
+// slowTarget is a memory target whose first batch is written last
+type slowTarget struct {
+	*adapters.MemoryAdapter
+	mu          sync.Mutex
+	active, max int
+}
+
+func (m *slowTarget) UpsertBatch(ctx context.Context, records []adapters.Record) error {
+	m.mu.Lock()
+	m.active++
+	if m.active > m.max {
+		m.max = m.active
+	}
+	m.mu.Unlock()
+	defer func() {
+		m.mu.Lock()
+		m.active--
+		m.mu.Unlock()
+	}()
+
+	if records[0].ID == "r00" {
+		time.Sleep(50 * time.Millisecond)
+	} else {
+		time.Sleep(5 * time.Millisecond)
+	}
+	return m.MemoryAdapter.UpsertBatch(ctx, records)
+}
+
+// contiguousStateTracker fails checkpoints that run ahead of the target
+type contiguousStateTracker struct {
+	recordingStateTracker
+	target *adapters.MemoryAdapter
+	ahead  []string
+}
+
+func (m *contiguousStateTracker) SaveCheckpoint(checkpoint *state.Checkpoint) error {
+	if checkpoint.LastProcessedID != "" {
+		records, _ := m.target.GetBatch(context.Background(), "", 1000)
+		written := 0
+		for _, r := range records {
+			if r.ID <= checkpoint.LastProcessedID {
+				written++
+			}
+		}
+		if int64(written) < checkpoint.ProcessedCount {
+			m.mu.Lock()
+			m.ahead = append(m.ahead, checkpoint.LastProcessedID)
+			m.mu.Unlock()
+		}
+	}
+	return m.recordingStateTracker.SaveCheckpoint(checkpoint)
+}
+
+// TestBaseOrchestrator_Pipeline tests concurrent writers with checkpoints
+// that only advance past contiguous written batches
+func TestBaseOrchestrator_Pipeline(t *testing.T) {
+	ctx := context.Background()
+	source := adapters.NewMemoryAdapter()
+	var records []adapters.Record
+	for i := 0; i < 40; i++ {
+		records = append(records, adapters.Record{
+			ID:     fmt.Sprintf("r%02d", i),
+			Vector: []float32{float32(i), 1},
+		})
+	}
+	if err := source.UpsertBatch(ctx, records); err != nil {
+		t.Fatalf("Failed to seed source: %v", err)
+	}
+
+	target := &slowTarget{MemoryAdapter: adapters.NewMemoryAdapter()}
+	tracker := &contiguousStateTracker{target: target.MemoryAdapter}
+	o := NewBaseOrchestrator("pipeline")
+	if err := o.Start(ctx, MigrationConfig{
+		SourceDB:      source,
+		TargetDB:      target,
+		SchemaMapper:  &mockMapper{},
+		StateTracker:  tracker,
+		BatchSize:     4,
+		ValidateEvery: 1,
+		Mappers:       2,
+		Writers:       3,
+	}); err != nil {
+		t.Fatalf("Failed to start: %v", err)
+	}
+
+	stats := waitForCompletion(t, o, "pipeline")
+	if stats.Status != "completed" || stats.MigratedRecords != 40 || stats.BatchesProcessed != 10 {
+		t.Fatalf("Unexpected final stats: %+v", stats)
+	}
+
+	target.mu.Lock()
+	maxWriters := target.max
+	target.mu.Unlock()
+	if maxWriters < 2 || maxWriters > 3 {
+		t.Errorf("Expected 2-3 concurrent writes, got %d", maxWriters)
+	}
+
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+	if len(tracker.ahead) > 0 {
+		t.Errorf("Checkpoints ran ahead of written batches: %v", tracker.ahead)
+	}
+	last := ""
+	for _, checkpoint := range tracker.checkpoints {
+		if checkpoint.LastProcessedID == "" {
+			continue
+		}
+		if checkpoint.LastProcessedID < last {
+			t.Errorf("Checkpoint moved back from %s to %s", last, checkpoint.LastProcessedID)
+		}
+		last = checkpoint.LastProcessedID
+	}
+	if last != "r39" {
+		t.Errorf("Expected the last batch checkpoint at r39, got %q", last)
+	}
+
+	t.Log("✓ BaseOrchestrator pipelines batches and commits checkpoints in order")
+}
+
+// TestBaseOrchestrator_Retry tests retrying transient and rate-limited
+// source errors and failing fast on permanent ones
+func TestBaseOrchestrator_Retry(t *testing.T) {
+	fake := fakes.NewPinecone(t, "docs", 2)
+	for i := 0; i < 5; i++ {
+		fake.Seed("", fakes.PineconeVector{ID: fmt.Sprintf("vec-%d", i), Values: []float32{float32(i), 1}})
+	}
+
+	ctx := context.Background()
+	run := func(id string, maxRetries int) *MigrationStats {
+		source := &adapters.PineconeAdapter{}
+		if err := source.Connect(ctx, adapters.DBConfig{Type: "pinecone", URL: fake.URL, Index: "docs"}); err != nil {
+			t.Fatalf("Failed to connect source: %v", err)
+		}
+		target := adapters.NewMemoryAdapter()
+		if err := target.Connect(ctx, adapters.DBConfig{Type: "memory"}); err != nil {
+			t.Fatalf("Failed to connect target: %v", err)
+		}
+
+		o := NewBaseOrchestrator(id)
+		if err := o.Start(ctx, MigrationConfig{
+			SourceDB:     source,
+			TargetDB:     target,
+			SchemaMapper: &mockMapper{},
+			StateTracker: &recordingStateTracker{},
+			BatchSize:    2,
+			MaxRetries:   maxRetries,
+			RetryBackoff: time.Millisecond,
+		}); err != nil {
+			t.Fatalf("Failed to start: %v", err)
+		}
+		return waitForCompletion(t, o, id)
+	}
+
+	// Two unavailable lists and a throttled fetch are retried
+	fake.Inject(fakes.Fault{Method: "GET", Path: "/vectors/list", Status: http.StatusServiceUnavailable, Times: 2})
+	fake.Inject(fakes.Fault{Method: "GET", Path: "/vectors/fetch", Status: http.StatusTooManyRequests, Times: 1})
+	stats := run("retry-transient", 3)
+	if stats.Status != "completed" || stats.MigratedRecords != 5 {
+		t.Fatalf("Expected the migration to survive transient errors, got %+v", stats)
+	}
+	if stats.Retries != 3 || stats.RateLimited != 1 {
+		t.Errorf("Expected 3 retries, 1 rate limited, got %d and %d", stats.Retries, stats.RateLimited)
+	}
+
+	// Retries run out
+	fake.Inject(fakes.Fault{Method: "GET", Path: "/vectors/list", Status: http.StatusBadGateway, Times: 3})
+	stats = run("retry-exhausted", 2)
+	if !strings.HasPrefix(stats.Status, "failed") || stats.Retries != 2 {
+		t.Errorf("Expected failure after 2 retries, got %q after %d", stats.Status, stats.Retries)
+	}
+	fake.ClearFaults()
+
+	// Permanent errors are not retried
+	fake.Inject(fakes.Fault{Method: "GET", Path: "/vectors/list", Status: http.StatusBadRequest, Times: 1})
+	stats = run("retry-permanent", 3)
+	if !strings.HasPrefix(stats.Status, "failed") || stats.Retries != 0 {
+		t.Errorf("Expected failure without retries, got %q after %d", stats.Status, stats.Retries)
+	}
+
+	t.Log("✓ BaseOrchestrator retries retryable errors with backoff")
+}
+
+// TestRetry tests the retry helper shared with dlq retry
+func TestRetry(t *testing.T) {
+	ctx := context.Background()
+	throttled := &adapters.Error{Kind: adapters.ErrorRateLimited, StatusCode: 429, Err: fmt.Errorf("slow down")}
+	invalid := &adapters.Error{Kind: adapters.ErrorPermanent, StatusCode: 400, Err: fmt.Errorf("invalid")}
+
+	var kinds []adapters.ErrorKind
+	policy := RetryPolicy{
+		MaxRetries: 3,
+		Backoff:    time.Millisecond,
+		OnRetry:    func(kind adapters.ErrorKind) { kinds = append(kinds, kind) },
+	}
+
+	// A throttled call is retried until it succeeds
+	calls := 0
+	err := Retry(ctx, policy, func() error {
+		calls++
+		if calls < 3 {
+			return throttled
+		}
+		return nil
+	})
+	if err != nil || calls != 3 || len(kinds) != 2 || kinds[0] != adapters.ErrorRateLimited {
+		t.Errorf("Expected success on the third call after 2 rate-limited retries, got %v after %d calls (%v)", err, calls, kinds)
+	}
+
+	// Permanent errors are not retried
+	calls = 0
+	if err := Retry(ctx, policy, func() error { calls++; return invalid }); err != invalid || calls != 1 {
+		t.Errorf("Expected the permanent error after 1 call, got %v after %d", err, calls)
+	}
+
+	// Retries run out
+	calls = 0
+	if err := Retry(ctx, policy, func() error { calls++; return throttled }); err != throttled || calls != 4 {
+		t.Errorf("Expected the last error after 4 calls, got %v after %d", err, calls)
+	}
+
+	t.Log("✓ Retry retries retryable errors and gives up on permanent ones")
+}
+
+// badRecordMapper fails to map the record with ID bad, prefixes the IDs of
+// the others and reports every sparse vector as dropped
+type badRecordMapper struct {
+	mockMapper
+	prefix string
+}
+
+func (m *badRecordMapper) SparseReport(records []adapters.Record, mapping *mapper.SchemaMapping) mapper.SparseReport {
+	var report mapper.SparseReport
+	for _, record := range records {
+		if record.Sparse != nil {
+			report.Dropped++
+		}
+	}
+	return report
+}
+
+func (m *badRecordMapper) MapRecord(record adapters.Record, mapping *mapper.SchemaMapping) (adapters.Record, error) {
+	if record.ID == "bad" {
+		return adapters.Record{}, fmt.Errorf("cannot convert field n")
+	}
+	record.ID = m.prefix + record.ID
+	return record, nil
+}
+
+func (m *badRecordMapper) MapBatch(records []adapters.Record, mapping *mapper.SchemaMapping) ([]adapters.Record, error) {
+	results := make([]adapters.Record, len(records))
+	for i, record := range records {
+		mapped, err := m.MapRecord(record, mapping)
+		if err != nil {
+			return nil, fmt.Errorf("failed to map record %d: %w", i, err)
+		}
+		results[i] = mapped
+	}
+	return results, nil
+}
+
+// rejectingTarget is a memory target that rejects batches containing a
+// record it was told to reject
+type rejectingTarget struct {
+	*adapters.MemoryAdapter
+	reject map[string]bool
+}
+
+func (m *rejectingTarget) UpsertBatch(ctx context.Context, records []adapters.Record) error {
+	for _, record := range records {
+		if m.reject[record.ID] {
+			return &adapters.Error{Kind: adapters.ErrorPermanent, StatusCode: 400, Err: fmt.Errorf("invalid record %s", record.ID)}
+		}
+	}
+	return m.MemoryAdapter.UpsertBatch(ctx, records)
+}
+
+// TestBaseOrchestrator_DeadLetters tests setting aside records that fail
+// mapping or upsert and continuing
+func TestBaseOrchestrator_DeadLetters(t *testing.T) {
+	ctx := context.Background()
+	source := adapters.NewMemoryAdapter()
+	if err := source.Connect(ctx, adapters.DBConfig{Type: "memory"}); err != nil {
+		t.Fatalf("Failed to connect source: %v", err)
+	}
+	sparse := &adapters.SparseVector{Indices: []uint32{1}, Values: []float32{1}}
+	records := []adapters.Record{{ID: "bad", Vector: []float32{1, 0}, Sparse: sparse}}
+	for i := 0; i < 9; i++ {
+		records = append(records, adapters.Record{ID: fmt.Sprintf("r%d", i), Vector: []float32{float32(i), 1}, Sparse: sparse})
+	}
+	if err := source.UpsertBatch(ctx, records); err != nil {
+		t.Fatalf("Failed to seed source: %v", err)
+	}
+
+	run := func(id string, tracker state.StateTracker, target *rejectingTarget, mapper *badRecordMapper) *MigrationStats {
+		if err := target.Connect(ctx, adapters.DBConfig{Type: "memory"}); err != nil {
+			t.Fatalf("Failed to connect target: %v", err)
+		}
+		o := NewBaseOrchestrator(id)
+		if err := o.Start(ctx, MigrationConfig{
+			SourceDB:     source,
+			TargetDB:     target,
+			SchemaMapper: mapper,
+			StateTracker: tracker,
+			BatchSize:    5,
+		}); err != nil {
+			t.Fatalf("Failed to start: %v", err)
+		}
+		return waitForCompletion(t, o, id)
+	}
+
+	tracker, err := state.NewSQLiteTracker(filepath.Join(t.TempDir(), "state.db"))
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+	defer tracker.Close()
+
+	target := &rejectingTarget{MemoryAdapter: adapters.NewMemoryAdapter(), reject: map[string]bool{"r6": true}}
+	stats := run("dlq", tracker, target, &badRecordMapper{})
+	if stats.Status != "completed" || stats.MigratedRecords != 8 || stats.FailedRecords != 2 {
+		t.Fatalf("Expected 8 migrated and 2 failed records, got %+v", stats)
+	}
+	if written, _ := target.GetBatch(ctx, "", 100); len(written) != 8 {
+		t.Errorf("Expected 8 records written, got %d", len(written))
+	}
+	if stats.Sparse.Dropped != 8 {
+		t.Errorf("Expected sparse vectors counted for the 8 written records, got %+v", stats.Sparse)
+	}
+
+	letters, err := tracker.ListDeadLetters("dlq", 0, 0)
+	if err != nil {
+		t.Fatalf("Failed to list dead letters: %v", err)
+	}
+	if len(letters) != 2 {
+		t.Fatalf("Expected 2 dead letters, got %+v", letters)
+	}
+	byID := map[string]state.DeadLetter{letters[0].RecordID: letters[0], letters[1].RecordID: letters[1]}
+	if letter := byID["bad"]; letter.Stage != state.DeadLetterMap || !strings.Contains(letter.Error, "cannot convert") {
+		t.Errorf("Unexpected map dead letter: %+v", letter)
+	}
+	var rejected adapters.Record
+	letter := byID["r6"]
+	if err := json.Unmarshal(letter.Record, &rejected); err != nil || rejected.ID != "r6" || letter.Stage != state.DeadLetterUpsert {
+		t.Errorf("Unexpected upsert dead letter: %+v (%v)", letter, err)
+	}
+	checkpoint, _ := tracker.GetCheckpoint("dlq")
+	if checkpoint == nil || checkpoint.FailedCount != 2 {
+		t.Errorf("Expected the checkpoint to count 2 failed records, got %+v", checkpoint)
+	}
+
+	// Records rejected under a mapped ID are still left out of the sparse
+	// count
+	target = &rejectingTarget{MemoryAdapter: adapters.NewMemoryAdapter(), reject: map[string]bool{"t-r6": true}}
+	stats = run("dlq-mapped", tracker, target, &badRecordMapper{prefix: "t-"})
+	if stats.Status != "completed" || stats.MigratedRecords != 8 || stats.Sparse.Dropped != 8 {
+		t.Errorf("Expected sparse vectors counted for the 8 written records, got %+v", stats)
+	}
+
+	// A target rejecting every record is not the records' fault
+	all := map[string]bool{}
+	for _, record := range records {
+		all[record.ID] = true
+	}
+	stats = run("dlq-all", tracker, &rejectingTarget{MemoryAdapter: adapters.NewMemoryAdapter(), reject: all}, &badRecordMapper{})
+	if !strings.HasPrefix(stats.Status, "failed") {
+		t.Errorf("Expected the migration to fail, got %+v", stats)
+	}
+
+	// Without a dead-letter store a bad record fails the migration
+	stats = run("no-dlq", &recordingStateTracker{}, &rejectingTarget{MemoryAdapter: adapters.NewMemoryAdapter()}, &badRecordMapper{})
+	if !strings.HasPrefix(stats.Status, "failed") {
+		t.Errorf("Expected the migration to fail, got %+v", stats)
+	}
+
+	t.Log("✓ BaseOrchestrator sets failed records aside in the dead-letter store")
+}
+
+// flakyTarget is a memory target that fails batches containing failOn,
+// takes delay per batch and counts how often each record is written
+type flakyTarget struct {
+	*adapters.MemoryAdapter
+	mu     sync.Mutex
+	failOn string
+	delay  time.Duration
+	writes map[string]int
+}
+
+func (m *flakyTarget) UpsertBatch(ctx context.Context, records []adapters.Record) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	time.Sleep(m.delay)
+	for _, record := range records {
+		if record.ID == m.failOn {
+			return &adapters.Error{Kind: adapters.ErrorRetryable, StatusCode: 503, Err: fmt.Errorf("unavailable")}
+		}
+	}
+	for _, record := range records {
+		m.writes[record.ID]++
+	}
+	return m.MemoryAdapter.UpsertBatch(ctx, records)
+}
+
+// TestBaseOrchestrator_ResumeFromCheckpoint tests continuing a failed
+// migration from its checkpoint without copying written batches again
+func TestBaseOrchestrator_ResumeFromCheckpoint(t *testing.T) {
+	ctx := context.Background()
+	tracker, err := state.NewSQLiteTracker(filepath.Join(t.TempDir(), "state.db"))
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+	defer tracker.Close()
+
+	source := adapters.NewMemoryAdapter()
+	if err := source.Connect(ctx, adapters.DBConfig{Type: "memory"}); err != nil {
+		t.Fatalf("Failed to connect source: %v", err)
+	}
+	var records []adapters.Record
+	for i := 0; i < 10; i++ {
+		records = append(records, adapters.Record{ID: fmt.Sprintf("r%d", i), Vector: []float32{float32(i), 1}})
+	}
+	if err := source.UpsertBatch(ctx, records); err != nil {
+		t.Fatalf("Failed to seed source: %v", err)
+	}
+
+	target := &flakyTarget{MemoryAdapter: adapters.NewMemoryAdapter(), failOn: "r6", writes: map[string]int{}}
+	if err := target.Connect(ctx, adapters.DBConfig{Type: "memory"}); err != nil {
+		t.Fatalf("Failed to connect target: %v", err)
+	}
+	// Checkpoints follow every committed batch, whatever ValidateEvery is
+	config := MigrationConfig{
+		SourceDB:      source,
+		TargetDB:      target,
+		SchemaMapper:  &mockMapper{},
+		StateTracker:  tracker,
+		BatchSize:     2,
+		ValidateEvery: 10,
+		SourceConfig:  adapters.DBConfig{Type: "pgvector", URL: "postgres://user:secret@db:5432/app", Index: "items"},
+		TargetConfig:  adapters.DBConfig{Type: "qdrant", URL: "http://qdrant:6333", Index: "items"},
+	}
+
+	o := NewBaseOrchestrator("resume-test")
+	if err := o.ResumeFromCheckpoint(ctx, config); err == nil {
+		t.Fatal("Expected resuming a migration without a checkpoint to fail")
+	}
+	if err := o.Start(ctx, config); err != nil {
+		t.Fatalf("Failed to start: %v", err)
+	}
+	stats := waitForCompletion(t, o, "resume-test")
+	if !strings.HasPrefix(stats.Status, "failed") || stats.MigratedRecords != 6 {
+		t.Fatalf("Expected the migration to fail after 6 records, got %+v", stats)
+	}
+
+	checkpoint, _ := tracker.GetCheckpoint("resume-test")
+	if checkpoint.LastProcessedID != "r5" || checkpoint.SchemaMapping == nil || checkpoint.Source.URL != "postgres://db:5432/app" {
+		t.Fatalf("Unexpected checkpoint: %+v", checkpoint)
+	}
+
+	// A different target is a different migration
+	moved := config
+	moved.TargetConfig.Index = "other"
+	if err := NewBaseOrchestrator("resume-test").ResumeFromCheckpoint(ctx, moved); err == nil || !strings.Contains(err.Error(), "target") {
+		t.Errorf("Expected a target mismatch, got %v", err)
+	}
+	moved = config
+	moved.Partitions = 4
+	if err := NewBaseOrchestrator("resume-test").ResumeFromCheckpoint(ctx, moved); err == nil || !strings.Contains(err.Error(), "partitions") {
+		t.Errorf("Expected a partition count mismatch, got %v", err)
+	}
+
+	target.mu.Lock()
+	target.failOn = ""
+	target.mu.Unlock()
+
+	o = NewBaseOrchestrator("resume-test")
+	if err := o.ResumeFromCheckpoint(ctx, config); err != nil {
+		t.Fatalf("Failed to resume: %v", err)
+	}
+	stats = waitForCompletion(t, o, "resume-test")
+	if stats.Status != "completed" || stats.MigratedRecords != 10 || stats.BatchesProcessed != 5 {
+		t.Fatalf("Unexpected final stats: %+v", stats)
+	}
+	target.mu.Lock()
+	for _, record := range records {
+		if target.writes[record.ID] != 1 {
+			t.Errorf("Expected %s written once, got %d", record.ID, target.writes[record.ID])
+		}
+	}
+	target.mu.Unlock()
+
+	if err := NewBaseOrchestrator("resume-test").ResumeFromCheckpoint(ctx, config); err == nil {
+		t.Error("Expected resuming a completed migration to fail")
+	}
+
+	// Namespaces continue from their own checkpoints
+	fake := fakes.NewPinecone(t, "docs", 2)
+	for _, namespace := range []string{"tenant-a", "tenant-b"} {
+		for i := 0; i < 3; i++ {
+			fake.Seed(namespace, fakes.PineconeVector{ID: fmt.Sprintf("%s-%d", namespace, i), Values: []float32{float32(i), 1}})
+		}
+	}
+	pinecone := &adapters.PineconeAdapter{}
+	if err := pinecone.Connect(ctx, adapters.DBConfig{Type: "pinecone", URL: fake.URL, Index: "docs"}); err != nil {
+		t.Fatalf("Failed to connect source: %v", err)
+	}
+	target.mu.Lock()
+	target.failOn = "tenant-b-1"
+	target.mu.Unlock()
+	config.SourceDB = pinecone
+	config.SourceConfig = adapters.DBConfig{}
+	config.NamespaceRouting = &NamespaceRouting{Namespaces: []string{"tenant-a", "tenant-b"}, Default: NamespaceRoute{Mode: RouteField}}
+
+	o = NewBaseOrchestrator("resume-namespaces")
+	if err := o.Start(ctx, config); err != nil {
+		t.Fatalf("Failed to start: %v", err)
+	}
+	if stats := waitForCompletion(t, o, "resume-namespaces"); !strings.HasPrefix(stats.Status, "failed") {
+		t.Fatalf("Expected the migration to fail in tenant-b, got %+v", stats)
+	}
+
+	target.mu.Lock()
+	target.failOn = ""
+	target.mu.Unlock()
+	o = NewBaseOrchestrator("resume-namespaces")
+	if err := o.ResumeFromCheckpoint(ctx, config); err != nil {
+		t.Fatalf("Failed to resume: %v", err)
+	}
+	stats = waitForCompletion(t, o, "resume-namespaces")
+	if stats.Status != "completed" || stats.MigratedRecords != 6 || stats.Namespaces["tenant-a"].Status != "completed" {
+		t.Fatalf("Unexpected final stats: %+v", stats)
+	}
+	target.mu.Lock()
+	for id, n := range target.writes {
+		if n != 1 {
+			t.Errorf("Expected %s written once, got %d", id, n)
+		}
+	}
+	target.mu.Unlock()
+
+	t.Log("✓ BaseOrchestrator resumes from its checkpoint")
+}
+
+// shiftingSource is a memory source that splits into one more partition
+// each time it is asked, like a source whose ranges move with writes
+type shiftingSource struct {
+	*adapters.MemoryAdapter
+	mu    sync.Mutex
+	calls int
+}
+
+func (m *shiftingSource) Partitions(ctx context.Context, n int) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls++
+	return m.MemoryAdapter.Partitions(ctx, n+m.calls-1)
+}
+
+// TestBaseOrchestrator_ResumePartitions tests that a resumed partitioned
+// migration reads the partitions it was checkpointed with
+func TestBaseOrchestrator_ResumePartitions(t *testing.T) {
+	ctx := context.Background()
+	tracker, err := state.NewSQLiteTracker(filepath.Join(t.TempDir(), "state.db"))
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+	defer tracker.Close()
+
+	source := &shiftingSource{MemoryAdapter: adapters.NewMemoryAdapter()}
+	var records []adapters.Record
+	for i := 0; i < 24; i++ {
+		records = append(records, adapters.Record{ID: fmt.Sprintf("r%02d", i), Vector: []float32{float32(i), 1}})
+	}
+	if err := source.UpsertBatch(ctx, records); err != nil {
+		t.Fatalf("Failed to seed source: %v", err)
+	}
+	target := &flakyTarget{MemoryAdapter: adapters.NewMemoryAdapter(), failOn: "r13", writes: map[string]int{}}
+	config := MigrationConfig{
+		SourceDB:      source,
+		TargetDB:      target,
+		SchemaMapper:  &mockMapper{},
+		StateTracker:  tracker,
+		BatchSize:     2,
+		ValidateEvery: 1,
+		MaxRetries:    1,
+		Partitions:    3,
+	}
+
+	o := NewBaseOrchestrator("resume-partitions")
+	if err := o.Start(ctx, config); err != nil {
+		t.Fatalf("Failed to start: %v", err)
+	}
+	if stats := waitForCompletion(t, o, "resume-partitions"); !strings.HasPrefix(stats.Status, "failed") {
+		t.Fatalf("Expected the migration to fail, got %+v", stats)
+	}
+	checkpoint, _ := tracker.GetCheckpoint("resume-partitions")
+	if len(checkpoint.PartitionKeys) != 3 {
+		t.Fatalf("Expected 3 checkpointed partition keys, got %v", checkpoint.PartitionKeys)
+	}
+
+	// Positions saved for other keys cannot be resumed
+	stale := *checkpoint
+	stale.PartitionKeys = []string{"0/4", "1/4", "2/4", "3/4"}
+	if err := checkResume(&stale, config); err == nil || !strings.Contains(err.Error(), "partition keys") {
+		t.Errorf("Expected a partition key mismatch, got %v", err)
+	}
+
+	target.mu.Lock()
+	target.failOn = ""
+	target.mu.Unlock()
+	o = NewBaseOrchestrator("resume-partitions")
+	if err := o.ResumeFromCheckpoint(ctx, config); err != nil {
+		t.Fatalf("Failed to resume: %v", err)
+	}
+	stats := waitForCompletion(t, o, "resume-partitions")
+	if stats.Status != "completed" || stats.MigratedRecords != 24 || len(stats.Partitions) != 3 {
+		t.Fatalf("Unexpected final stats: %+v", stats)
+	}
+	target.mu.Lock()
+	for _, record := range records {
+		if target.writes[record.ID] != 1 {
+			t.Errorf("Expected %s written once, got %d", record.ID, target.writes[record.ID])
+		}
+	}
+	target.mu.Unlock()
+
+	t.Log("✓ BaseOrchestrator resumes partitions by their checkpointed keys")
+}
+
+// waitForStatus polls until the migration reaches status
+func waitForStatus(t *testing.T, o *BaseOrchestrator, migrationID, status string) *MigrationStats {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		stats, err := o.GetStatus(migrationID)
+		if err != nil {
+			t.Fatalf("Failed to get status: %v", err)
+		}
+		if stats.Status == status {
+			return stats
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("Migration did not reach %s in time", status)
+	return nil
+}
+
+// TestBaseOrchestrator_PauseResume tests parking a migration at a batch
+// boundary and restarting it from the checkpoint saved there
+func TestBaseOrchestrator_PauseResume(t *testing.T) {
+	ctx := context.Background()
+	tracker, err := state.NewSQLiteTracker(filepath.Join(t.TempDir(), "state.db"))
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+	defer tracker.Close()
+
+	source := adapters.NewMemoryAdapter()
+	if err := source.Connect(ctx, adapters.DBConfig{Type: "memory"}); err != nil {
+		t.Fatalf("Failed to connect source: %v", err)
+	}
+	var records []adapters.Record
+	for i := 0; i < 40; i++ {
+		records = append(records, adapters.Record{ID: fmt.Sprintf("r%02d", i), Vector: []float32{float32(i), 1}})
+	}
+	if err := source.UpsertBatch(ctx, records); err != nil {
+		t.Fatalf("Failed to seed source: %v", err)
+	}
+
+	target := &flakyTarget{MemoryAdapter: adapters.NewMemoryAdapter(), delay: 5 * time.Millisecond, writes: map[string]int{}}
+	if err := target.Connect(ctx, adapters.DBConfig{Type: "memory"}); err != nil {
+		t.Fatalf("Failed to connect target: %v", err)
+	}
+
+	// Checkpoints are rarely saved, so only the pause saves the position
+	o := NewBaseOrchestrator("pause-test")
+	if err := o.Start(ctx, MigrationConfig{
+		SourceDB:      source,
+		TargetDB:      target,
+		SchemaMapper:  &mockMapper{},
+		StateTracker:  tracker,
+		BatchSize:     2,
+		ValidateEvery: 100,
+		Writers:       2,
+	}); err != nil {
+		t.Fatalf("Failed to start: %v", err)
+	}
+	if err := o.Resume("pause-test"); err == nil {
+		t.Error("Expected resuming a running migration to fail")
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		stats, _ := o.GetStatus("pause-test")
+		if stats.MigratedRecords >= 6 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Migration made no progress")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if err := o.Pause("pause-test"); err != nil {
+		t.Fatalf("Failed to pause: %v", err)
+	}
+	paused := waitForStatus(t, o, "pause-test", "paused")
+
+	// The worker has exited and the position is persisted
+	o.mu.RLock()
+	running := o.isRunning
+	o.mu.RUnlock()
+	if running {
+		t.Error("Expected the worker to exit when parked")
+	}
+	if migrationState, _ := tracker.GetState("pause-test"); migrationState != state.StatePaused {
+		t.Errorf("Expected the paused state to be persisted, got %s", migrationState)
+	}
+	checkpoint, _ := tracker.GetCheckpoint("pause-test")
+	want := fmt.Sprintf("r%02d", paused.MigratedRecords-1)
+	if checkpoint.LastProcessedID != want || checkpoint.ProcessedCount != paused.MigratedRecords {
+		t.Errorf("Expected a checkpoint after %s with %d records, got %+v", want, paused.MigratedRecords, checkpoint)
+	}
+
+	target.mu.Lock()
+	written := len(target.writes)
+	target.mu.Unlock()
+	time.Sleep(20 * time.Millisecond)
+	target.mu.Lock()
+	if len(target.writes) != written || int64(written) != paused.MigratedRecords {
+		t.Errorf("Expected %d records written while parked, got %d then %d", paused.MigratedRecords, written, len(target.writes))
+	}
+	target.mu.Unlock()
+
+	if err := o.Resume("pause-test"); err != nil {
+		t.Fatalf("Failed to resume: %v", err)
+	}
+	stats := waitForCompletion(t, o, "pause-test")
+	if stats.Status != "completed" || stats.MigratedRecords != 40 || stats.BatchesProcessed != 20 {
+		t.Fatalf("Unexpected final stats: %+v", stats)
+	}
+	target.mu.Lock()
+	for _, record := range records {
+		if target.writes[record.ID] != 1 {
+			t.Errorf("Expected %s written once, got %d", record.ID, target.writes[record.ID])
+		}
+	}
+	target.mu.Unlock()
+	if migrationState, _ := tracker.GetState("pause-test"); migrationState != state.StateCompleted {
+		t.Errorf("Expected the completed state, got %s", migrationState)
+	}
+
+	t.Log("✓ BaseOrchestrator parks paused migrations and resumes them from the checkpoint")
+}
+
+// TestBaseOrchestrator_Park tests that a parked run can be resumed as soon
+// as it reports paused, before its worker has returned
+func TestBaseOrchestrator_Park(t *testing.T) {
+	ctx := context.Background()
+	tracker, err := state.NewSQLiteTracker(filepath.Join(t.TempDir(), "state.db"))
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+	defer tracker.Close()
+
+	source := adapters.NewMemoryAdapter()
+	if err := source.Connect(ctx, adapters.DBConfig{Type: "memory"}); err != nil {
+		t.Fatalf("Failed to connect source: %v", err)
+	}
+	if err := source.UpsertBatch(ctx, []adapters.Record{{ID: "r00", Vector: []float32{0, 1}}}); err != nil {
+		t.Fatalf("Failed to seed source: %v", err)
+	}
+	target := adapters.NewMemoryAdapter()
+	if err := target.Connect(ctx, adapters.DBConfig{Type: "memory"}); err != nil {
+		t.Fatalf("Failed to connect target: %v", err)
+	}
+
+	// A run that was paused and has not parked yet
+	o := NewBaseOrchestrator("park-test")
+	o.config = MigrationConfig{
+		SourceDB:     source,
+		TargetDB:     target,
+		SchemaMapper: &mockMapper{},
+		StateTracker: tracker,
+		BatchSize:    10,
+	}
+	o.parent = ctx
+	o.ctx, o.cancel = context.WithCancel(ctx)
+	o.isRunning, o.isPaused = true, true
+	o.stats = &MigrationStats{Status: "pausing", StartTime: time.Now().Format(time.RFC3339)}
+	runCtx := o.ctx
+
+	o.park()
+	if stats, _ := o.GetStatus("park-test"); stats.Status != "paused" {
+		t.Fatalf("Expected the run to be paused, got %s", stats.Status)
+	}
+	if runCtx.Err() == nil {
+		t.Error("Expected parking to cancel the run")
+	}
+	if err := o.Resume("park-test"); err != nil {
+		t.Fatalf("Failed to resume a parked run: %v", err)
+	}
+	stats := waitForCompletion(t, o, "park-test")
+	if stats.Status != "completed" || stats.MigratedRecords != 1 {
+		t.Fatalf("Unexpected final stats: %+v", stats)
+	}
+
+	t.Log("✓ BaseOrchestrator parked migrations can be resumed at once")
+}
+
+// TestBaseOrchestrator_StopResume tests resuming a migration as soon as
+// Stop returns, without the stopped run touching the resumed one
+func TestBaseOrchestrator_StopResume(t *testing.T) {
+	ctx := context.Background()
+	tracker, err := state.NewSQLiteTracker(filepath.Join(t.TempDir(), "state.db"))
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+	defer tracker.Close()
+
+	source := adapters.NewMemoryAdapter()
+	if err := source.Connect(ctx, adapters.DBConfig{Type: "memory"}); err != nil {
+		t.Fatalf("Failed to connect source: %v", err)
+	}
+	var records []adapters.Record
+	for i := 0; i < 40; i++ {
+		records = append(records, adapters.Record{ID: fmt.Sprintf("r%02d", i), Vector: []float32{float32(i), 1}})
+	}
+	if err := source.UpsertBatch(ctx, records); err != nil {
+		t.Fatalf("Failed to seed source: %v", err)
+	}
+
+	target := &flakyTarget{MemoryAdapter: adapters.NewMemoryAdapter(), delay: 5 * time.Millisecond, writes: map[string]int{}}
+	if err := target.Connect(ctx, adapters.DBConfig{Type: "memory"}); err != nil {
+		t.Fatalf("Failed to connect target: %v", err)
+	}
+
+	config := MigrationConfig{
+		SourceDB:     source,
+		TargetDB:     target,
+		SchemaMapper: &mockMapper{},
+		StateTracker: tracker,
+		BatchSize:    2,
+		Writers:      2,
+	}
+	o := NewBaseOrchestrator("stop-test")
+	if err := o.Start(ctx, config); err != nil {
+		t.Fatalf("Failed to start: %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		stats, _ := o.GetStatus("stop-test")
+		if stats.MigratedRecords >= 6 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Migration made no progress")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if err := o.Stop("stop-test"); err != nil {
+		t.Fatalf("Failed to stop: %v", err)
+	}
+	stopped, _ := o.GetStatus("stop-test")
+	if stopped.Status != "stopped" {
+		t.Errorf("Expected the stopped status to be kept, got %s", stopped.Status)
+	}
+	checkpoint, _ := tracker.GetCheckpoint("stop-test")
+
+	if err := o.ResumeFromCheckpoint(ctx, config); err != nil {
+		t.Fatalf("Failed to resume: %v", err)
+	}
+	stats := waitForCompletion(t, o, "stop-test")
+	if stats.Status != "completed" || stats.MigratedRecords != 40 || stats.BatchesProcessed != 20 {
+		t.Fatalf("Unexpected final stats after resuming from %d records: %+v", checkpoint.ProcessedCount, stats)
+	}
+	if targetStats, _ := target.GetStats(ctx); targetStats.TotalRecords != 40 {
+		t.Errorf("Expected 40 records in the target, got %d", targetStats.TotalRecords)
+	}
+
+	t.Log("✓ BaseOrchestrator stopped migrations can be resumed at once")
+}
@@ -0,0 +1,94 @@
+package orchestrator
+
+import (
+	"fmt"
+
+	"github.com/AlphaTechini/vector-db-migration/internal/adapters"
+	"github.com/AlphaTechini/vector-db-migration/internal/state"
+)
+
+// partitionRun is the state of one partition of a partitioned scan
+type partitionRun struct {
+	key        string
+	stats      *PartitionStats
+	checkpoint state.PartitionCheckpoint
+}
+
+// runPartitions splits the source into partitions and reads up to
+// config.Readers of them at once (default config.Partitions). Partitions
+// already completed in o.partitions are skipped and the rest continue from
+// their checkpointed positions. A resumed run reuses the checkpointed keys
+// rather than splitting the source again, since the split may have moved
+// with writes to the source. Sources that cannot be split are copied in one
+// scan.
+func (o *BaseOrchestrator) runPartitions(source adapters.Partitioned, sourceStats *adapters.DBStats) (bool, error) {
+	o.mu.RLock()
+	keys := o.partitionKeys
+	o.mu.RUnlock()
+
+	if keys == nil {
+		var err error
+		keys, err = source.Partitions(o.ctx, o.config.Partitions)
+		if err != nil {
+			return false, fmt.Errorf("failed to partition source: %v", err)
+		}
+	}
+	if len(keys) == 0 {
+		return o.migrateSource(o.config.SourceDB, o.config.TargetDB, sourceStats, nil)
+	}
+
+	mapping, err := o.mappingFor(o.config.SourceDB, o.config.TargetDB, nil)
+	if err != nil {
+		return false, err
+	}
+
+	scans := make([]*scan, 0, len(keys))
+	runs := make([]*partitionRun, len(keys))
+	o.mu.Lock()
+	o.partitionKeys = keys
+	if o.partitions == nil {
+		o.partitions = make(map[string]state.PartitionCheckpoint, len(keys))
+	}
+	o.stats.Partitions = make(map[string]*PartitionStats, len(keys))
+	for i, key := range keys {
+		run := &partitionRun{
+			key:        key,
+			stats:      &PartitionStats{Status: "pending"},
+			checkpoint: o.partitions[key],
+		}
+		run.stats.MigratedRecords = run.checkpoint.ProcessedCount
+		o.partitions[key] = run.checkpoint
+		o.stats.Partitions[key] = run.stats
+		runs[i] = run
+
+		if run.checkpoint.Completed {
+			run.stats.Status = "completed"
+			continue
+		}
+		partition, err := source.WithPartition(key)
+		if err != nil {
+			o.mu.Unlock()
+			return false, fmt.Errorf("failed to open partition %q: %v", key, err)
+		}
+		scans = append(scans, &scan{
+			source:  partition,
+			part:    run,
+			afterID: run.checkpoint.LastProcessedID,
+			cursor:  run.checkpoint.Cursor,
+		})
+	}
+	o.mu.Unlock()
+
+	done, err := o.runPipeline(o.config.TargetDB, mapping, sourceStats, scans)
+
+	// Partitions interrupted by a pause resume later
+	o.mu.Lock()
+	for _, run := range runs {
+		if run.stats.Status == "in_progress" {
+			run.stats.Status = "pending"
+		}
+	}
+	o.mu.Unlock()
+
+	return done, err
+}
@@ -0,0 +1,464 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/AlphaTechini/vector-db-migration/internal/adapters"
+	"github.com/AlphaTechini/vector-db-migration/internal/mapper"
+	"github.com/AlphaTechini/vector-db-migration/internal/state"
+)
+
+// scan is one sequential read of a source: the whole source, a namespace
+// (ns) or a partition (part). Reads start after afterID or at cursor.
+type scan struct {
+	source adapters.Database
+	ns     *namespaceRun
+	part   *partitionRun
+
+	// Committed position, advanced only past contiguous written batches
+	afterID string
+	cursor  string
+
+	// Commit state, guarded by o.mu: batches written ahead of the next one
+	// to commit, and how the read ended
+	committed int
+	written   map[int]*batch
+	readDone  bool
+	finished  bool
+	read      int
+	completed bool
+}
+
+// batch is one page of a scan moving through the pipeline
+type batch struct {
+	scan    *scan
+	seq     int
+	records []adapters.Record
+	mapped  []adapters.Record
+
+	// Records set aside because they could not be mapped or written, and
+	// the indexes in records of their source records
+	failed   []state.DeadLetter
+	setAside map[int]bool
+
+	// Index in records of the source of each mapped record, or nil if
+	// every record was mapped
+	sources []int
+
+	// Position of the scan after this batch
+	afterID string
+	cursor  string
+}
+
+// committed returns the source records of b that were written, leaving out
+// those set aside
+func (b *batch) committed() []adapters.Record {
+	if len(b.setAside) == 0 {
+		return b.records
+	}
+
+	records := make([]adapters.Record, 0, len(b.records)-len(b.setAside))
+	for i, record := range b.records {
+		if !b.setAside[i] {
+			records = append(records, record)
+		}
+	}
+	return records
+}
+
+// setAsideRecord adds the dead letter of the source record at index i
+func (b *batch) setAsideRecord(i int, letter state.DeadLetter) {
+	if b.setAside == nil {
+		b.setAside = make(map[int]bool)
+	}
+	b.setAside[i] = true
+	b.failed = append(b.failed, letter)
+}
+
+// source returns the index in records of the source of mapped record i
+func (b *batch) source(i int) int {
+	if b.sources == nil {
+		return i
+	}
+	return b.sources[i]
+}
+
+// pipeline copies batches through three stages joined by bounded
+// channels: readers page through scans, mappers map the pages and writers
+// upsert them. A full channel blocks the stage before it, so no stage runs
+// more than a channel's length ahead of the next. Batches are written in
+// any order but committed to the checkpoint in scan order.
+type pipeline struct {
+	o           *BaseOrchestrator
+	ctx         context.Context
+	cancel      context.CancelFunc
+	target      adapters.Database
+	mapping     *mapper.SchemaMapping
+	sourceStats *adapters.DBStats
+
+	provisioned  sync.Once
+	provisionErr error
+
+	errOnce sync.Once
+	err     error
+}
+
+// runPipeline copies scans into target, reading up to config.Readers scans
+// at once, and returns false if the migration was paused or cancelled
+// first. Targets that provision their schema are provisioned from
+// sourceStats before the first write.
+func (o *BaseOrchestrator) runPipeline(target adapters.Database, mapping *mapper.SchemaMapping, sourceStats *adapters.DBStats, scans []*scan) (bool, error) {
+	p := &pipeline{
+		o:           o,
+		target:      target,
+		mapping:     mapping,
+		sourceStats: sourceStats,
+	}
+	p.ctx, p.cancel = context.WithCancel(o.ctx)
+	defer p.cancel()
+
+	readers, mappers, writers := o.workers()
+	if readers > len(scans) {
+		readers = len(scans)
+	}
+
+	pending := make(chan *scan, len(scans))
+	for _, s := range scans {
+		s.written = make(map[int]*batch)
+		pending <- s
+	}
+	close(pending)
+
+	toMap := make(chan *batch, mappers)
+	toWrite := make(chan *batch, writers)
+
+	var readWG, mapWG, writeWG sync.WaitGroup
+	for i := 0; i < readers; i++ {
+		readWG.Add(1)
+		go func() {
+			defer readWG.Done()
+			for s := range pending {
+				if !p.read(s, toMap) {
+					return
+				}
+			}
+		}()
+	}
+	for i := 0; i < mappers; i++ {
+		mapWG.Add(1)
+		go func() {
+			defer mapWG.Done()
+			for b := range toMap {
+				p.mapBatch(b, toWrite)
+			}
+		}()
+	}
+	for i := 0; i < writers; i++ {
+		writeWG.Add(1)
+		go func() {
+			defer writeWG.Done()
+			for b := range toWrite {
+				p.write(b)
+			}
+		}()
+	}
+
+	readWG.Wait()
+	close(toMap)
+	mapWG.Wait()
+	close(toWrite)
+	writeWG.Wait()
+
+	if p.err != nil {
+		return false, p.err
+	}
+	for _, s := range scans {
+		if !s.completed {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// workers returns the number of readers, mappers and writers. Readers
+// default to the partition count, since one scan is read sequentially.
+func (o *BaseOrchestrator) workers() (readers, mappers, writers int) {
+	readers, mappers, writers = o.config.Readers, o.config.Mappers, o.config.Writers
+	if readers == 0 {
+		readers = o.config.Partitions
+	}
+	if readers < 1 {
+		readers = 1
+	}
+	if mappers < 1 {
+		mappers = 1
+	}
+	if writers < 1 {
+		writers = 1
+	}
+	return readers, mappers, writers
+}
+
+// read pages through a scan, sending each batch to the mappers. It returns
+// false if the pipeline was paused, cancelled or failed.
+func (p *pipeline) read(s *scan, out chan<- *batch) bool {
+	o := p.o
+	if s.part != nil {
+		o.mu.Lock()
+		s.part.stats.Status = "in_progress"
+		o.mu.Unlock()
+	}
+
+	// Sources with native continuation tokens are paged by cursor; the
+	// rest resume after the last processed ID
+	batchSize := o.batchSize(s.source, p.target)
+	cursorReader, useCursor := s.source.(adapters.CursorReader)
+	afterID, cursor := s.afterID, s.cursor
+	seq := 0
+	finished := false
+
+	for {
+		// Check if paused or cancelled
+		o.mu.RLock()
+		stop := o.isPaused || p.ctx.Err() != nil
+		o.mu.RUnlock()
+		if stop {
+			break
+		}
+
+		var records []adapters.Record
+		var nextCursor string
+		err := o.retry(p.ctx, func() (err error) {
+			if useCursor {
+				records, nextCursor, err = cursorReader.GetBatchCursor(p.ctx, cursor, batchSize)
+			} else {
+				records, err = s.source.GetBatch(p.ctx, afterID, batchSize)
+			}
+			return err
+		})
+		if err != nil {
+			p.fail(s, fmt.Errorf("failed to get batch %d: %v", seq, err))
+			break
+		}
+
+		if len(records) == 0 {
+			if useCursor && nextCursor != "" {
+				// Empty page mid-stream; keep following the cursor
+				cursor = nextCursor
+				continue
+			}
+
+			// No more records, scan complete
+			finished = true
+			break
+		}
+
+		afterID = records[len(records)-1].ID
+		cursor = nextCursor
+		b := &batch{scan: s, seq: seq, records: records, afterID: afterID, cursor: cursor}
+		select {
+		case out <- b:
+		case <-p.ctx.Done():
+		}
+		seq++
+
+		// An empty cursor after a page means the source is exhausted;
+		// asking again would restart from the beginning
+		if useCursor && cursor == "" {
+			finished = true
+			break
+		}
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	s.readDone, s.finished, s.read = true, finished, seq
+	if err := p.finish(s); err != nil {
+		p.failLocked(s, err)
+	}
+	return finished && p.ctx.Err() == nil
+}
+
+// mapBatch maps a batch to the target schema and sends it to the writers.
+// If the batch fails to map and the state tracker keeps dead letters, the
+// records that fail are set aside and the rest continue.
+func (p *pipeline) mapBatch(b *batch, out chan<- *batch) {
+	if p.ctx.Err() != nil {
+		return
+	}
+
+	mapped, err := p.o.config.SchemaMapper.MapBatch(b.records, p.mapping)
+	if err != nil {
+		if p.o.deadLetters() == nil {
+			p.fail(b.scan, fmt.Errorf("failed to map batch %d: %v", b.seq, err))
+			return
+		}
+		mapped = p.mapRecords(b)
+	}
+	if ns := b.scan.ns; ns != nil {
+		mapped = ns.route.apply(ns.name, mapped)
+	}
+	b.mapped = mapped
+
+	select {
+	case out <- b:
+	case <-p.ctx.Done():
+	}
+}
+
+// write upserts a batch, provisioning the target first, and commits it. If
+// the target rejects the batch and the state tracker keeps dead letters,
+// the rejected records are isolated and set aside.
+func (p *pipeline) write(b *batch) {
+	if p.ctx.Err() != nil {
+		return
+	}
+
+	p.provisioned.Do(func() {
+		p.provisionErr = p.o.provision(p.target, p.sourceStats, b.mapped)
+	})
+	if p.provisionErr != nil {
+		p.fail(b.scan, p.provisionErr)
+		return
+	}
+
+	var err error
+	if len(b.mapped) > 0 {
+		err = p.o.retry(p.ctx, func() error {
+			return p.target.UpsertBatch(p.ctx, b.mapped)
+		})
+	}
+	if err != nil && p.o.deadLetters() != nil {
+		err = p.isolate(b, err)
+	}
+	if err != nil {
+		p.fail(b.scan, fmt.Errorf("failed to upsert batch %d: %v", b.seq, err))
+		return
+	}
+
+	p.o.mu.Lock()
+	defer p.o.mu.Unlock()
+	if err := p.commit(b); err != nil {
+		p.failLocked(b.scan, err)
+	}
+}
+
+// commit records a written batch and advances its scan past every
+// contiguous written batch. Callers must hold o.mu.
+func (p *pipeline) commit(b *batch) error {
+	s := b.scan
+	s.written[b.seq] = b
+
+	for {
+		next, ok := s.written[s.committed]
+		if !ok {
+			break
+		}
+		delete(s.written, s.committed)
+		if err := p.advance(next); err != nil {
+			return err
+		}
+		s.committed++
+	}
+	return p.finish(s)
+}
+
+// advance moves a scan's committed position past b, stores its dead
+// letters, updates progress and saves a checkpoint, so a resumed migration
+// writes no committed batch again. Callers must hold o.mu.
+func (p *pipeline) advance(b *batch) error {
+	o, s := p.o, b.scan
+	s.afterID, s.cursor = b.afterID, b.cursor
+
+	if len(b.failed) > 0 {
+		if err := o.deadLetters().AddDeadLetters(b.failed); err != nil {
+			return fmt.Errorf("failed to store dead letters: %v", err)
+		}
+	}
+	migrated := int64(len(b.records) - len(b.failed))
+
+	if reporter, ok := o.config.SchemaMapper.(mapper.SparseReporter); ok {
+		report := reporter.SparseReport(b.committed(), p.mapping)
+		o.stats.Sparse.Dropped += report.Dropped
+		o.stats.Sparse.Stashed += report.Stashed
+	}
+	o.stats.BatchesProcessed++
+	o.stats.MigratedRecords += migrated
+	o.stats.FailedRecords += int64(len(b.failed))
+
+	checkpointID, checkpointCursor := s.afterID, s.cursor
+	if ns := s.ns; ns != nil {
+		ns.stats.BatchesProcessed++
+		ns.stats.MigratedRecords += migrated
+		ns.checkpoint.LastProcessedID = s.afterID
+		ns.checkpoint.Cursor = s.cursor
+		ns.checkpoint.ProcessedCount = ns.stats.MigratedRecords
+		o.namespaces[ns.name] = ns.checkpoint
+
+		// Namespace positions live in Checkpoint.Namespaces
+		checkpointID, checkpointCursor = "", ""
+	}
+	if part := s.part; part != nil {
+		part.stats.BatchesProcessed++
+		part.stats.MigratedRecords += migrated
+		part.checkpoint.LastProcessedID = s.afterID
+		part.checkpoint.Cursor = s.cursor
+		part.checkpoint.ProcessedCount = part.stats.MigratedRecords
+		o.partitions[part.key] = part.checkpoint
+
+		// Partition positions live in Checkpoint.Partitions
+		checkpointID, checkpointCursor = "", ""
+	}
+	if s.ns == nil && s.part == nil {
+		o.afterID, o.cursor = s.afterID, s.cursor
+	}
+
+	if err := o.saveCheckpoint(checkpointID, checkpointCursor); err != nil {
+		return fmt.Errorf("failed to save checkpoint: %v", err)
+	}
+	return nil
+}
+
+// finish marks a scan completed once it has been read to the end and every
+// batch committed. Completed partitions are checkpointed as such. Callers
+// must hold o.mu.
+func (p *pipeline) finish(s *scan) error {
+	if s.completed || !s.readDone || !s.finished || s.committed < s.read {
+		return nil
+	}
+	s.completed = true
+
+	part := s.part
+	if part == nil {
+		return nil
+	}
+	part.stats.Status = "completed"
+	part.checkpoint.Cursor = ""
+	part.checkpoint.Completed = true
+	p.o.partitions[part.key] = part.checkpoint
+	if err := p.o.saveCheckpoint("", ""); err != nil {
+		return fmt.Errorf("failed to save checkpoint: %v", err)
+	}
+	return nil
+}
+
+// fail records the pipeline's first error and stops every stage
+func (p *pipeline) fail(s *scan, err error) {
+	p.o.mu.Lock()
+	defer p.o.mu.Unlock()
+	p.failLocked(s, err)
+}
+
+// failLocked is fail for callers holding o.mu
+func (p *pipeline) failLocked(s *scan, err error) {
+	if s.part != nil {
+		s.part.stats.Status = "failed"
+		err = fmt.Errorf("partition %q: %v", s.part.key, err)
+	}
+	p.errOnce.Do(func() {
+		p.err = err
+		p.cancel()
+	})
+}
@@ -0,0 +1,64 @@
+package orchestrator
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/AlphaTechini/vector-db-migration/internal/adapters"
+	"github.com/AlphaTechini/vector-db-migration/internal/mapper"
+)
+
+// provision creates the target's collection, if the target supports it,
+// from the source's stats and the first mapped batch
+func (o *BaseOrchestrator) provision(target adapters.Database, sourceStats *adapters.DBStats, records []adapters.Record) error {
+	provisioner, ok := target.(adapters.SchemaProvisioner)
+	if !ok {
+		return nil
+	}
+
+	if err := provisioner.EnsureCollection(o.ctx, collectionSpec(sourceStats, records)); err != nil {
+		return fmt.Errorf("failed to provision target: %v", err)
+	}
+	return nil
+}
+
+// collectionSpec derives a collection spec from mapped records. Vector
+// shapes come from the records as the target will receive them; distance
+// and index parameters come from the source.
+func collectionSpec(sourceStats *adapters.DBStats, records []adapters.Record) adapters.CollectionSpec {
+	spec := adapters.CollectionSpec{
+		Properties: mapper.InferProperties(records),
+	}
+	if sourceStats != nil {
+		spec.Distance = sourceStats.Distance
+		spec.HNSW = sourceStats.HNSW
+	}
+
+	sparse := make(map[string]bool)
+	for _, record := range records {
+		if spec.Dimensions == 0 && len(record.Vector) > 0 {
+			spec.Dimensions = len(record.Vector)
+		}
+		for name, vector := range record.NamedVectors {
+			if spec.NamedVectors == nil {
+				spec.NamedVectors = make(map[string]int)
+			}
+			if _, ok := spec.NamedVectors[name]; !ok {
+				spec.NamedVectors[name] = len(vector)
+			}
+		}
+		for name := range record.SparseVectors {
+			sparse[name] = true
+		}
+	}
+
+	for name := range sparse {
+		spec.SparseVectors = append(spec.SparseVectors, name)
+	}
+	sort.Strings(spec.SparseVectors)
+
+	if spec.Dimensions == 0 && len(spec.NamedVectors) == 0 && sourceStats != nil {
+		spec.Dimensions = sourceStats.Dimensions
+	}
+	return spec
+}
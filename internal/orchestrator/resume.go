@@ -0,0 +1,158 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/AlphaTechini/vector-db-migration/internal/adapters"
+	"github.com/AlphaTechini/vector-db-migration/internal/state"
+)
+
+// ResumeFromCheckpoint continues a migration that crashed, failed, was
+// stopped or was paused from its last checkpoint. It restores the scan
+// positions of the source, its namespaces or partitions, the progress
+// counters and the schema mapping. A checkpoint is saved after every
+// committed batch, so only batches that were being written when the
+// migration stopped are written again. The checkpoint must have been saved
+// with the same source, target, namespace mode and partition count as
+// config.
+func (o *BaseOrchestrator) ResumeFromCheckpoint(ctx context.Context, config MigrationConfig) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.isRunning {
+		return fmt.Errorf("migration already running")
+	}
+
+	migrationState, err := config.StateTracker.GetState(o.migrationID)
+	if err != nil {
+		return fmt.Errorf("failed to get state: %w", err)
+	}
+	switch migrationState {
+	case state.StateCompleted, state.StateRolledBack:
+		return fmt.Errorf("migration %s is %s", o.migrationID, migrationState)
+	}
+
+	checkpoint, err := config.StateTracker.GetCheckpoint(o.migrationID)
+	if err != nil {
+		return fmt.Errorf("failed to load checkpoint: %w", err)
+	}
+	if checkpoint == nil {
+		return fmt.Errorf("no checkpoint for migration %s", o.migrationID)
+	}
+	if err := checkResume(checkpoint, config); err != nil {
+		return fmt.Errorf("cannot resume migration %s: %w", o.migrationID, err)
+	}
+
+	o.config = config
+	o.parent = ctx
+	o.ctx, o.cancel = context.WithCancel(ctx)
+	o.done = make(chan struct{})
+	o.isRunning = true
+	o.isPaused = false
+
+	// Totals are counted again from the source
+	o.stats = &MigrationStats{
+		MigratedRecords:  checkpoint.ProcessedCount,
+		FailedRecords:    checkpoint.FailedCount,
+		BatchesProcessed: checkpoint.BatchesProcessed,
+		Status:           "in_progress",
+		StartTime:        checkpoint.StartedAt.Format(time.RFC3339),
+	}
+	o.afterID, o.cursor = checkpoint.LastProcessedID, checkpoint.Cursor
+	o.schemaMapping = checkpoint.SchemaMapping
+	o.namespaces, o.partitions = nil, nil
+	o.partitionKeys = checkpoint.PartitionKeys
+	if len(checkpoint.Namespaces) > 0 {
+		o.namespaces = make(map[string]state.NamespaceCheckpoint, len(checkpoint.Namespaces))
+		for name, ns := range checkpoint.Namespaces {
+			o.namespaces[name] = ns
+		}
+	}
+	if len(checkpoint.Partitions) > 0 {
+		o.partitions = make(map[string]state.PartitionCheckpoint, len(checkpoint.Partitions))
+		for key, part := range checkpoint.Partitions {
+			o.partitions[key] = part
+		}
+	}
+
+	if err := config.StateTracker.SetState(o.migrationID, state.StateInProgress); err != nil {
+		return fmt.Errorf("failed to update state: %w", err)
+	}
+
+	// Continue migration in background
+	go o.runMigration()
+
+	return nil
+}
+
+// checkResume returns an error if config does not continue the migration
+// checkpoint was saved by
+func checkResume(checkpoint *state.Checkpoint, config MigrationConfig) error {
+	endpoints := []struct {
+		role     string
+		saved    *state.Endpoint
+		resuming *state.Endpoint
+	}{
+		{"source", checkpoint.Source, endpoint(config.SourceConfig)},
+		{"target", checkpoint.Target, endpoint(config.TargetConfig)},
+	}
+	for _, e := range endpoints {
+		if e.saved != nil && e.resuming != nil && *e.saved != *e.resuming {
+			return fmt.Errorf("%s %s does not match the checkpoint's %s", e.role, e.resuming, e.saved)
+		}
+	}
+
+	byNamespace := config.NamespaceRouting != nil
+	switch {
+	case byNamespace && (checkpoint.LastProcessedID != "" || checkpoint.Cursor != "" || len(checkpoint.Partitions) > 0):
+		return fmt.Errorf("the checkpoint is of a migration that did not copy by namespace")
+	case !byNamespace && len(checkpoint.Namespaces) > 0:
+		return fmt.Errorf("the checkpoint is of a multi-namespace migration")
+	}
+
+	// Partition keys depend on the partition count
+	partitions := config.Partitions
+	if partitions <= 1 {
+		partitions = 0
+	}
+	if checkpoint.PartitionCount != partitions {
+		return fmt.Errorf("the checkpoint was saved with %d partitions, not %d", checkpoint.PartitionCount, config.Partitions)
+	}
+
+	// Partition positions are only meaningful for the keys they were
+	// saved with
+	keys := make(map[string]bool, len(checkpoint.PartitionKeys))
+	for _, key := range checkpoint.PartitionKeys {
+		keys[key] = true
+	}
+	for key := range checkpoint.Partitions {
+		if !keys[key] {
+			return fmt.Errorf("the checkpoint's partition %q is not one of its partition keys", key)
+		}
+	}
+	return nil
+}
+
+// endpoint identifies a database for checkpoints, or returns nil if config
+// is empty. User info is stripped from the URL, since it may hold a
+// password.
+func endpoint(config adapters.DBConfig) *state.Endpoint {
+	if config.Type == "" {
+		return nil
+	}
+
+	address := config.URL
+	if parsed, err := url.Parse(address); err == nil && parsed.User != nil {
+		parsed.User = nil
+		address = parsed.String()
+	}
+	return &state.Endpoint{
+		Type:      config.Type,
+		URL:       address,
+		Index:     config.Index,
+		Namespace: config.Extra["namespace"],
+	}
+}
@@ -0,0 +1,103 @@
+package orchestrator
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/AlphaTechini/vector-db-migration/internal/adapters"
+)
+
+const (
+	// defaultRetryBackoff is the wait before the first retry
+	defaultRetryBackoff = 500 * time.Millisecond
+
+	// defaultMaxRetryBackoff caps the wait between retries
+	defaultMaxRetryBackoff = 30 * time.Second
+)
+
+// RetryPolicy configures Retry
+type RetryPolicy struct {
+	MaxRetries int           // Retries after the first call
+	Backoff    time.Duration // Wait before the first retry (default: 500ms)
+	MaxBackoff time.Duration // Cap on the wait between retries (default: 30s)
+
+	// OnRetry, if set, is called before each wait with the kind of the
+	// error being retried
+	OnRetry func(kind adapters.ErrorKind)
+}
+
+// Retry calls fn until it succeeds, fails with an error that is not
+// retryable, or has been retried policy.MaxRetries times, and returns its
+// last error. Waits double from policy.Backoff with jitter; a rate-limited
+// call waits at least as long as the database asked.
+func Retry(ctx context.Context, policy RetryPolicy, fn func() error) error {
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if err == nil || ctx.Err() != nil {
+			return err
+		}
+
+		kind, retryAfter := adapters.ClassifyError(err)
+		if kind == adapters.ErrorPermanent || attempt >= policy.MaxRetries {
+			return err
+		}
+
+		wait := policy.backoff(attempt)
+		if kind == adapters.ErrorRateLimited && retryAfter > wait {
+			wait = retryAfter
+		}
+
+		if policy.OnRetry != nil {
+			policy.OnRetry(kind)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		}
+	}
+}
+
+// retry retries fn with the migration's retry settings, counting retries
+// in its stats
+func (o *BaseOrchestrator) retry(ctx context.Context, fn func() error) error {
+	return Retry(ctx, RetryPolicy{
+		MaxRetries: o.config.MaxRetries,
+		Backoff:    o.config.RetryBackoff,
+		MaxBackoff: o.config.MaxRetryBackoff,
+		OnRetry: func(kind adapters.ErrorKind) {
+			o.mu.Lock()
+			o.stats.Retries++
+			if kind == adapters.ErrorRateLimited {
+				o.stats.RateLimited++
+			}
+			o.mu.Unlock()
+		},
+	}, fn)
+}
+
+// backoff returns the wait before retry attempt+1: the base backoff
+// doubled per attempt, capped, and jittered down by up to half
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	base := p.Backoff
+	if base <= 0 {
+		base = defaultRetryBackoff
+	}
+	max := p.MaxBackoff
+	if max <= 0 {
+		max = defaultMaxRetryBackoff
+	}
+
+	wait := base
+	for i := 0; i < attempt && wait < max; i++ {
+		wait *= 2
+	}
+	if wait > max {
+		wait = max
+	}
+	return wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+}
@@ -0,0 +1,150 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AlphaTechini/vector-db-migration/internal/adapters"
+)
+
+const (
+	// defaultSearchK is the number of neighbours compared per query
+	defaultSearchK = 10
+
+	// defaultSearchQueries is the number of source vectors used as queries
+	defaultSearchQueries = 20
+)
+
+// SearchComparison measures how closely the target's nearest-neighbour
+// results reproduce the source's for the same query vectors
+type SearchComparison struct {
+	// Queries is the number of queries the source returned results for
+	Queries int `json:"queries"`
+	K       int `json:"k"`
+
+	// RecallAtK is the mean fraction of the source's top k that the
+	// target's top k also contains; MinRecall is the worst query's
+	RecallAtK float64 `json:"recall_at_k"`
+	MinRecall float64 `json:"min_recall"`
+
+	// RankOverlap is the mean average overlap of the two rankings: the
+	// overlap of their top d, averaged over every depth d up to k. Unlike
+	// recall it drops when the same records come back in a different order.
+	RankOverlap float64 `json:"rank_overlap"`
+}
+
+// CompareSearch runs each query against source and target and compares
+// the top k results by record ID. Queries the source has no results for
+// are not scored.
+func CompareSearch(ctx context.Context, source, target adapters.Searcher, queries [][]float32, k int, filter adapters.Filter) (*SearchComparison, error) {
+	comparison := &SearchComparison{K: k, MinRecall: 1}
+	for i, query := range queries {
+		expected, err := source.Search(ctx, query, k, filter)
+		if err != nil {
+			return nil, fmt.Errorf("source search %d failed: %v", i, err)
+		}
+		if len(expected) == 0 {
+			continue
+		}
+		actual, err := target.Search(ctx, query, k, filter)
+		if err != nil {
+			return nil, fmt.Errorf("target search %d failed: %v", i, err)
+		}
+
+		recall := recallAtK(expected, actual)
+		comparison.Queries++
+		comparison.RecallAtK += recall
+		comparison.RankOverlap += rankOverlap(expected, actual, k)
+		if recall < comparison.MinRecall {
+			comparison.MinRecall = recall
+		}
+	}
+
+	if comparison.Queries == 0 {
+		comparison.MinRecall = 0
+		return comparison, nil
+	}
+	comparison.RecallAtK /= float64(comparison.Queries)
+	comparison.RankOverlap /= float64(comparison.Queries)
+	return comparison, nil
+}
+
+// recallAtK returns the fraction of expected IDs found in actual
+func recallAtK(expected, actual []adapters.SearchResult) float64 {
+	found := make(map[string]bool, len(actual))
+	for _, r := range actual {
+		found[r.ID] = true
+	}
+
+	hits := 0
+	for _, r := range expected {
+		if found[r.ID] {
+			hits++
+		}
+	}
+	return float64(hits) / float64(len(expected))
+}
+
+// rankOverlap returns the average overlap of two rankings over depths 1..k.
+// Depths beyond the shorter ranking count the missing ranks as misses.
+func rankOverlap(expected, actual []adapters.SearchResult, k int) float64 {
+	if k > len(expected) {
+		k = len(expected)
+	}
+
+	seenExpected := make(map[string]bool, k)
+	seenActual := make(map[string]bool, k)
+	shared, total := 0, 0.0
+	for d := 0; d < k; d++ {
+		id := expected[d].ID
+		seenExpected[id] = true
+		if seenActual[id] {
+			shared++
+		}
+		if d < len(actual) {
+			other := actual[d].ID
+			seenActual[other] = true
+			if seenExpected[other] {
+				shared++
+			}
+		}
+		total += float64(shared) / float64(d+1)
+	}
+	return total / float64(k)
+}
+
+// compareSearch compares nearest-neighbour results of the configured source
+// and target, querying with the vectors of the source's first records. It
+// returns nil if either database cannot search.
+func (o *BaseOrchestrator) compareSearch(ctx context.Context) (*SearchComparison, error) {
+	source, ok := o.config.SourceDB.(adapters.Searcher)
+	if !ok {
+		return nil, nil
+	}
+	target, ok := o.config.TargetDB.(adapters.Searcher)
+	if !ok {
+		return nil, nil
+	}
+
+	k := o.config.SearchK
+	if k == 0 {
+		k = defaultSearchK
+	}
+	n := o.config.SearchQueries
+	if n == 0 {
+		n = defaultSearchQueries
+	}
+
+	sample, err := o.config.SourceDB.GetBatch(ctx, "", n)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sample query vectors: %v", err)
+	}
+	queries := make([][]float32, 0, len(sample))
+	for _, record := range sample {
+		if len(record.Vector) > 0 {
+			queries = append(queries, record.Vector)
+		}
+	}
+
+	return CompareSearch(ctx, source, target, queries, k, o.config.SearchFilter)
+}
@@ -15,6 +15,7 @@ type MigrationState string
 const (
 	StateNotStarted   MigrationState = "not_started"
 	StateInProgress   MigrationState = "in_progress"
+	StatePaused       MigrationState = "paused"
 	StateCompleted    MigrationState = "completed"
 	StateRolledBack   MigrationState = "rolled_back"
 	StateFailed       MigrationState = "failed"
@@ -24,13 +25,61 @@ const (
 type Checkpoint struct {
 	MigrationID        string                 `json:"migration_id"`
 	LastProcessedID    string                 `json:"last_processed_id"`
+	Cursor             string                 `json:"cursor,omitempty"` // Opaque source pagination token, for cursor-paged sources
 	TotalRecords       int64                  `json:"total_records"`
 	ProcessedCount     int64                  `json:"processed_count"`
 	FailedCount        int64                  `json:"failed_count"`
+	BatchesProcessed   int64                  `json:"batches_processed,omitempty"`
 	StartedAt          time.Time              `json:"started_at"`
 	LastCheckpointAt   time.Time              `json:"last_checkpoint_at"`
 	SchemaMapping      map[string]interface{} `json:"schema_mapping,omitempty"`
 	ValidationStats    ValidationStats        `json:"validation_stats,omitempty"`
+	Namespaces         map[string]NamespaceCheckpoint `json:"namespaces,omitempty"` // Per-namespace progress of multi-namespace migrations
+	Partitions         map[string]PartitionCheckpoint `json:"partitions,omitempty"` // Per-partition progress of partitioned scans, by partition key
+	PartitionCount     int                    `json:"partition_count,omitempty"` // Partitions requested; partition keys depend on it
+	PartitionKeys      []string               `json:"partition_keys,omitempty"`  // Partition keys in scan order, reused on resume since a source may split differently later
+	
+	// Source and Target identify the databases, so a resumed migration
+	// can be checked against the one it continues
+	Source *Endpoint `json:"source,omitempty"`
+	Target *Endpoint `json:"target,omitempty"`
+}
+
+// Endpoint identifies a source or target database, without credentials
+type Endpoint struct {
+	Type      string `json:"type"`
+	URL       string `json:"url"`
+	Index     string `json:"index"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// String returns the endpoint as type:url/index[/namespace]
+func (e Endpoint) String() string {
+	s := fmt.Sprintf("%s:%s/%s", e.Type, e.URL, e.Index)
+	if e.Namespace != "" {
+		s += "/" + e.Namespace
+	}
+	return s
+}
+
+// NamespaceCheckpoint tracks progress through one source namespace
+type NamespaceCheckpoint struct {
+	LastProcessedID string `json:"last_processed_id,omitempty"`
+	Cursor          string `json:"cursor,omitempty"`
+	TotalRecords    int64  `json:"total_records"`
+	ProcessedCount  int64  `json:"processed_count"`
+	Completed       bool   `json:"completed"`
+	
+	// SchemaMapping is the mapping the namespace is copied with
+	SchemaMapping map[string]interface{} `json:"schema_mapping,omitempty"`
+}
+
+// PartitionCheckpoint tracks progress through one partition of the source
+type PartitionCheckpoint struct {
+	LastProcessedID string `json:"last_processed_id,omitempty"`
+	Cursor          string `json:"cursor,omitempty"`
+	ProcessedCount  int64  `json:"processed_count"`
+	Completed       bool   `json:"completed"`
 }
 
 // ValidationStats tracks validation metrics
@@ -41,6 +90,24 @@ type ValidationStats struct {
 	MaxCosineSimilarity float64 `json:"max_cosine_similarity"`
 }
 
+// Dead-letter stages: where in the pipeline a record failed
+const (
+	DeadLetterMap    = "map"
+	DeadLetterUpsert = "upsert"
+)
+
+// DeadLetter is a record a migration set aside because it could not be
+// mapped or written
+type DeadLetter struct {
+	MigrationID string          `json:"migration_id"`
+	RecordID    string          `json:"record_id"`
+	Namespace   string          `json:"namespace,omitempty"` // Source namespace, for multi-namespace migrations
+	Stage       string          `json:"stage"`               // DeadLetterMap or DeadLetterUpsert
+	Error       string          `json:"error"`
+	Record      json.RawMessage `json:"record"`              // The source record for map failures, the mapped record for upsert failures
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
 // StateTracker interface for persisting and retrieving migration state
 type StateTracker interface {
 	// GetState returns the current state of a migration
@@ -68,6 +135,20 @@ type StateTracker interface {
 	GetMigrationSummary(migrationID string) (*Checkpoint, error)
 }
 
+// DeadLetterStore is implemented by trackers that keep dead letters. Adding
+// a letter for a record already stored replaces it.
+type DeadLetterStore interface {
+	// AddDeadLetters stores records that failed
+	AddDeadLetters(letters []DeadLetter) error
+	
+	// ListDeadLetters returns a migration's dead letters, oldest first; a
+	// limit of 0 returns all of them
+	ListDeadLetters(migrationID string, limit, offset int) ([]DeadLetter, error)
+	
+	// DeleteDeadLetters removes dead letters, e.g. once a retry succeeded
+	DeleteDeadLetters(letters []DeadLetter) error
+}
+
 // SQLiteTracker implements StateTracker using SQLite
 type SQLiteTracker struct {
 	db *sql.DB
@@ -107,6 +188,17 @@ func createTables(db *sql.DB) error {
 		FOREIGN KEY (migration_id) REFERENCES migrations(migration_id) ON DELETE CASCADE
 	);
 
+	CREATE TABLE IF NOT EXISTS dead_letters (
+		migration_id TEXT NOT NULL,
+		namespace TEXT NOT NULL DEFAULT '',
+		record_id TEXT NOT NULL,
+		stage TEXT NOT NULL,
+		error TEXT NOT NULL,
+		record_data TEXT NOT NULL,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (migration_id, namespace, record_id)
+	);
+
 	CREATE INDEX IF NOT EXISTS idx_migrations_state ON migrations(state);
 	`
 
@@ -257,5 +349,86 @@ func (t *SQLiteTracker) GetMigrationSummary(migrationID string) (*Checkpoint, er
 	return t.GetCheckpoint(migrationID)
 }
 
+// AddDeadLetters stores records that failed, replacing earlier letters for
+// the same records
+func (t *SQLiteTracker) AddDeadLetters(letters []DeadLetter) error {
+	tx, err := t.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+	INSERT INTO dead_letters (migration_id, namespace, record_id, stage, error, record_data, created_at)
+	VALUES (?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+	ON CONFLICT(migration_id, namespace, record_id) DO UPDATE SET
+		stage = excluded.stage,
+		error = excluded.error,
+		record_data = excluded.record_data,
+		created_at = CURRENT_TIMESTAMP
+	`
+
+	for _, letter := range letters {
+		_, err := tx.Exec(query, letter.MigrationID, letter.Namespace, letter.RecordID, letter.Stage, letter.Error, string(letter.Record))
+		if err != nil {
+			return fmt.Errorf("failed to add dead letter %s: %w", letter.RecordID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit dead letters: %w", err)
+	}
+	return nil
+}
+
+// ListDeadLetters returns a migration's dead letters, oldest first
+func (t *SQLiteTracker) ListDeadLetters(migrationID string, limit, offset int) ([]DeadLetter, error) {
+	if limit <= 0 {
+		limit = -1 // SQLite: no limit
+	}
+
+	query := `
+	SELECT migration_id, namespace, record_id, stage, error, record_data, created_at
+	FROM dead_letters WHERE migration_id = ?
+	ORDER BY created_at, rowid LIMIT ? OFFSET ?
+	`
+
+	rows, err := t.db.Query(query, migrationID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dead letters: %w", err)
+	}
+	defer rows.Close()
+
+	var letters []DeadLetter
+	for rows.Next() {
+		var letter DeadLetter
+		var record string
+		if err := rows.Scan(&letter.MigrationID, &letter.Namespace, &letter.RecordID, &letter.Stage, &letter.Error, &record, &letter.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan dead letter: %w", err)
+		}
+		letter.Record = json.RawMessage(record)
+		letters = append(letters, letter)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list dead letters: %w", err)
+	}
+
+	return letters, nil
+}
+
+// DeleteDeadLetters removes dead letters
+func (t *SQLiteTracker) DeleteDeadLetters(letters []DeadLetter) error {
+	query := `DELETE FROM dead_letters WHERE migration_id = ? AND namespace = ? AND record_id = ?`
+	for _, letter := range letters {
+		if _, err := t.db.Exec(query, letter.MigrationID, letter.Namespace, letter.RecordID); err != nil {
+			return fmt.Errorf("failed to delete dead letter %s: %w", letter.RecordID, err)
+		}
+	}
+	return nil
+}
+
 // Ensure SQLiteTracker implements StateTracker interface
 var _ StateTracker = (*SQLiteTracker)(nil)
+
+// Ensure SQLiteTracker implements DeadLetterStore interface
+var _ DeadLetterStore = (*SQLiteTracker)(nil)
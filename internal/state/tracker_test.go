@@ -1,7 +1,9 @@
 package state
 
 import (
+	"encoding/json"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 )
@@ -196,3 +198,55 @@ func TestSQLiteTracker_MultipleMigrations(t *testing.T) {
 		}
 	}
 }
+
+func TestSQLiteTracker_DeadLetters(t *testing.T) {
+	tracker, err := NewSQLiteTracker(filepath.Join(t.TempDir(), "dlq.db"))
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+	defer tracker.Close()
+
+	letters := []DeadLetter{
+		{MigrationID: "mig-1", RecordID: "a", Stage: DeadLetterMap, Error: "bad type", Record: json.RawMessage(`{"id":"a"}`)},
+		{MigrationID: "mig-1", RecordID: "b", Stage: DeadLetterUpsert, Error: "rejected", Record: json.RawMessage(`{"id":"b"}`)},
+		{MigrationID: "mig-1", RecordID: "a", Namespace: "tenant", Stage: DeadLetterMap, Error: "bad type", Record: json.RawMessage(`{"id":"a"}`)},
+		{MigrationID: "mig-2", RecordID: "c", Stage: DeadLetterUpsert, Error: "rejected", Record: json.RawMessage(`{"id":"c"}`)},
+	}
+	if err := tracker.AddDeadLetters(letters); err != nil {
+		t.Fatalf("Failed to add dead letters: %v", err)
+	}
+
+	// Adding a record again replaces its letter
+	retried := letters[1]
+	retried.Error = "rejected again"
+	if err := tracker.AddDeadLetters([]DeadLetter{retried}); err != nil {
+		t.Fatalf("Failed to replace dead letter: %v", err)
+	}
+
+	got, err := tracker.ListDeadLetters("mig-1", 0, 0)
+	if err != nil {
+		t.Fatalf("Failed to list dead letters: %v", err)
+	}
+	if len(got) != 3 || got[0].RecordID != "a" || got[1].Error != "rejected again" || got[2].Namespace != "tenant" {
+		t.Fatalf("Unexpected dead letters: %+v", got)
+	}
+	if string(got[0].Record) != `{"id":"a"}` || got[0].CreatedAt.IsZero() {
+		t.Errorf("Expected the stored record and time, got %+v", got[0])
+	}
+
+	page, err := tracker.ListDeadLetters("mig-1", 1, 1)
+	if err != nil || len(page) != 1 || page[0].RecordID != "b" {
+		t.Errorf("Expected the second letter, got %+v (%v)", page, err)
+	}
+
+	if err := tracker.DeleteDeadLetters(got[:2]); err != nil {
+		t.Fatalf("Failed to delete dead letters: %v", err)
+	}
+	got, _ = tracker.ListDeadLetters("mig-1", 0, 0)
+	if len(got) != 1 || got[0].Namespace != "tenant" {
+		t.Errorf("Expected only the namespaced letter left, got %+v", got)
+	}
+	if other, _ := tracker.ListDeadLetters("mig-2", 0, 0); len(other) != 1 {
+		t.Errorf("Expected other migrations untouched, got %+v", other)
+	}
+}